@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kizuna-org/akari/internal/config"
+)
+
+// printConfig shows the effective configuration with secrets redacted, then
+// lists every validation problem. It exits non-zero when there are any, so
+// it doubles as a preflight check.
+func printConfig() int {
+	// Values that fail to parse are printed as zero and reported by Load.
+	cfg, _ := config.Read()
+
+	err := config.Print(os.Stdout, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return exitInvalid
+	}
+
+	_, err = config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+
+		return exitInvalid
+	}
+
+	return 0
+}
@@ -1,7 +1,32 @@
 package main
 
-import "github.com/kizuna-org/akari/internal/app"
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kizuna-org/akari/internal/app"
+)
+
+const (
+	exitInvalid = 1
+	exitUsage   = 2
+)
 
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(run(os.Args[1:]))
+	}
+
 	app.New().Run()
 }
+
+func run(args []string) int {
+	if strings.Join(args, " ") == "config print" {
+		return printConfig()
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\nusage: akari [config print]\n", strings.Join(args, " "))
+
+	return exitUsage
+}
@@ -1,3 +1,3 @@
 package ent
 
-//go:generate go tool ent generate --target ../gen/ent ./schema
+//go:generate go tool ent generate --feature sql/upsert --target ../gen/ent ./schema
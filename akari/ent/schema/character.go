@@ -0,0 +1,37 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// Character is a persona hosted by akari. Its ID is the kiseki character ID.
+type Character struct {
+	ent.Schema
+}
+
+func (Character) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			NotEmpty().
+			Immutable(),
+		field.String("name").
+			NotEmpty(),
+		field.Text("persona").
+			Default(""),
+		field.Text("prompt_template").
+			Default(""),
+		field.Strings("channel_ids").
+			Default([]string{}),
+		field.Strings("tools").
+			Default([]string{}),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
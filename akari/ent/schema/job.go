@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Job is work scheduled to run at a time, such as delivering a reminder.
+// An instance leases a due job until lease_expires_at so that no other
+// instance runs it meanwhile.
+type Job struct {
+	ent.Schema
+}
+
+func (Job) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(newJobID).
+			Immutable(),
+		field.String("kind").
+			NotEmpty().
+			Immutable(),
+		field.Text("payload").
+			Immutable(),
+		field.Enum("status").
+			Values("pending", "done", "failed").
+			Default("pending"),
+		field.Time("run_at"),
+		field.Int("attempts").
+			Default(0),
+		field.Text("last_error").
+			Default(""),
+		field.String("lease_owner").
+			Default(""),
+		field.Time("lease_expires_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+func (Job) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("status", "run_at"),
+	}
+}
+
+// newJobID returns a time-ordered UUIDv7.
+func newJobID() uuid.UUID {
+	return uuid.Must(uuid.NewV7())
+}
@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Turn is one exchange between a user and a character: the message, the
+// files attached to it, the reply and the memories recalled to write it.
+// Turns are never edited.
+type Turn struct {
+	ent.Schema
+}
+
+func (Turn) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(newTurnID).
+			Immutable(),
+		field.String("character_id").
+			Immutable(),
+		field.String("guild_id").
+			Default("").
+			Immutable(),
+		field.String("channel_id").
+			NotEmpty().
+			Immutable(),
+		field.String("author_id").
+			NotEmpty().
+			Immutable(),
+		field.String("author_name").
+			Immutable(),
+		field.Text("message").
+			Immutable(),
+		field.Text("reply").
+			Immutable(),
+		field.Strings("memories").
+			Default([]string{}).
+			Immutable(),
+		field.JSON("attachments", []Attachment{}).
+			Default([]Attachment{}).
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Attachment describes a file attached to a turn's message. Only the
+// metadata is kept; the file stays on Discord.
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int    `json:"size"`
+	URL         string `json:"url"`
+}
+
+func (Turn) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("author_id", "created_at"),
+		index.Fields("channel_id", "created_at"),
+	}
+}
+
+// newTurnID returns a time-ordered UUIDv7, like kiseki's IDs.
+func newTurnID() uuid.UUID {
+	return uuid.Must(uuid.NewV7())
+}
@@ -4,8 +4,11 @@ package ent
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
 	"github.com/kizuna-org/akari/gen/ent/appstate"
@@ -16,6 +19,33 @@ type AppStateCreate struct {
 	config
 	mutation *AppStateMutation
 	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetKey sets the "key" field.
+func (_c *AppStateCreate) SetKey(v string) *AppStateCreate {
+	_c.mutation.SetKey(v)
+	return _c
+}
+
+// SetValue sets the "value" field.
+func (_c *AppStateCreate) SetValue(v string) *AppStateCreate {
+	_c.mutation.SetValue(v)
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *AppStateCreate) SetUpdatedAt(v time.Time) *AppStateCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *AppStateCreate) SetNillableUpdatedAt(v *time.Time) *AppStateCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
 }
 
 // Mutation returns the AppStateMutation object of the builder.
@@ -25,6 +55,7 @@ func (_c *AppStateCreate) Mutation() *AppStateMutation {
 
 // Save creates the AppState in the database.
 func (_c *AppStateCreate) Save(ctx context.Context) (*AppState, error) {
+	_c.defaults()
 	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
 }
 
@@ -50,8 +81,30 @@ func (_c *AppStateCreate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (_c *AppStateCreate) defaults() {
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := appstate.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (_c *AppStateCreate) check() error {
+	if _, ok := _c.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "AppState.key"`)}
+	}
+	if v, ok := _c.mutation.Key(); ok {
+		if err := appstate.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "AppState.key": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Value(); !ok {
+		return &ValidationError{Name: "value", err: errors.New(`ent: missing required field "AppState.value"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "AppState.updated_at"`)}
+	}
 	return nil
 }
 
@@ -78,14 +131,207 @@ func (_c *AppStateCreate) createSpec() (*AppState, *sqlgraph.CreateSpec) {
 		_node = &AppState{config: _c.config}
 		_spec = sqlgraph.NewCreateSpec(appstate.Table, sqlgraph.NewFieldSpec(appstate.FieldID, field.TypeInt))
 	)
+	_spec.OnConflict = _c.conflict
+	if value, ok := _c.mutation.Key(); ok {
+		_spec.SetField(appstate.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := _c.mutation.Value(); ok {
+		_spec.SetField(appstate.FieldValue, field.TypeString, value)
+		_node.Value = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(appstate.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
 	return _node, _spec
 }
 
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.AppState.Create().
+//		SetKey(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.AppStateUpsert) {
+//			SetKey(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *AppStateCreate) OnConflict(opts ...sql.ConflictOption) *AppStateUpsertOne {
+	_c.conflict = opts
+	return &AppStateUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.AppState.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *AppStateCreate) OnConflictColumns(columns ...string) *AppStateUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &AppStateUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// AppStateUpsertOne is the builder for "upsert"-ing
+	//  one AppState node.
+	AppStateUpsertOne struct {
+		create *AppStateCreate
+	}
+
+	// AppStateUpsert is the "OnConflict" setter.
+	AppStateUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetValue sets the "value" field.
+func (u *AppStateUpsert) SetValue(v string) *AppStateUpsert {
+	u.Set(appstate.FieldValue, v)
+	return u
+}
+
+// UpdateValue sets the "value" field to the value that was provided on create.
+func (u *AppStateUpsert) UpdateValue() *AppStateUpsert {
+	u.SetExcluded(appstate.FieldValue)
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *AppStateUpsert) SetUpdatedAt(v time.Time) *AppStateUpsert {
+	u.Set(appstate.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *AppStateUpsert) UpdateUpdatedAt() *AppStateUpsert {
+	u.SetExcluded(appstate.FieldUpdatedAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.AppState.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *AppStateUpsertOne) UpdateNewValues() *AppStateUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.Key(); exists {
+			s.SetIgnore(appstate.FieldKey)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.AppState.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *AppStateUpsertOne) Ignore() *AppStateUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *AppStateUpsertOne) DoNothing() *AppStateUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the AppStateCreate.OnConflict
+// documentation for more info.
+func (u *AppStateUpsertOne) Update(set func(*AppStateUpsert)) *AppStateUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&AppStateUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetValue sets the "value" field.
+func (u *AppStateUpsertOne) SetValue(v string) *AppStateUpsertOne {
+	return u.Update(func(s *AppStateUpsert) {
+		s.SetValue(v)
+	})
+}
+
+// UpdateValue sets the "value" field to the value that was provided on create.
+func (u *AppStateUpsertOne) UpdateValue() *AppStateUpsertOne {
+	return u.Update(func(s *AppStateUpsert) {
+		s.UpdateValue()
+	})
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *AppStateUpsertOne) SetUpdatedAt(v time.Time) *AppStateUpsertOne {
+	return u.Update(func(s *AppStateUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *AppStateUpsertOne) UpdateUpdatedAt() *AppStateUpsertOne {
+	return u.Update(func(s *AppStateUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *AppStateUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for AppStateCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *AppStateUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *AppStateUpsertOne) ID(ctx context.Context) (id int, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *AppStateUpsertOne) IDX(ctx context.Context) int {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
 // AppStateCreateBulk is the builder for creating many AppState entities in bulk.
 type AppStateCreateBulk struct {
 	config
 	err      error
 	builders []*AppStateCreate
+	conflict []sql.ConflictOption
 }
 
 // Save creates the AppState entities in the database.
@@ -99,6 +345,7 @@ func (_c *AppStateCreateBulk) Save(ctx context.Context) ([]*AppState, error) {
 	for i := range _c.builders {
 		func(i int, root context.Context) {
 			builder := _c.builders[i]
+			builder.defaults()
 			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
 				mutation, ok := m.(*AppStateMutation)
 				if !ok {
@@ -114,6 +361,7 @@ func (_c *AppStateCreateBulk) Save(ctx context.Context) ([]*AppState, error) {
 					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
 				} else {
 					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
 					// Invoke the actual operation on the latest mutation in the chain.
 					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
 						if sqlgraph.IsConstraintError(err) {
@@ -167,3 +415,142 @@ func (_c *AppStateCreateBulk) ExecX(ctx context.Context) {
 		panic(err)
 	}
 }
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.AppState.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.AppStateUpsert) {
+//			SetKey(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *AppStateCreateBulk) OnConflict(opts ...sql.ConflictOption) *AppStateUpsertBulk {
+	_c.conflict = opts
+	return &AppStateUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.AppState.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *AppStateCreateBulk) OnConflictColumns(columns ...string) *AppStateUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &AppStateUpsertBulk{
+		create: _c,
+	}
+}
+
+// AppStateUpsertBulk is the builder for "upsert"-ing
+// a bulk of AppState nodes.
+type AppStateUpsertBulk struct {
+	create *AppStateCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.AppState.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *AppStateUpsertBulk) UpdateNewValues() *AppStateUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.Key(); exists {
+				s.SetIgnore(appstate.FieldKey)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.AppState.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *AppStateUpsertBulk) Ignore() *AppStateUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *AppStateUpsertBulk) DoNothing() *AppStateUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the AppStateCreateBulk.OnConflict
+// documentation for more info.
+func (u *AppStateUpsertBulk) Update(set func(*AppStateUpsert)) *AppStateUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&AppStateUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetValue sets the "value" field.
+func (u *AppStateUpsertBulk) SetValue(v string) *AppStateUpsertBulk {
+	return u.Update(func(s *AppStateUpsert) {
+		s.SetValue(v)
+	})
+}
+
+// UpdateValue sets the "value" field to the value that was provided on create.
+func (u *AppStateUpsertBulk) UpdateValue() *AppStateUpsertBulk {
+	return u.Update(func(s *AppStateUpsert) {
+		s.UpdateValue()
+	})
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *AppStateUpsertBulk) SetUpdatedAt(v time.Time) *AppStateUpsertBulk {
+	return u.Update(func(s *AppStateUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *AppStateUpsertBulk) UpdateUpdatedAt() *AppStateUpsertBulk {
+	return u.Update(func(s *AppStateUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *AppStateUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the AppStateCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for AppStateCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *AppStateUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
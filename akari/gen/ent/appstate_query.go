@@ -258,6 +258,18 @@ func (_q *AppStateQuery) Clone() *AppStateQuery {
 
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		Key string `json:"key,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.AppState.Query().
+//		GroupBy(appstate.FieldKey).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
 func (_q *AppStateQuery) GroupBy(field string, fields ...string) *AppStateGroupBy {
 	_q.ctx.Fields = append([]string{field}, fields...)
 	grbuild := &AppStateGroupBy{build: _q}
@@ -269,6 +281,16 @@ func (_q *AppStateQuery) GroupBy(field string, fields ...string) *AppStateGroupB
 
 // Select allows the selection one or more fields/columns for the given query,
 // instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		Key string `json:"key,omitempty"`
+//	}
+//
+//	client.AppState.Query().
+//		Select(appstate.FieldKey).
+//		Scan(ctx, &v)
 func (_q *AppStateQuery) Select(fields ...string) *AppStateSelect {
 	_q.ctx.Fields = append(_q.ctx.Fields, fields...)
 	sbuild := &AppStateSelect{AppStateQuery: _q}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -27,6 +28,26 @@ func (_u *AppStateUpdate) Where(ps ...predicate.AppState) *AppStateUpdate {
 	return _u
 }
 
+// SetValue sets the "value" field.
+func (_u *AppStateUpdate) SetValue(v string) *AppStateUpdate {
+	_u.mutation.SetValue(v)
+	return _u
+}
+
+// SetNillableValue sets the "value" field if the given value is not nil.
+func (_u *AppStateUpdate) SetNillableValue(v *string) *AppStateUpdate {
+	if v != nil {
+		_u.SetValue(*v)
+	}
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *AppStateUpdate) SetUpdatedAt(v time.Time) *AppStateUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
 // Mutation returns the AppStateMutation object of the builder.
 func (_u *AppStateUpdate) Mutation() *AppStateMutation {
 	return _u.mutation
@@ -34,6 +55,7 @@ func (_u *AppStateUpdate) Mutation() *AppStateMutation {
 
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *AppStateUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
 	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
 }
 
@@ -59,6 +81,14 @@ func (_u *AppStateUpdate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (_u *AppStateUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := appstate.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
 func (_u *AppStateUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	_spec := sqlgraph.NewUpdateSpec(appstate.Table, appstate.Columns, sqlgraph.NewFieldSpec(appstate.FieldID, field.TypeInt))
 	if ps := _u.mutation.predicates; len(ps) > 0 {
@@ -68,6 +98,12 @@ func (_u *AppStateUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 			}
 		}
 	}
+	if value, ok := _u.mutation.Value(); ok {
+		_spec.SetField(appstate.FieldValue, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(appstate.FieldUpdatedAt, field.TypeTime, value)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{appstate.Label}
@@ -88,6 +124,26 @@ type AppStateUpdateOne struct {
 	mutation *AppStateMutation
 }
 
+// SetValue sets the "value" field.
+func (_u *AppStateUpdateOne) SetValue(v string) *AppStateUpdateOne {
+	_u.mutation.SetValue(v)
+	return _u
+}
+
+// SetNillableValue sets the "value" field if the given value is not nil.
+func (_u *AppStateUpdateOne) SetNillableValue(v *string) *AppStateUpdateOne {
+	if v != nil {
+		_u.SetValue(*v)
+	}
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *AppStateUpdateOne) SetUpdatedAt(v time.Time) *AppStateUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
 // Mutation returns the AppStateMutation object of the builder.
 func (_u *AppStateUpdateOne) Mutation() *AppStateMutation {
 	return _u.mutation
@@ -108,6 +164,7 @@ func (_u *AppStateUpdateOne) Select(field string, fields ...string) *AppStateUpd
 
 // Save executes the query and returns the updated AppState entity.
 func (_u *AppStateUpdateOne) Save(ctx context.Context) (*AppState, error) {
+	_u.defaults()
 	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
 }
 
@@ -133,6 +190,14 @@ func (_u *AppStateUpdateOne) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (_u *AppStateUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := appstate.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
 func (_u *AppStateUpdateOne) sqlSave(ctx context.Context) (_node *AppState, err error) {
 	_spec := sqlgraph.NewUpdateSpec(appstate.Table, appstate.Columns, sqlgraph.NewFieldSpec(appstate.FieldID, field.TypeInt))
 	id, ok := _u.mutation.ID()
@@ -159,6 +224,12 @@ func (_u *AppStateUpdateOne) sqlSave(ctx context.Context) (_node *AppState, err
 			}
 		}
 	}
+	if value, ok := _u.mutation.Value(); ok {
+		_spec.SetField(appstate.FieldValue, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(appstate.FieldUpdatedAt, field.TypeTime, value)
+	}
 	_node = &AppState{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -0,0 +1,177 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/kizuna-org/akari/gen/ent/character"
+)
+
+// Character is the model entity for the Character schema.
+type Character struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID string `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// Persona holds the value of the "persona" field.
+	Persona string `json:"persona,omitempty"`
+	// PromptTemplate holds the value of the "prompt_template" field.
+	PromptTemplate string `json:"prompt_template,omitempty"`
+	// ChannelIds holds the value of the "channel_ids" field.
+	ChannelIds []string `json:"channel_ids,omitempty"`
+	// Tools holds the value of the "tools" field.
+	Tools []string `json:"tools,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Character) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case character.FieldChannelIds, character.FieldTools:
+			values[i] = new([]byte)
+		case character.FieldID, character.FieldName, character.FieldPersona, character.FieldPromptTemplate:
+			values[i] = new(sql.NullString)
+		case character.FieldCreatedAt, character.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Character fields.
+func (_m *Character) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case character.FieldID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				_m.ID = value.String
+			}
+		case character.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		case character.FieldPersona:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field persona", values[i])
+			} else if value.Valid {
+				_m.Persona = value.String
+			}
+		case character.FieldPromptTemplate:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field prompt_template", values[i])
+			} else if value.Valid {
+				_m.PromptTemplate = value.String
+			}
+		case character.FieldChannelIds:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field channel_ids", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.ChannelIds); err != nil {
+					return fmt.Errorf("unmarshal field channel_ids: %w", err)
+				}
+			}
+		case character.FieldTools:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field tools", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Tools); err != nil {
+					return fmt.Errorf("unmarshal field tools: %w", err)
+				}
+			}
+		case character.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case character.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Character.
+// This includes values selected through modifiers, order, etc.
+func (_m *Character) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Character.
+// Note that you need to call Character.Unwrap() before calling this method if this Character
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Character) Update() *CharacterUpdateOne {
+	return NewCharacterClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Character entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Character) Unwrap() *Character {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Character is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Character) String() string {
+	var builder strings.Builder
+	builder.WriteString("Character(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
+	builder.WriteString("persona=")
+	builder.WriteString(_m.Persona)
+	builder.WriteString(", ")
+	builder.WriteString("prompt_template=")
+	builder.WriteString(_m.PromptTemplate)
+	builder.WriteString(", ")
+	builder.WriteString("channel_ids=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ChannelIds))
+	builder.WriteString(", ")
+	builder.WriteString("tools=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Tools))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Characters is a parsable slice of Character.
+type Characters []*Character
@@ -0,0 +1,108 @@
+// Code generated by ent, DO NOT EDIT.
+
+package character
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the character type in the database.
+	Label = "character"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldName holds the string denoting the name field in the database.
+	FieldName = "name"
+	// FieldPersona holds the string denoting the persona field in the database.
+	FieldPersona = "persona"
+	// FieldPromptTemplate holds the string denoting the prompt_template field in the database.
+	FieldPromptTemplate = "prompt_template"
+	// FieldChannelIds holds the string denoting the channel_ids field in the database.
+	FieldChannelIds = "channel_ids"
+	// FieldTools holds the string denoting the tools field in the database.
+	FieldTools = "tools"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// Table holds the table name of the character in the database.
+	Table = "characters"
+)
+
+// Columns holds all SQL columns for character fields.
+var Columns = []string{
+	FieldID,
+	FieldName,
+	FieldPersona,
+	FieldPromptTemplate,
+	FieldChannelIds,
+	FieldTools,
+	FieldCreatedAt,
+	FieldUpdatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// NameValidator is a validator for the "name" field. It is called by the builders before save.
+	NameValidator func(string) error
+	// DefaultPersona holds the default value on creation for the "persona" field.
+	DefaultPersona string
+	// DefaultPromptTemplate holds the default value on creation for the "prompt_template" field.
+	DefaultPromptTemplate string
+	// DefaultChannelIds holds the default value on creation for the "channel_ids" field.
+	DefaultChannelIds []string
+	// DefaultTools holds the default value on creation for the "tools" field.
+	DefaultTools []string
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
+	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
+	UpdateDefaultUpdatedAt func() time.Time
+	// IDValidator is a validator for the "id" field. It is called by the builders before save.
+	IDValidator func(string) error
+)
+
+// OrderOption defines the ordering options for the Character queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByName orders the results by the name field.
+func ByName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldName, opts...).ToFunc()
+}
+
+// ByPersona orders the results by the persona field.
+func ByPersona(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPersona, opts...).ToFunc()
+}
+
+// ByPromptTemplate orders the results by the prompt_template field.
+func ByPromptTemplate(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPromptTemplate, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
@@ -0,0 +1,380 @@
+// Code generated by ent, DO NOT EDIT.
+
+package character
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/kizuna-org/akari/gen/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id string) predicate.Character {
+	return predicate.Character(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id string) predicate.Character {
+	return predicate.Character(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id string) predicate.Character {
+	return predicate.Character(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...string) predicate.Character {
+	return predicate.Character(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...string) predicate.Character {
+	return predicate.Character(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id string) predicate.Character {
+	return predicate.Character(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id string) predicate.Character {
+	return predicate.Character(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id string) predicate.Character {
+	return predicate.Character(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id string) predicate.Character {
+	return predicate.Character(sql.FieldLTE(FieldID, id))
+}
+
+// IDEqualFold applies the EqualFold predicate on the ID field.
+func IDEqualFold(id string) predicate.Character {
+	return predicate.Character(sql.FieldEqualFold(FieldID, id))
+}
+
+// IDContainsFold applies the ContainsFold predicate on the ID field.
+func IDContainsFold(id string) predicate.Character {
+	return predicate.Character(sql.FieldContainsFold(FieldID, id))
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.Character {
+	return predicate.Character(sql.FieldEQ(FieldName, v))
+}
+
+// Persona applies equality check predicate on the "persona" field. It's identical to PersonaEQ.
+func Persona(v string) predicate.Character {
+	return predicate.Character(sql.FieldEQ(FieldPersona, v))
+}
+
+// PromptTemplate applies equality check predicate on the "prompt_template" field. It's identical to PromptTemplateEQ.
+func PromptTemplate(v string) predicate.Character {
+	return predicate.Character(sql.FieldEQ(FieldPromptTemplate, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.Character {
+	return predicate.Character(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.Character {
+	return predicate.Character(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.Character {
+	return predicate.Character(sql.FieldEQ(FieldName, v))
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.Character {
+	return predicate.Character(sql.FieldNEQ(FieldName, v))
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.Character {
+	return predicate.Character(sql.FieldIn(FieldName, vs...))
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.Character {
+	return predicate.Character(sql.FieldNotIn(FieldName, vs...))
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.Character {
+	return predicate.Character(sql.FieldGT(FieldName, v))
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.Character {
+	return predicate.Character(sql.FieldGTE(FieldName, v))
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.Character {
+	return predicate.Character(sql.FieldLT(FieldName, v))
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.Character {
+	return predicate.Character(sql.FieldLTE(FieldName, v))
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.Character {
+	return predicate.Character(sql.FieldContains(FieldName, v))
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.Character {
+	return predicate.Character(sql.FieldHasPrefix(FieldName, v))
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.Character {
+	return predicate.Character(sql.FieldHasSuffix(FieldName, v))
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.Character {
+	return predicate.Character(sql.FieldEqualFold(FieldName, v))
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.Character {
+	return predicate.Character(sql.FieldContainsFold(FieldName, v))
+}
+
+// PersonaEQ applies the EQ predicate on the "persona" field.
+func PersonaEQ(v string) predicate.Character {
+	return predicate.Character(sql.FieldEQ(FieldPersona, v))
+}
+
+// PersonaNEQ applies the NEQ predicate on the "persona" field.
+func PersonaNEQ(v string) predicate.Character {
+	return predicate.Character(sql.FieldNEQ(FieldPersona, v))
+}
+
+// PersonaIn applies the In predicate on the "persona" field.
+func PersonaIn(vs ...string) predicate.Character {
+	return predicate.Character(sql.FieldIn(FieldPersona, vs...))
+}
+
+// PersonaNotIn applies the NotIn predicate on the "persona" field.
+func PersonaNotIn(vs ...string) predicate.Character {
+	return predicate.Character(sql.FieldNotIn(FieldPersona, vs...))
+}
+
+// PersonaGT applies the GT predicate on the "persona" field.
+func PersonaGT(v string) predicate.Character {
+	return predicate.Character(sql.FieldGT(FieldPersona, v))
+}
+
+// PersonaGTE applies the GTE predicate on the "persona" field.
+func PersonaGTE(v string) predicate.Character {
+	return predicate.Character(sql.FieldGTE(FieldPersona, v))
+}
+
+// PersonaLT applies the LT predicate on the "persona" field.
+func PersonaLT(v string) predicate.Character {
+	return predicate.Character(sql.FieldLT(FieldPersona, v))
+}
+
+// PersonaLTE applies the LTE predicate on the "persona" field.
+func PersonaLTE(v string) predicate.Character {
+	return predicate.Character(sql.FieldLTE(FieldPersona, v))
+}
+
+// PersonaContains applies the Contains predicate on the "persona" field.
+func PersonaContains(v string) predicate.Character {
+	return predicate.Character(sql.FieldContains(FieldPersona, v))
+}
+
+// PersonaHasPrefix applies the HasPrefix predicate on the "persona" field.
+func PersonaHasPrefix(v string) predicate.Character {
+	return predicate.Character(sql.FieldHasPrefix(FieldPersona, v))
+}
+
+// PersonaHasSuffix applies the HasSuffix predicate on the "persona" field.
+func PersonaHasSuffix(v string) predicate.Character {
+	return predicate.Character(sql.FieldHasSuffix(FieldPersona, v))
+}
+
+// PersonaEqualFold applies the EqualFold predicate on the "persona" field.
+func PersonaEqualFold(v string) predicate.Character {
+	return predicate.Character(sql.FieldEqualFold(FieldPersona, v))
+}
+
+// PersonaContainsFold applies the ContainsFold predicate on the "persona" field.
+func PersonaContainsFold(v string) predicate.Character {
+	return predicate.Character(sql.FieldContainsFold(FieldPersona, v))
+}
+
+// PromptTemplateEQ applies the EQ predicate on the "prompt_template" field.
+func PromptTemplateEQ(v string) predicate.Character {
+	return predicate.Character(sql.FieldEQ(FieldPromptTemplate, v))
+}
+
+// PromptTemplateNEQ applies the NEQ predicate on the "prompt_template" field.
+func PromptTemplateNEQ(v string) predicate.Character {
+	return predicate.Character(sql.FieldNEQ(FieldPromptTemplate, v))
+}
+
+// PromptTemplateIn applies the In predicate on the "prompt_template" field.
+func PromptTemplateIn(vs ...string) predicate.Character {
+	return predicate.Character(sql.FieldIn(FieldPromptTemplate, vs...))
+}
+
+// PromptTemplateNotIn applies the NotIn predicate on the "prompt_template" field.
+func PromptTemplateNotIn(vs ...string) predicate.Character {
+	return predicate.Character(sql.FieldNotIn(FieldPromptTemplate, vs...))
+}
+
+// PromptTemplateGT applies the GT predicate on the "prompt_template" field.
+func PromptTemplateGT(v string) predicate.Character {
+	return predicate.Character(sql.FieldGT(FieldPromptTemplate, v))
+}
+
+// PromptTemplateGTE applies the GTE predicate on the "prompt_template" field.
+func PromptTemplateGTE(v string) predicate.Character {
+	return predicate.Character(sql.FieldGTE(FieldPromptTemplate, v))
+}
+
+// PromptTemplateLT applies the LT predicate on the "prompt_template" field.
+func PromptTemplateLT(v string) predicate.Character {
+	return predicate.Character(sql.FieldLT(FieldPromptTemplate, v))
+}
+
+// PromptTemplateLTE applies the LTE predicate on the "prompt_template" field.
+func PromptTemplateLTE(v string) predicate.Character {
+	return predicate.Character(sql.FieldLTE(FieldPromptTemplate, v))
+}
+
+// PromptTemplateContains applies the Contains predicate on the "prompt_template" field.
+func PromptTemplateContains(v string) predicate.Character {
+	return predicate.Character(sql.FieldContains(FieldPromptTemplate, v))
+}
+
+// PromptTemplateHasPrefix applies the HasPrefix predicate on the "prompt_template" field.
+func PromptTemplateHasPrefix(v string) predicate.Character {
+	return predicate.Character(sql.FieldHasPrefix(FieldPromptTemplate, v))
+}
+
+// PromptTemplateHasSuffix applies the HasSuffix predicate on the "prompt_template" field.
+func PromptTemplateHasSuffix(v string) predicate.Character {
+	return predicate.Character(sql.FieldHasSuffix(FieldPromptTemplate, v))
+}
+
+// PromptTemplateEqualFold applies the EqualFold predicate on the "prompt_template" field.
+func PromptTemplateEqualFold(v string) predicate.Character {
+	return predicate.Character(sql.FieldEqualFold(FieldPromptTemplate, v))
+}
+
+// PromptTemplateContainsFold applies the ContainsFold predicate on the "prompt_template" field.
+func PromptTemplateContainsFold(v string) predicate.Character {
+	return predicate.Character(sql.FieldContainsFold(FieldPromptTemplate, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.Character {
+	return predicate.Character(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.Character {
+	return predicate.Character(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.Character {
+	return predicate.Character(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.Character {
+	return predicate.Character(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.Character {
+	return predicate.Character(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.Character {
+	return predicate.Character(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.Character {
+	return predicate.Character(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.Character {
+	return predicate.Character(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.Character {
+	return predicate.Character(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.Character {
+	return predicate.Character(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.Character {
+	return predicate.Character(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.Character {
+	return predicate.Character(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.Character {
+	return predicate.Character(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.Character {
+	return predicate.Character(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.Character {
+	return predicate.Character(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.Character {
+	return predicate.Character(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Character) predicate.Character {
+	return predicate.Character(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Character) predicate.Character {
+	return predicate.Character(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Character) predicate.Character {
+	return predicate.Character(sql.NotPredicates(p))
+}
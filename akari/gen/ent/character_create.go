@@ -0,0 +1,846 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/kizuna-org/akari/gen/ent/character"
+)
+
+// CharacterCreate is the builder for creating a Character entity.
+type CharacterCreate struct {
+	config
+	mutation *CharacterMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetName sets the "name" field.
+func (_c *CharacterCreate) SetName(v string) *CharacterCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetPersona sets the "persona" field.
+func (_c *CharacterCreate) SetPersona(v string) *CharacterCreate {
+	_c.mutation.SetPersona(v)
+	return _c
+}
+
+// SetNillablePersona sets the "persona" field if the given value is not nil.
+func (_c *CharacterCreate) SetNillablePersona(v *string) *CharacterCreate {
+	if v != nil {
+		_c.SetPersona(*v)
+	}
+	return _c
+}
+
+// SetPromptTemplate sets the "prompt_template" field.
+func (_c *CharacterCreate) SetPromptTemplate(v string) *CharacterCreate {
+	_c.mutation.SetPromptTemplate(v)
+	return _c
+}
+
+// SetNillablePromptTemplate sets the "prompt_template" field if the given value is not nil.
+func (_c *CharacterCreate) SetNillablePromptTemplate(v *string) *CharacterCreate {
+	if v != nil {
+		_c.SetPromptTemplate(*v)
+	}
+	return _c
+}
+
+// SetChannelIds sets the "channel_ids" field.
+func (_c *CharacterCreate) SetChannelIds(v []string) *CharacterCreate {
+	_c.mutation.SetChannelIds(v)
+	return _c
+}
+
+// SetTools sets the "tools" field.
+func (_c *CharacterCreate) SetTools(v []string) *CharacterCreate {
+	_c.mutation.SetTools(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *CharacterCreate) SetCreatedAt(v time.Time) *CharacterCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *CharacterCreate) SetNillableCreatedAt(v *time.Time) *CharacterCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *CharacterCreate) SetUpdatedAt(v time.Time) *CharacterCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *CharacterCreate) SetNillableUpdatedAt(v *time.Time) *CharacterCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *CharacterCreate) SetID(v string) *CharacterCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// Mutation returns the CharacterMutation object of the builder.
+func (_c *CharacterCreate) Mutation() *CharacterMutation {
+	return _c.mutation
+}
+
+// Save creates the Character in the database.
+func (_c *CharacterCreate) Save(ctx context.Context) (*Character, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *CharacterCreate) SaveX(ctx context.Context) *Character {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *CharacterCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *CharacterCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *CharacterCreate) defaults() {
+	if _, ok := _c.mutation.Persona(); !ok {
+		v := character.DefaultPersona
+		_c.mutation.SetPersona(v)
+	}
+	if _, ok := _c.mutation.PromptTemplate(); !ok {
+		v := character.DefaultPromptTemplate
+		_c.mutation.SetPromptTemplate(v)
+	}
+	if _, ok := _c.mutation.ChannelIds(); !ok {
+		v := character.DefaultChannelIds
+		_c.mutation.SetChannelIds(v)
+	}
+	if _, ok := _c.mutation.Tools(); !ok {
+		v := character.DefaultTools
+		_c.mutation.SetTools(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := character.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := character.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *CharacterCreate) check() error {
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Character.name"`)}
+	}
+	if v, ok := _c.mutation.Name(); ok {
+		if err := character.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Character.name": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Persona(); !ok {
+		return &ValidationError{Name: "persona", err: errors.New(`ent: missing required field "Character.persona"`)}
+	}
+	if _, ok := _c.mutation.PromptTemplate(); !ok {
+		return &ValidationError{Name: "prompt_template", err: errors.New(`ent: missing required field "Character.prompt_template"`)}
+	}
+	if _, ok := _c.mutation.ChannelIds(); !ok {
+		return &ValidationError{Name: "channel_ids", err: errors.New(`ent: missing required field "Character.channel_ids"`)}
+	}
+	if _, ok := _c.mutation.Tools(); !ok {
+		return &ValidationError{Name: "tools", err: errors.New(`ent: missing required field "Character.tools"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Character.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Character.updated_at"`)}
+	}
+	if v, ok := _c.mutation.ID(); ok {
+		if err := character.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "Character.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_c *CharacterCreate) sqlSave(ctx context.Context) (*Character, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected Character.ID type: %T", _spec.ID.Value)
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *CharacterCreate) createSpec() (*Character, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Character{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(character.Table, sqlgraph.NewFieldSpec(character.FieldID, field.TypeString))
+	)
+	_spec.OnConflict = _c.conflict
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(character.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := _c.mutation.Persona(); ok {
+		_spec.SetField(character.FieldPersona, field.TypeString, value)
+		_node.Persona = value
+	}
+	if value, ok := _c.mutation.PromptTemplate(); ok {
+		_spec.SetField(character.FieldPromptTemplate, field.TypeString, value)
+		_node.PromptTemplate = value
+	}
+	if value, ok := _c.mutation.ChannelIds(); ok {
+		_spec.SetField(character.FieldChannelIds, field.TypeJSON, value)
+		_node.ChannelIds = value
+	}
+	if value, ok := _c.mutation.Tools(); ok {
+		_spec.SetField(character.FieldTools, field.TypeJSON, value)
+		_node.Tools = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(character.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(character.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Character.Create().
+//		SetName(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.CharacterUpsert) {
+//			SetName(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *CharacterCreate) OnConflict(opts ...sql.ConflictOption) *CharacterUpsertOne {
+	_c.conflict = opts
+	return &CharacterUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Character.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *CharacterCreate) OnConflictColumns(columns ...string) *CharacterUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &CharacterUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// CharacterUpsertOne is the builder for "upsert"-ing
+	//  one Character node.
+	CharacterUpsertOne struct {
+		create *CharacterCreate
+	}
+
+	// CharacterUpsert is the "OnConflict" setter.
+	CharacterUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetName sets the "name" field.
+func (u *CharacterUpsert) SetName(v string) *CharacterUpsert {
+	u.Set(character.FieldName, v)
+	return u
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *CharacterUpsert) UpdateName() *CharacterUpsert {
+	u.SetExcluded(character.FieldName)
+	return u
+}
+
+// SetPersona sets the "persona" field.
+func (u *CharacterUpsert) SetPersona(v string) *CharacterUpsert {
+	u.Set(character.FieldPersona, v)
+	return u
+}
+
+// UpdatePersona sets the "persona" field to the value that was provided on create.
+func (u *CharacterUpsert) UpdatePersona() *CharacterUpsert {
+	u.SetExcluded(character.FieldPersona)
+	return u
+}
+
+// SetPromptTemplate sets the "prompt_template" field.
+func (u *CharacterUpsert) SetPromptTemplate(v string) *CharacterUpsert {
+	u.Set(character.FieldPromptTemplate, v)
+	return u
+}
+
+// UpdatePromptTemplate sets the "prompt_template" field to the value that was provided on create.
+func (u *CharacterUpsert) UpdatePromptTemplate() *CharacterUpsert {
+	u.SetExcluded(character.FieldPromptTemplate)
+	return u
+}
+
+// SetChannelIds sets the "channel_ids" field.
+func (u *CharacterUpsert) SetChannelIds(v []string) *CharacterUpsert {
+	u.Set(character.FieldChannelIds, v)
+	return u
+}
+
+// UpdateChannelIds sets the "channel_ids" field to the value that was provided on create.
+func (u *CharacterUpsert) UpdateChannelIds() *CharacterUpsert {
+	u.SetExcluded(character.FieldChannelIds)
+	return u
+}
+
+// SetTools sets the "tools" field.
+func (u *CharacterUpsert) SetTools(v []string) *CharacterUpsert {
+	u.Set(character.FieldTools, v)
+	return u
+}
+
+// UpdateTools sets the "tools" field to the value that was provided on create.
+func (u *CharacterUpsert) UpdateTools() *CharacterUpsert {
+	u.SetExcluded(character.FieldTools)
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *CharacterUpsert) SetUpdatedAt(v time.Time) *CharacterUpsert {
+	u.Set(character.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *CharacterUpsert) UpdateUpdatedAt() *CharacterUpsert {
+	u.SetExcluded(character.FieldUpdatedAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.Character.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(character.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *CharacterUpsertOne) UpdateNewValues() *CharacterUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(character.FieldID)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(character.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Character.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *CharacterUpsertOne) Ignore() *CharacterUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *CharacterUpsertOne) DoNothing() *CharacterUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the CharacterCreate.OnConflict
+// documentation for more info.
+func (u *CharacterUpsertOne) Update(set func(*CharacterUpsert)) *CharacterUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&CharacterUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetName sets the "name" field.
+func (u *CharacterUpsertOne) SetName(v string) *CharacterUpsertOne {
+	return u.Update(func(s *CharacterUpsert) {
+		s.SetName(v)
+	})
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *CharacterUpsertOne) UpdateName() *CharacterUpsertOne {
+	return u.Update(func(s *CharacterUpsert) {
+		s.UpdateName()
+	})
+}
+
+// SetPersona sets the "persona" field.
+func (u *CharacterUpsertOne) SetPersona(v string) *CharacterUpsertOne {
+	return u.Update(func(s *CharacterUpsert) {
+		s.SetPersona(v)
+	})
+}
+
+// UpdatePersona sets the "persona" field to the value that was provided on create.
+func (u *CharacterUpsertOne) UpdatePersona() *CharacterUpsertOne {
+	return u.Update(func(s *CharacterUpsert) {
+		s.UpdatePersona()
+	})
+}
+
+// SetPromptTemplate sets the "prompt_template" field.
+func (u *CharacterUpsertOne) SetPromptTemplate(v string) *CharacterUpsertOne {
+	return u.Update(func(s *CharacterUpsert) {
+		s.SetPromptTemplate(v)
+	})
+}
+
+// UpdatePromptTemplate sets the "prompt_template" field to the value that was provided on create.
+func (u *CharacterUpsertOne) UpdatePromptTemplate() *CharacterUpsertOne {
+	return u.Update(func(s *CharacterUpsert) {
+		s.UpdatePromptTemplate()
+	})
+}
+
+// SetChannelIds sets the "channel_ids" field.
+func (u *CharacterUpsertOne) SetChannelIds(v []string) *CharacterUpsertOne {
+	return u.Update(func(s *CharacterUpsert) {
+		s.SetChannelIds(v)
+	})
+}
+
+// UpdateChannelIds sets the "channel_ids" field to the value that was provided on create.
+func (u *CharacterUpsertOne) UpdateChannelIds() *CharacterUpsertOne {
+	return u.Update(func(s *CharacterUpsert) {
+		s.UpdateChannelIds()
+	})
+}
+
+// SetTools sets the "tools" field.
+func (u *CharacterUpsertOne) SetTools(v []string) *CharacterUpsertOne {
+	return u.Update(func(s *CharacterUpsert) {
+		s.SetTools(v)
+	})
+}
+
+// UpdateTools sets the "tools" field to the value that was provided on create.
+func (u *CharacterUpsertOne) UpdateTools() *CharacterUpsertOne {
+	return u.Update(func(s *CharacterUpsert) {
+		s.UpdateTools()
+	})
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *CharacterUpsertOne) SetUpdatedAt(v time.Time) *CharacterUpsertOne {
+	return u.Update(func(s *CharacterUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *CharacterUpsertOne) UpdateUpdatedAt() *CharacterUpsertOne {
+	return u.Update(func(s *CharacterUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *CharacterUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for CharacterCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *CharacterUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *CharacterUpsertOne) ID(ctx context.Context) (id string, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: CharacterUpsertOne.ID is not supported by MySQL driver. Use CharacterUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *CharacterUpsertOne) IDX(ctx context.Context) string {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// CharacterCreateBulk is the builder for creating many Character entities in bulk.
+type CharacterCreateBulk struct {
+	config
+	err      error
+	builders []*CharacterCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the Character entities in the database.
+func (_c *CharacterCreateBulk) Save(ctx context.Context) ([]*Character, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Character, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*CharacterMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *CharacterCreateBulk) SaveX(ctx context.Context) []*Character {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *CharacterCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *CharacterCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Character.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.CharacterUpsert) {
+//			SetName(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *CharacterCreateBulk) OnConflict(opts ...sql.ConflictOption) *CharacterUpsertBulk {
+	_c.conflict = opts
+	return &CharacterUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Character.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *CharacterCreateBulk) OnConflictColumns(columns ...string) *CharacterUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &CharacterUpsertBulk{
+		create: _c,
+	}
+}
+
+// CharacterUpsertBulk is the builder for "upsert"-ing
+// a bulk of Character nodes.
+type CharacterUpsertBulk struct {
+	create *CharacterCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.Character.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(character.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *CharacterUpsertBulk) UpdateNewValues() *CharacterUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(character.FieldID)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(character.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Character.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *CharacterUpsertBulk) Ignore() *CharacterUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *CharacterUpsertBulk) DoNothing() *CharacterUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the CharacterCreateBulk.OnConflict
+// documentation for more info.
+func (u *CharacterUpsertBulk) Update(set func(*CharacterUpsert)) *CharacterUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&CharacterUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetName sets the "name" field.
+func (u *CharacterUpsertBulk) SetName(v string) *CharacterUpsertBulk {
+	return u.Update(func(s *CharacterUpsert) {
+		s.SetName(v)
+	})
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *CharacterUpsertBulk) UpdateName() *CharacterUpsertBulk {
+	return u.Update(func(s *CharacterUpsert) {
+		s.UpdateName()
+	})
+}
+
+// SetPersona sets the "persona" field.
+func (u *CharacterUpsertBulk) SetPersona(v string) *CharacterUpsertBulk {
+	return u.Update(func(s *CharacterUpsert) {
+		s.SetPersona(v)
+	})
+}
+
+// UpdatePersona sets the "persona" field to the value that was provided on create.
+func (u *CharacterUpsertBulk) UpdatePersona() *CharacterUpsertBulk {
+	return u.Update(func(s *CharacterUpsert) {
+		s.UpdatePersona()
+	})
+}
+
+// SetPromptTemplate sets the "prompt_template" field.
+func (u *CharacterUpsertBulk) SetPromptTemplate(v string) *CharacterUpsertBulk {
+	return u.Update(func(s *CharacterUpsert) {
+		s.SetPromptTemplate(v)
+	})
+}
+
+// UpdatePromptTemplate sets the "prompt_template" field to the value that was provided on create.
+func (u *CharacterUpsertBulk) UpdatePromptTemplate() *CharacterUpsertBulk {
+	return u.Update(func(s *CharacterUpsert) {
+		s.UpdatePromptTemplate()
+	})
+}
+
+// SetChannelIds sets the "channel_ids" field.
+func (u *CharacterUpsertBulk) SetChannelIds(v []string) *CharacterUpsertBulk {
+	return u.Update(func(s *CharacterUpsert) {
+		s.SetChannelIds(v)
+	})
+}
+
+// UpdateChannelIds sets the "channel_ids" field to the value that was provided on create.
+func (u *CharacterUpsertBulk) UpdateChannelIds() *CharacterUpsertBulk {
+	return u.Update(func(s *CharacterUpsert) {
+		s.UpdateChannelIds()
+	})
+}
+
+// SetTools sets the "tools" field.
+func (u *CharacterUpsertBulk) SetTools(v []string) *CharacterUpsertBulk {
+	return u.Update(func(s *CharacterUpsert) {
+		s.SetTools(v)
+	})
+}
+
+// UpdateTools sets the "tools" field to the value that was provided on create.
+func (u *CharacterUpsertBulk) UpdateTools() *CharacterUpsertBulk {
+	return u.Update(func(s *CharacterUpsert) {
+		s.UpdateTools()
+	})
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *CharacterUpsertBulk) SetUpdatedAt(v time.Time) *CharacterUpsertBulk {
+	return u.Update(func(s *CharacterUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *CharacterUpsertBulk) UpdateUpdatedAt() *CharacterUpsertBulk {
+	return u.Update(func(s *CharacterUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *CharacterUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the CharacterCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for CharacterCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *CharacterUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,421 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/kizuna-org/akari/gen/ent/character"
+	"github.com/kizuna-org/akari/gen/ent/predicate"
+)
+
+// CharacterUpdate is the builder for updating Character entities.
+type CharacterUpdate struct {
+	config
+	hooks    []Hook
+	mutation *CharacterMutation
+}
+
+// Where appends a list predicates to the CharacterUpdate builder.
+func (_u *CharacterUpdate) Where(ps ...predicate.Character) *CharacterUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *CharacterUpdate) SetName(v string) *CharacterUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *CharacterUpdate) SetNillableName(v *string) *CharacterUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetPersona sets the "persona" field.
+func (_u *CharacterUpdate) SetPersona(v string) *CharacterUpdate {
+	_u.mutation.SetPersona(v)
+	return _u
+}
+
+// SetNillablePersona sets the "persona" field if the given value is not nil.
+func (_u *CharacterUpdate) SetNillablePersona(v *string) *CharacterUpdate {
+	if v != nil {
+		_u.SetPersona(*v)
+	}
+	return _u
+}
+
+// SetPromptTemplate sets the "prompt_template" field.
+func (_u *CharacterUpdate) SetPromptTemplate(v string) *CharacterUpdate {
+	_u.mutation.SetPromptTemplate(v)
+	return _u
+}
+
+// SetNillablePromptTemplate sets the "prompt_template" field if the given value is not nil.
+func (_u *CharacterUpdate) SetNillablePromptTemplate(v *string) *CharacterUpdate {
+	if v != nil {
+		_u.SetPromptTemplate(*v)
+	}
+	return _u
+}
+
+// SetChannelIds sets the "channel_ids" field.
+func (_u *CharacterUpdate) SetChannelIds(v []string) *CharacterUpdate {
+	_u.mutation.SetChannelIds(v)
+	return _u
+}
+
+// AppendChannelIds appends value to the "channel_ids" field.
+func (_u *CharacterUpdate) AppendChannelIds(v []string) *CharacterUpdate {
+	_u.mutation.AppendChannelIds(v)
+	return _u
+}
+
+// SetTools sets the "tools" field.
+func (_u *CharacterUpdate) SetTools(v []string) *CharacterUpdate {
+	_u.mutation.SetTools(v)
+	return _u
+}
+
+// AppendTools appends value to the "tools" field.
+func (_u *CharacterUpdate) AppendTools(v []string) *CharacterUpdate {
+	_u.mutation.AppendTools(v)
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *CharacterUpdate) SetUpdatedAt(v time.Time) *CharacterUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// Mutation returns the CharacterMutation object of the builder.
+func (_u *CharacterUpdate) Mutation() *CharacterMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *CharacterUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *CharacterUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *CharacterUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *CharacterUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *CharacterUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := character.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *CharacterUpdate) check() error {
+	if v, ok := _u.mutation.Name(); ok {
+		if err := character.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Character.name": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *CharacterUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(character.Table, character.Columns, sqlgraph.NewFieldSpec(character.FieldID, field.TypeString))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(character.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Persona(); ok {
+		_spec.SetField(character.FieldPersona, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.PromptTemplate(); ok {
+		_spec.SetField(character.FieldPromptTemplate, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ChannelIds(); ok {
+		_spec.SetField(character.FieldChannelIds, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedChannelIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, character.FieldChannelIds, value)
+		})
+	}
+	if value, ok := _u.mutation.Tools(); ok {
+		_spec.SetField(character.FieldTools, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedTools(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, character.FieldTools, value)
+		})
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(character.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{character.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// CharacterUpdateOne is the builder for updating a single Character entity.
+type CharacterUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *CharacterMutation
+}
+
+// SetName sets the "name" field.
+func (_u *CharacterUpdateOne) SetName(v string) *CharacterUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *CharacterUpdateOne) SetNillableName(v *string) *CharacterUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetPersona sets the "persona" field.
+func (_u *CharacterUpdateOne) SetPersona(v string) *CharacterUpdateOne {
+	_u.mutation.SetPersona(v)
+	return _u
+}
+
+// SetNillablePersona sets the "persona" field if the given value is not nil.
+func (_u *CharacterUpdateOne) SetNillablePersona(v *string) *CharacterUpdateOne {
+	if v != nil {
+		_u.SetPersona(*v)
+	}
+	return _u
+}
+
+// SetPromptTemplate sets the "prompt_template" field.
+func (_u *CharacterUpdateOne) SetPromptTemplate(v string) *CharacterUpdateOne {
+	_u.mutation.SetPromptTemplate(v)
+	return _u
+}
+
+// SetNillablePromptTemplate sets the "prompt_template" field if the given value is not nil.
+func (_u *CharacterUpdateOne) SetNillablePromptTemplate(v *string) *CharacterUpdateOne {
+	if v != nil {
+		_u.SetPromptTemplate(*v)
+	}
+	return _u
+}
+
+// SetChannelIds sets the "channel_ids" field.
+func (_u *CharacterUpdateOne) SetChannelIds(v []string) *CharacterUpdateOne {
+	_u.mutation.SetChannelIds(v)
+	return _u
+}
+
+// AppendChannelIds appends value to the "channel_ids" field.
+func (_u *CharacterUpdateOne) AppendChannelIds(v []string) *CharacterUpdateOne {
+	_u.mutation.AppendChannelIds(v)
+	return _u
+}
+
+// SetTools sets the "tools" field.
+func (_u *CharacterUpdateOne) SetTools(v []string) *CharacterUpdateOne {
+	_u.mutation.SetTools(v)
+	return _u
+}
+
+// AppendTools appends value to the "tools" field.
+func (_u *CharacterUpdateOne) AppendTools(v []string) *CharacterUpdateOne {
+	_u.mutation.AppendTools(v)
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *CharacterUpdateOne) SetUpdatedAt(v time.Time) *CharacterUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// Mutation returns the CharacterMutation object of the builder.
+func (_u *CharacterUpdateOne) Mutation() *CharacterMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the CharacterUpdate builder.
+func (_u *CharacterUpdateOne) Where(ps ...predicate.Character) *CharacterUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *CharacterUpdateOne) Select(field string, fields ...string) *CharacterUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Character entity.
+func (_u *CharacterUpdateOne) Save(ctx context.Context) (*Character, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *CharacterUpdateOne) SaveX(ctx context.Context) *Character {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *CharacterUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *CharacterUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *CharacterUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := character.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *CharacterUpdateOne) check() error {
+	if v, ok := _u.mutation.Name(); ok {
+		if err := character.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Character.name": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *CharacterUpdateOne) sqlSave(ctx context.Context) (_node *Character, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(character.Table, character.Columns, sqlgraph.NewFieldSpec(character.FieldID, field.TypeString))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Character.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, character.FieldID)
+		for _, f := range fields {
+			if !character.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != character.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(character.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Persona(); ok {
+		_spec.SetField(character.FieldPersona, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.PromptTemplate(); ok {
+		_spec.SetField(character.FieldPromptTemplate, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ChannelIds(); ok {
+		_spec.SetField(character.FieldChannelIds, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedChannelIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, character.FieldChannelIds, value)
+		})
+	}
+	if value, ok := _u.mutation.Tools(); ok {
+		_spec.SetField(character.FieldTools, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedTools(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, character.FieldTools, value)
+		})
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(character.FieldUpdatedAt, field.TypeTime, value)
+	}
+	_node = &Character{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{character.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -13,6 +13,9 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/kizuna-org/akari/gen/ent/appstate"
+	"github.com/kizuna-org/akari/gen/ent/character"
+	"github.com/kizuna-org/akari/gen/ent/job"
+	"github.com/kizuna-org/akari/gen/ent/turn"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -73,7 +76,10 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			appstate.Table: appstate.ValidColumn,
+			appstate.Table:  appstate.ValidColumn,
+			character.Table: character.ValidColumn,
+			job.Table:       job.ValidColumn,
+			turn.Table:      turn.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
@@ -11,6 +11,9 @@ var (
 	// AppStatesColumns holds the columns for the "app_states" table.
 	AppStatesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "key", Type: field.TypeString, Unique: true},
+		{Name: "value", Type: field.TypeString, Size: 2147483647},
+		{Name: "updated_at", Type: field.TypeTime},
 	}
 	// AppStatesTable holds the schema information for the "app_states" table.
 	AppStatesTable = &schema.Table{
@@ -18,9 +21,88 @@ var (
 		Columns:    AppStatesColumns,
 		PrimaryKey: []*schema.Column{AppStatesColumns[0]},
 	}
+	// CharactersColumns holds the columns for the "characters" table.
+	CharactersColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString},
+		{Name: "name", Type: field.TypeString},
+		{Name: "persona", Type: field.TypeString, Size: 2147483647, Default: ""},
+		{Name: "prompt_template", Type: field.TypeString, Size: 2147483647, Default: ""},
+		{Name: "channel_ids", Type: field.TypeJSON},
+		{Name: "tools", Type: field.TypeJSON},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// CharactersTable holds the schema information for the "characters" table.
+	CharactersTable = &schema.Table{
+		Name:       "characters",
+		Columns:    CharactersColumns,
+		PrimaryKey: []*schema.Column{CharactersColumns[0]},
+	}
+	// JobsColumns holds the columns for the "jobs" table.
+	JobsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "kind", Type: field.TypeString},
+		{Name: "payload", Type: field.TypeString, Size: 2147483647},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"pending", "done", "failed"}, Default: "pending"},
+		{Name: "run_at", Type: field.TypeTime},
+		{Name: "attempts", Type: field.TypeInt, Default: 0},
+		{Name: "last_error", Type: field.TypeString, Size: 2147483647, Default: ""},
+		{Name: "lease_owner", Type: field.TypeString, Default: ""},
+		{Name: "lease_expires_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// JobsTable holds the schema information for the "jobs" table.
+	JobsTable = &schema.Table{
+		Name:       "jobs",
+		Columns:    JobsColumns,
+		PrimaryKey: []*schema.Column{JobsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "job_status_run_at",
+				Unique:  false,
+				Columns: []*schema.Column{JobsColumns[3], JobsColumns[4]},
+			},
+		},
+	}
+	// TurnsColumns holds the columns for the "turns" table.
+	TurnsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "character_id", Type: field.TypeString},
+		{Name: "guild_id", Type: field.TypeString, Default: ""},
+		{Name: "channel_id", Type: field.TypeString},
+		{Name: "author_id", Type: field.TypeString},
+		{Name: "author_name", Type: field.TypeString},
+		{Name: "message", Type: field.TypeString, Size: 2147483647},
+		{Name: "reply", Type: field.TypeString, Size: 2147483647},
+		{Name: "memories", Type: field.TypeJSON},
+		{Name: "attachments", Type: field.TypeJSON},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// TurnsTable holds the schema information for the "turns" table.
+	TurnsTable = &schema.Table{
+		Name:       "turns",
+		Columns:    TurnsColumns,
+		PrimaryKey: []*schema.Column{TurnsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "turn_author_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{TurnsColumns[4], TurnsColumns[10]},
+			},
+			{
+				Name:    "turn_channel_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{TurnsColumns[3], TurnsColumns[10]},
+			},
+		},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
 		AppStatesTable,
+		CharactersTable,
+		JobsTable,
+		TurnsTable,
 	}
 )
 
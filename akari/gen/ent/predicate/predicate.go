@@ -8,3 +8,12 @@ import (
 
 // AppState is the predicate function for appstate builders.
 type AppState func(*sql.Selector)
+
+// Character is the predicate function for character builders.
+type Character func(*sql.Selector)
+
+// Job is the predicate function for job builders.
+type Job func(*sql.Selector)
+
+// Turn is the predicate function for turn builders.
+type Turn func(*sql.Selector)
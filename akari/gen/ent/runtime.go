@@ -2,8 +2,129 @@
 
 package ent
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kizuna-org/akari/ent/schema"
+	"github.com/kizuna-org/akari/gen/ent/appstate"
+	"github.com/kizuna-org/akari/gen/ent/character"
+	"github.com/kizuna-org/akari/gen/ent/job"
+	"github.com/kizuna-org/akari/gen/ent/turn"
+)
+
 // The init function reads all schema descriptors with runtime code
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	appstateFields := schema.AppState{}.Fields()
+	_ = appstateFields
+	// appstateDescKey is the schema descriptor for key field.
+	appstateDescKey := appstateFields[0].Descriptor()
+	// appstate.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	appstate.KeyValidator = appstateDescKey.Validators[0].(func(string) error)
+	// appstateDescUpdatedAt is the schema descriptor for updated_at field.
+	appstateDescUpdatedAt := appstateFields[2].Descriptor()
+	// appstate.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	appstate.DefaultUpdatedAt = appstateDescUpdatedAt.Default.(func() time.Time)
+	// appstate.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	appstate.UpdateDefaultUpdatedAt = appstateDescUpdatedAt.UpdateDefault.(func() time.Time)
+	characterFields := schema.Character{}.Fields()
+	_ = characterFields
+	// characterDescName is the schema descriptor for name field.
+	characterDescName := characterFields[1].Descriptor()
+	// character.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	character.NameValidator = characterDescName.Validators[0].(func(string) error)
+	// characterDescPersona is the schema descriptor for persona field.
+	characterDescPersona := characterFields[2].Descriptor()
+	// character.DefaultPersona holds the default value on creation for the persona field.
+	character.DefaultPersona = characterDescPersona.Default.(string)
+	// characterDescPromptTemplate is the schema descriptor for prompt_template field.
+	characterDescPromptTemplate := characterFields[3].Descriptor()
+	// character.DefaultPromptTemplate holds the default value on creation for the prompt_template field.
+	character.DefaultPromptTemplate = characterDescPromptTemplate.Default.(string)
+	// characterDescChannelIds is the schema descriptor for channel_ids field.
+	characterDescChannelIds := characterFields[4].Descriptor()
+	// character.DefaultChannelIds holds the default value on creation for the channel_ids field.
+	character.DefaultChannelIds = characterDescChannelIds.Default.([]string)
+	// characterDescTools is the schema descriptor for tools field.
+	characterDescTools := characterFields[5].Descriptor()
+	// character.DefaultTools holds the default value on creation for the tools field.
+	character.DefaultTools = characterDescTools.Default.([]string)
+	// characterDescCreatedAt is the schema descriptor for created_at field.
+	characterDescCreatedAt := characterFields[6].Descriptor()
+	// character.DefaultCreatedAt holds the default value on creation for the created_at field.
+	character.DefaultCreatedAt = characterDescCreatedAt.Default.(func() time.Time)
+	// characterDescUpdatedAt is the schema descriptor for updated_at field.
+	characterDescUpdatedAt := characterFields[7].Descriptor()
+	// character.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	character.DefaultUpdatedAt = characterDescUpdatedAt.Default.(func() time.Time)
+	// character.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	character.UpdateDefaultUpdatedAt = characterDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// characterDescID is the schema descriptor for id field.
+	characterDescID := characterFields[0].Descriptor()
+	// character.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	character.IDValidator = characterDescID.Validators[0].(func(string) error)
+	jobFields := schema.Job{}.Fields()
+	_ = jobFields
+	// jobDescKind is the schema descriptor for kind field.
+	jobDescKind := jobFields[1].Descriptor()
+	// job.KindValidator is a validator for the "kind" field. It is called by the builders before save.
+	job.KindValidator = jobDescKind.Validators[0].(func(string) error)
+	// jobDescAttempts is the schema descriptor for attempts field.
+	jobDescAttempts := jobFields[5].Descriptor()
+	// job.DefaultAttempts holds the default value on creation for the attempts field.
+	job.DefaultAttempts = jobDescAttempts.Default.(int)
+	// jobDescLastError is the schema descriptor for last_error field.
+	jobDescLastError := jobFields[6].Descriptor()
+	// job.DefaultLastError holds the default value on creation for the last_error field.
+	job.DefaultLastError = jobDescLastError.Default.(string)
+	// jobDescLeaseOwner is the schema descriptor for lease_owner field.
+	jobDescLeaseOwner := jobFields[7].Descriptor()
+	// job.DefaultLeaseOwner holds the default value on creation for the lease_owner field.
+	job.DefaultLeaseOwner = jobDescLeaseOwner.Default.(string)
+	// jobDescCreatedAt is the schema descriptor for created_at field.
+	jobDescCreatedAt := jobFields[9].Descriptor()
+	// job.DefaultCreatedAt holds the default value on creation for the created_at field.
+	job.DefaultCreatedAt = jobDescCreatedAt.Default.(func() time.Time)
+	// jobDescUpdatedAt is the schema descriptor for updated_at field.
+	jobDescUpdatedAt := jobFields[10].Descriptor()
+	// job.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	job.DefaultUpdatedAt = jobDescUpdatedAt.Default.(func() time.Time)
+	// job.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	job.UpdateDefaultUpdatedAt = jobDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// jobDescID is the schema descriptor for id field.
+	jobDescID := jobFields[0].Descriptor()
+	// job.DefaultID holds the default value on creation for the id field.
+	job.DefaultID = jobDescID.Default.(func() uuid.UUID)
+	turnFields := schema.Turn{}.Fields()
+	_ = turnFields
+	// turnDescGuildID is the schema descriptor for guild_id field.
+	turnDescGuildID := turnFields[2].Descriptor()
+	// turn.DefaultGuildID holds the default value on creation for the guild_id field.
+	turn.DefaultGuildID = turnDescGuildID.Default.(string)
+	// turnDescChannelID is the schema descriptor for channel_id field.
+	turnDescChannelID := turnFields[3].Descriptor()
+	// turn.ChannelIDValidator is a validator for the "channel_id" field. It is called by the builders before save.
+	turn.ChannelIDValidator = turnDescChannelID.Validators[0].(func(string) error)
+	// turnDescAuthorID is the schema descriptor for author_id field.
+	turnDescAuthorID := turnFields[4].Descriptor()
+	// turn.AuthorIDValidator is a validator for the "author_id" field. It is called by the builders before save.
+	turn.AuthorIDValidator = turnDescAuthorID.Validators[0].(func(string) error)
+	// turnDescMemories is the schema descriptor for memories field.
+	turnDescMemories := turnFields[8].Descriptor()
+	// turn.DefaultMemories holds the default value on creation for the memories field.
+	turn.DefaultMemories = turnDescMemories.Default.([]string)
+	// turnDescAttachments is the schema descriptor for attachments field.
+	turnDescAttachments := turnFields[9].Descriptor()
+	// turn.DefaultAttachments holds the default value on creation for the attachments field.
+	turn.DefaultAttachments = turnDescAttachments.Default.([]schema.Attachment)
+	// turnDescCreatedAt is the schema descriptor for created_at field.
+	turnDescCreatedAt := turnFields[10].Descriptor()
+	// turn.DefaultCreatedAt holds the default value on creation for the created_at field.
+	turn.DefaultCreatedAt = turnDescCreatedAt.Default.(func() time.Time)
+	// turnDescID is the schema descriptor for id field.
+	turnDescID := turnFields[0].Descriptor()
+	// turn.DefaultID holds the default value on creation for the id field.
+	turn.DefaultID = turnDescID.Default.(func() uuid.UUID)
 }
@@ -0,0 +1,214 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/kizuna-org/akari/ent/schema"
+	"github.com/kizuna-org/akari/gen/ent/turn"
+)
+
+// Turn is the model entity for the Turn schema.
+type Turn struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// CharacterID holds the value of the "character_id" field.
+	CharacterID string `json:"character_id,omitempty"`
+	// GuildID holds the value of the "guild_id" field.
+	GuildID string `json:"guild_id,omitempty"`
+	// ChannelID holds the value of the "channel_id" field.
+	ChannelID string `json:"channel_id,omitempty"`
+	// AuthorID holds the value of the "author_id" field.
+	AuthorID string `json:"author_id,omitempty"`
+	// AuthorName holds the value of the "author_name" field.
+	AuthorName string `json:"author_name,omitempty"`
+	// Message holds the value of the "message" field.
+	Message string `json:"message,omitempty"`
+	// Reply holds the value of the "reply" field.
+	Reply string `json:"reply,omitempty"`
+	// Memories holds the value of the "memories" field.
+	Memories []string `json:"memories,omitempty"`
+	// Attachments holds the value of the "attachments" field.
+	Attachments []schema.Attachment `json:"attachments,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Turn) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case turn.FieldMemories, turn.FieldAttachments:
+			values[i] = new([]byte)
+		case turn.FieldCharacterID, turn.FieldGuildID, turn.FieldChannelID, turn.FieldAuthorID, turn.FieldAuthorName, turn.FieldMessage, turn.FieldReply:
+			values[i] = new(sql.NullString)
+		case turn.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case turn.FieldID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Turn fields.
+func (_m *Turn) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case turn.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case turn.FieldCharacterID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field character_id", values[i])
+			} else if value.Valid {
+				_m.CharacterID = value.String
+			}
+		case turn.FieldGuildID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field guild_id", values[i])
+			} else if value.Valid {
+				_m.GuildID = value.String
+			}
+		case turn.FieldChannelID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field channel_id", values[i])
+			} else if value.Valid {
+				_m.ChannelID = value.String
+			}
+		case turn.FieldAuthorID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field author_id", values[i])
+			} else if value.Valid {
+				_m.AuthorID = value.String
+			}
+		case turn.FieldAuthorName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field author_name", values[i])
+			} else if value.Valid {
+				_m.AuthorName = value.String
+			}
+		case turn.FieldMessage:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field message", values[i])
+			} else if value.Valid {
+				_m.Message = value.String
+			}
+		case turn.FieldReply:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field reply", values[i])
+			} else if value.Valid {
+				_m.Reply = value.String
+			}
+		case turn.FieldMemories:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field memories", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Memories); err != nil {
+					return fmt.Errorf("unmarshal field memories: %w", err)
+				}
+			}
+		case turn.FieldAttachments:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field attachments", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Attachments); err != nil {
+					return fmt.Errorf("unmarshal field attachments: %w", err)
+				}
+			}
+		case turn.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Turn.
+// This includes values selected through modifiers, order, etc.
+func (_m *Turn) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Turn.
+// Note that you need to call Turn.Unwrap() before calling this method if this Turn
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Turn) Update() *TurnUpdateOne {
+	return NewTurnClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Turn entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Turn) Unwrap() *Turn {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Turn is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Turn) String() string {
+	var builder strings.Builder
+	builder.WriteString("Turn(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("character_id=")
+	builder.WriteString(_m.CharacterID)
+	builder.WriteString(", ")
+	builder.WriteString("guild_id=")
+	builder.WriteString(_m.GuildID)
+	builder.WriteString(", ")
+	builder.WriteString("channel_id=")
+	builder.WriteString(_m.ChannelID)
+	builder.WriteString(", ")
+	builder.WriteString("author_id=")
+	builder.WriteString(_m.AuthorID)
+	builder.WriteString(", ")
+	builder.WriteString("author_name=")
+	builder.WriteString(_m.AuthorName)
+	builder.WriteString(", ")
+	builder.WriteString("message=")
+	builder.WriteString(_m.Message)
+	builder.WriteString(", ")
+	builder.WriteString("reply=")
+	builder.WriteString(_m.Reply)
+	builder.WriteString(", ")
+	builder.WriteString("memories=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Memories))
+	builder.WriteString(", ")
+	builder.WriteString("attachments=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Attachments))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Turns is a parsable slice of Turn.
+type Turns []*Turn
@@ -0,0 +1,689 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/kizuna-org/akari/ent/schema"
+	"github.com/kizuna-org/akari/gen/ent/turn"
+)
+
+// TurnCreate is the builder for creating a Turn entity.
+type TurnCreate struct {
+	config
+	mutation *TurnMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetCharacterID sets the "character_id" field.
+func (_c *TurnCreate) SetCharacterID(v string) *TurnCreate {
+	_c.mutation.SetCharacterID(v)
+	return _c
+}
+
+// SetGuildID sets the "guild_id" field.
+func (_c *TurnCreate) SetGuildID(v string) *TurnCreate {
+	_c.mutation.SetGuildID(v)
+	return _c
+}
+
+// SetNillableGuildID sets the "guild_id" field if the given value is not nil.
+func (_c *TurnCreate) SetNillableGuildID(v *string) *TurnCreate {
+	if v != nil {
+		_c.SetGuildID(*v)
+	}
+	return _c
+}
+
+// SetChannelID sets the "channel_id" field.
+func (_c *TurnCreate) SetChannelID(v string) *TurnCreate {
+	_c.mutation.SetChannelID(v)
+	return _c
+}
+
+// SetAuthorID sets the "author_id" field.
+func (_c *TurnCreate) SetAuthorID(v string) *TurnCreate {
+	_c.mutation.SetAuthorID(v)
+	return _c
+}
+
+// SetAuthorName sets the "author_name" field.
+func (_c *TurnCreate) SetAuthorName(v string) *TurnCreate {
+	_c.mutation.SetAuthorName(v)
+	return _c
+}
+
+// SetMessage sets the "message" field.
+func (_c *TurnCreate) SetMessage(v string) *TurnCreate {
+	_c.mutation.SetMessage(v)
+	return _c
+}
+
+// SetReply sets the "reply" field.
+func (_c *TurnCreate) SetReply(v string) *TurnCreate {
+	_c.mutation.SetReply(v)
+	return _c
+}
+
+// SetMemories sets the "memories" field.
+func (_c *TurnCreate) SetMemories(v []string) *TurnCreate {
+	_c.mutation.SetMemories(v)
+	return _c
+}
+
+// SetAttachments sets the "attachments" field.
+func (_c *TurnCreate) SetAttachments(v []schema.Attachment) *TurnCreate {
+	_c.mutation.SetAttachments(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *TurnCreate) SetCreatedAt(v time.Time) *TurnCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *TurnCreate) SetNillableCreatedAt(v *time.Time) *TurnCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *TurnCreate) SetID(v uuid.UUID) *TurnCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *TurnCreate) SetNillableID(v *uuid.UUID) *TurnCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the TurnMutation object of the builder.
+func (_c *TurnCreate) Mutation() *TurnMutation {
+	return _c.mutation
+}
+
+// Save creates the Turn in the database.
+func (_c *TurnCreate) Save(ctx context.Context) (*Turn, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *TurnCreate) SaveX(ctx context.Context) *Turn {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TurnCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TurnCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *TurnCreate) defaults() {
+	if _, ok := _c.mutation.GuildID(); !ok {
+		v := turn.DefaultGuildID
+		_c.mutation.SetGuildID(v)
+	}
+	if _, ok := _c.mutation.Memories(); !ok {
+		v := turn.DefaultMemories
+		_c.mutation.SetMemories(v)
+	}
+	if _, ok := _c.mutation.Attachments(); !ok {
+		v := turn.DefaultAttachments
+		_c.mutation.SetAttachments(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := turn.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := turn.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *TurnCreate) check() error {
+	if _, ok := _c.mutation.CharacterID(); !ok {
+		return &ValidationError{Name: "character_id", err: errors.New(`ent: missing required field "Turn.character_id"`)}
+	}
+	if _, ok := _c.mutation.GuildID(); !ok {
+		return &ValidationError{Name: "guild_id", err: errors.New(`ent: missing required field "Turn.guild_id"`)}
+	}
+	if _, ok := _c.mutation.ChannelID(); !ok {
+		return &ValidationError{Name: "channel_id", err: errors.New(`ent: missing required field "Turn.channel_id"`)}
+	}
+	if v, ok := _c.mutation.ChannelID(); ok {
+		if err := turn.ChannelIDValidator(v); err != nil {
+			return &ValidationError{Name: "channel_id", err: fmt.Errorf(`ent: validator failed for field "Turn.channel_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.AuthorID(); !ok {
+		return &ValidationError{Name: "author_id", err: errors.New(`ent: missing required field "Turn.author_id"`)}
+	}
+	if v, ok := _c.mutation.AuthorID(); ok {
+		if err := turn.AuthorIDValidator(v); err != nil {
+			return &ValidationError{Name: "author_id", err: fmt.Errorf(`ent: validator failed for field "Turn.author_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.AuthorName(); !ok {
+		return &ValidationError{Name: "author_name", err: errors.New(`ent: missing required field "Turn.author_name"`)}
+	}
+	if _, ok := _c.mutation.Message(); !ok {
+		return &ValidationError{Name: "message", err: errors.New(`ent: missing required field "Turn.message"`)}
+	}
+	if _, ok := _c.mutation.Reply(); !ok {
+		return &ValidationError{Name: "reply", err: errors.New(`ent: missing required field "Turn.reply"`)}
+	}
+	if _, ok := _c.mutation.Memories(); !ok {
+		return &ValidationError{Name: "memories", err: errors.New(`ent: missing required field "Turn.memories"`)}
+	}
+	if _, ok := _c.mutation.Attachments(); !ok {
+		return &ValidationError{Name: "attachments", err: errors.New(`ent: missing required field "Turn.attachments"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Turn.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *TurnCreate) sqlSave(ctx context.Context) (*Turn, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *TurnCreate) createSpec() (*Turn, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Turn{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(turn.Table, sqlgraph.NewFieldSpec(turn.FieldID, field.TypeUUID))
+	)
+	_spec.OnConflict = _c.conflict
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.CharacterID(); ok {
+		_spec.SetField(turn.FieldCharacterID, field.TypeString, value)
+		_node.CharacterID = value
+	}
+	if value, ok := _c.mutation.GuildID(); ok {
+		_spec.SetField(turn.FieldGuildID, field.TypeString, value)
+		_node.GuildID = value
+	}
+	if value, ok := _c.mutation.ChannelID(); ok {
+		_spec.SetField(turn.FieldChannelID, field.TypeString, value)
+		_node.ChannelID = value
+	}
+	if value, ok := _c.mutation.AuthorID(); ok {
+		_spec.SetField(turn.FieldAuthorID, field.TypeString, value)
+		_node.AuthorID = value
+	}
+	if value, ok := _c.mutation.AuthorName(); ok {
+		_spec.SetField(turn.FieldAuthorName, field.TypeString, value)
+		_node.AuthorName = value
+	}
+	if value, ok := _c.mutation.Message(); ok {
+		_spec.SetField(turn.FieldMessage, field.TypeString, value)
+		_node.Message = value
+	}
+	if value, ok := _c.mutation.Reply(); ok {
+		_spec.SetField(turn.FieldReply, field.TypeString, value)
+		_node.Reply = value
+	}
+	if value, ok := _c.mutation.Memories(); ok {
+		_spec.SetField(turn.FieldMemories, field.TypeJSON, value)
+		_node.Memories = value
+	}
+	if value, ok := _c.mutation.Attachments(); ok {
+		_spec.SetField(turn.FieldAttachments, field.TypeJSON, value)
+		_node.Attachments = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(turn.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Turn.Create().
+//		SetCharacterID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.TurnUpsert) {
+//			SetCharacterID(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *TurnCreate) OnConflict(opts ...sql.ConflictOption) *TurnUpsertOne {
+	_c.conflict = opts
+	return &TurnUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Turn.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *TurnCreate) OnConflictColumns(columns ...string) *TurnUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &TurnUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// TurnUpsertOne is the builder for "upsert"-ing
+	//  one Turn node.
+	TurnUpsertOne struct {
+		create *TurnCreate
+	}
+
+	// TurnUpsert is the "OnConflict" setter.
+	TurnUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.Turn.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(turn.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *TurnUpsertOne) UpdateNewValues() *TurnUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(turn.FieldID)
+		}
+		if _, exists := u.create.mutation.CharacterID(); exists {
+			s.SetIgnore(turn.FieldCharacterID)
+		}
+		if _, exists := u.create.mutation.GuildID(); exists {
+			s.SetIgnore(turn.FieldGuildID)
+		}
+		if _, exists := u.create.mutation.ChannelID(); exists {
+			s.SetIgnore(turn.FieldChannelID)
+		}
+		if _, exists := u.create.mutation.AuthorID(); exists {
+			s.SetIgnore(turn.FieldAuthorID)
+		}
+		if _, exists := u.create.mutation.AuthorName(); exists {
+			s.SetIgnore(turn.FieldAuthorName)
+		}
+		if _, exists := u.create.mutation.Message(); exists {
+			s.SetIgnore(turn.FieldMessage)
+		}
+		if _, exists := u.create.mutation.Reply(); exists {
+			s.SetIgnore(turn.FieldReply)
+		}
+		if _, exists := u.create.mutation.Memories(); exists {
+			s.SetIgnore(turn.FieldMemories)
+		}
+		if _, exists := u.create.mutation.Attachments(); exists {
+			s.SetIgnore(turn.FieldAttachments)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(turn.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Turn.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *TurnUpsertOne) Ignore() *TurnUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *TurnUpsertOne) DoNothing() *TurnUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the TurnCreate.OnConflict
+// documentation for more info.
+func (u *TurnUpsertOne) Update(set func(*TurnUpsert)) *TurnUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&TurnUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// Exec executes the query.
+func (u *TurnUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for TurnCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *TurnUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *TurnUpsertOne) ID(ctx context.Context) (id uuid.UUID, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: TurnUpsertOne.ID is not supported by MySQL driver. Use TurnUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *TurnUpsertOne) IDX(ctx context.Context) uuid.UUID {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// TurnCreateBulk is the builder for creating many Turn entities in bulk.
+type TurnCreateBulk struct {
+	config
+	err      error
+	builders []*TurnCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the Turn entities in the database.
+func (_c *TurnCreateBulk) Save(ctx context.Context) ([]*Turn, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Turn, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TurnMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *TurnCreateBulk) SaveX(ctx context.Context) []*Turn {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TurnCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TurnCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Turn.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.TurnUpsert) {
+//			SetCharacterID(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *TurnCreateBulk) OnConflict(opts ...sql.ConflictOption) *TurnUpsertBulk {
+	_c.conflict = opts
+	return &TurnUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Turn.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *TurnCreateBulk) OnConflictColumns(columns ...string) *TurnUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &TurnUpsertBulk{
+		create: _c,
+	}
+}
+
+// TurnUpsertBulk is the builder for "upsert"-ing
+// a bulk of Turn nodes.
+type TurnUpsertBulk struct {
+	create *TurnCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.Turn.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(turn.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *TurnUpsertBulk) UpdateNewValues() *TurnUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(turn.FieldID)
+			}
+			if _, exists := b.mutation.CharacterID(); exists {
+				s.SetIgnore(turn.FieldCharacterID)
+			}
+			if _, exists := b.mutation.GuildID(); exists {
+				s.SetIgnore(turn.FieldGuildID)
+			}
+			if _, exists := b.mutation.ChannelID(); exists {
+				s.SetIgnore(turn.FieldChannelID)
+			}
+			if _, exists := b.mutation.AuthorID(); exists {
+				s.SetIgnore(turn.FieldAuthorID)
+			}
+			if _, exists := b.mutation.AuthorName(); exists {
+				s.SetIgnore(turn.FieldAuthorName)
+			}
+			if _, exists := b.mutation.Message(); exists {
+				s.SetIgnore(turn.FieldMessage)
+			}
+			if _, exists := b.mutation.Reply(); exists {
+				s.SetIgnore(turn.FieldReply)
+			}
+			if _, exists := b.mutation.Memories(); exists {
+				s.SetIgnore(turn.FieldMemories)
+			}
+			if _, exists := b.mutation.Attachments(); exists {
+				s.SetIgnore(turn.FieldAttachments)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(turn.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Turn.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *TurnUpsertBulk) Ignore() *TurnUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *TurnUpsertBulk) DoNothing() *TurnUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the TurnCreateBulk.OnConflict
+// documentation for more info.
+func (u *TurnUpsertBulk) Update(set func(*TurnUpsert)) *TurnUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&TurnUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// Exec executes the query.
+func (u *TurnUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the TurnCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for TurnCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *TurnUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -14,6 +14,12 @@ type Tx struct {
 	config
 	// AppState is the client for interacting with the AppState builders.
 	AppState *AppStateClient
+	// Character is the client for interacting with the Character builders.
+	Character *CharacterClient
+	// Job is the client for interacting with the Job builders.
+	Job *JobClient
+	// Turn is the client for interacting with the Turn builders.
+	Turn *TurnClient
 
 	// lazily loaded.
 	client     *Client
@@ -146,6 +152,9 @@ func (tx *Tx) Client() *Client {
 
 func (tx *Tx) init() {
 	tx.AppState = NewAppStateClient(tx.config)
+	tx.Character = NewCharacterClient(tx.config)
+	tx.Job = NewJobClient(tx.config)
+	tx.Turn = NewTurnClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
@@ -0,0 +1,178 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: akari/v1/account.proto
+
+package akariv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateLinkCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLinkCodeRequest) Reset() {
+	*x = CreateLinkCodeRequest{}
+	mi := &file_akari_v1_account_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLinkCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLinkCodeRequest) ProtoMessage() {}
+
+func (x *CreateLinkCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_account_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLinkCodeRequest.ProtoReflect.Descriptor instead.
+func (*CreateLinkCodeRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_account_proto_rawDescGZIP(), []int{0}
+}
+
+type CreateLinkCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLinkCodeResponse) Reset() {
+	*x = CreateLinkCodeResponse{}
+	mi := &file_akari_v1_account_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLinkCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLinkCodeResponse) ProtoMessage() {}
+
+func (x *CreateLinkCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_account_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLinkCodeResponse.ProtoReflect.Descriptor instead.
+func (*CreateLinkCodeResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_account_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateLinkCodeResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateLinkCodeResponse) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+var File_akari_v1_account_proto protoreflect.FileDescriptor
+
+const file_akari_v1_account_proto_rawDesc = "" +
+	"\n" +
+	"\x16akari/v1/account.proto\x12\bakari.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x17\n" +
+	"\x15CreateLinkCodeRequest\"i\n" +
+	"\x16CreateLinkCodeResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12;\n" +
+	"\vexpire_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime2e\n" +
+	"\x0eAccountService\x12S\n" +
+	"\x0eCreateLinkCode\x12\x1f.akari.v1.CreateLinkCodeRequest\x1a .akari.v1.CreateLinkCodeResponseB\x95\x01\n" +
+	"\fcom.akari.v1B\fAccountProtoP\x01Z6github.com/kizuna-org/akari/gen/proto/akari/v1;akariv1\xa2\x02\x03AXX\xaa\x02\bAkari.V1\xca\x02\bAkari\\V1\xe2\x02\x14Akari\\V1\\GPBMetadata\xea\x02\tAkari::V1b\x06proto3"
+
+var (
+	file_akari_v1_account_proto_rawDescOnce sync.Once
+	file_akari_v1_account_proto_rawDescData []byte
+)
+
+func file_akari_v1_account_proto_rawDescGZIP() []byte {
+	file_akari_v1_account_proto_rawDescOnce.Do(func() {
+		file_akari_v1_account_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_akari_v1_account_proto_rawDesc), len(file_akari_v1_account_proto_rawDesc)))
+	})
+	return file_akari_v1_account_proto_rawDescData
+}
+
+var file_akari_v1_account_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_akari_v1_account_proto_goTypes = []any{
+	(*CreateLinkCodeRequest)(nil),  // 0: akari.v1.CreateLinkCodeRequest
+	(*CreateLinkCodeResponse)(nil), // 1: akari.v1.CreateLinkCodeResponse
+	(*timestamppb.Timestamp)(nil),  // 2: google.protobuf.Timestamp
+}
+var file_akari_v1_account_proto_depIdxs = []int32{
+	2, // 0: akari.v1.CreateLinkCodeResponse.expire_time:type_name -> google.protobuf.Timestamp
+	0, // 1: akari.v1.AccountService.CreateLinkCode:input_type -> akari.v1.CreateLinkCodeRequest
+	1, // 2: akari.v1.AccountService.CreateLinkCode:output_type -> akari.v1.CreateLinkCodeResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_akari_v1_account_proto_init() }
+func file_akari_v1_account_proto_init() {
+	if File_akari_v1_account_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_akari_v1_account_proto_rawDesc), len(file_akari_v1_account_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_akari_v1_account_proto_goTypes,
+		DependencyIndexes: file_akari_v1_account_proto_depIdxs,
+		MessageInfos:      file_akari_v1_account_proto_msgTypes,
+	}.Build()
+	File_akari_v1_account_proto = out.File
+	file_akari_v1_account_proto_goTypes = nil
+	file_akari_v1_account_proto_depIdxs = nil
+}
@@ -0,0 +1,551 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: akari/v1/admin.proto
+
+package akariv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetRateLimitStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRateLimitStatsRequest) Reset() {
+	*x = GetRateLimitStatsRequest{}
+	mi := &file_akari_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRateLimitStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRateLimitStatsRequest) ProtoMessage() {}
+
+func (x *GetRateLimitStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRateLimitStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetRateLimitStatsRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type GetRateLimitStatsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Allowed        int64                  `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	LimitedUser    int64                  `protobuf:"varint,2,opt,name=limited_user,json=limitedUser,proto3" json:"limited_user,omitempty"`
+	LimitedChannel int64                  `protobuf:"varint,3,opt,name=limited_channel,json=limitedChannel,proto3" json:"limited_channel,omitempty"`
+	LimitedGlobal  int64                  `protobuf:"varint,4,opt,name=limited_global,json=limitedGlobal,proto3" json:"limited_global,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetRateLimitStatsResponse) Reset() {
+	*x = GetRateLimitStatsResponse{}
+	mi := &file_akari_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRateLimitStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRateLimitStatsResponse) ProtoMessage() {}
+
+func (x *GetRateLimitStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRateLimitStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetRateLimitStatsResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetRateLimitStatsResponse) GetAllowed() int64 {
+	if x != nil {
+		return x.Allowed
+	}
+	return 0
+}
+
+func (x *GetRateLimitStatsResponse) GetLimitedUser() int64 {
+	if x != nil {
+		return x.LimitedUser
+	}
+	return 0
+}
+
+func (x *GetRateLimitStatsResponse) GetLimitedChannel() int64 {
+	if x != nil {
+		return x.LimitedChannel
+	}
+	return 0
+}
+
+func (x *GetRateLimitStatsResponse) GetLimitedGlobal() int64 {
+	if x != nil {
+		return x.LimitedGlobal
+	}
+	return 0
+}
+
+type SleepRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CharacterId   string                 `protobuf:"bytes,1,opt,name=character_id,json=characterId,proto3" json:"character_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SleepRequest) Reset() {
+	*x = SleepRequest{}
+	mi := &file_akari_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SleepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SleepRequest) ProtoMessage() {}
+
+func (x *SleepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SleepRequest.ProtoReflect.Descriptor instead.
+func (*SleepRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SleepRequest) GetCharacterId() string {
+	if x != nil {
+		return x.CharacterId
+	}
+	return ""
+}
+
+type SleepResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SleepResponse) Reset() {
+	*x = SleepResponse{}
+	mi := &file_akari_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SleepResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SleepResponse) ProtoMessage() {}
+
+func (x *SleepResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SleepResponse.ProtoReflect.Descriptor instead.
+func (*SleepResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+type RuntimeConfigEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// source is "default" (the environment), "file" or "override".
+	Source        string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuntimeConfigEntry) Reset() {
+	*x = RuntimeConfigEntry{}
+	mi := &file_akari_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuntimeConfigEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuntimeConfigEntry) ProtoMessage() {}
+
+func (x *RuntimeConfigEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuntimeConfigEntry.ProtoReflect.Descriptor instead.
+func (*RuntimeConfigEntry) Descriptor() ([]byte, []int) {
+	return file_akari_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RuntimeConfigEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *RuntimeConfigEntry) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *RuntimeConfigEntry) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type GetRuntimeConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRuntimeConfigRequest) Reset() {
+	*x = GetRuntimeConfigRequest{}
+	mi := &file_akari_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRuntimeConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRuntimeConfigRequest) ProtoMessage() {}
+
+func (x *GetRuntimeConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRuntimeConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetRuntimeConfigRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+type GetRuntimeConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*RuntimeConfigEntry  `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRuntimeConfigResponse) Reset() {
+	*x = GetRuntimeConfigResponse{}
+	mi := &file_akari_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRuntimeConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRuntimeConfigResponse) ProtoMessage() {}
+
+func (x *GetRuntimeConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRuntimeConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetRuntimeConfigResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetRuntimeConfigResponse) GetEntries() []*RuntimeConfigEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type PatchRuntimeConfigRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// set overrides the given keys.
+	Set map[string]string `protobuf:"bytes,1,rep,name=set,proto3" json:"set,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// unset removes the overrides of the given keys, restoring the file or
+	// default value.
+	Unset         []string `protobuf:"bytes,2,rep,name=unset,proto3" json:"unset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchRuntimeConfigRequest) Reset() {
+	*x = PatchRuntimeConfigRequest{}
+	mi := &file_akari_v1_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchRuntimeConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchRuntimeConfigRequest) ProtoMessage() {}
+
+func (x *PatchRuntimeConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchRuntimeConfigRequest.ProtoReflect.Descriptor instead.
+func (*PatchRuntimeConfigRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PatchRuntimeConfigRequest) GetSet() map[string]string {
+	if x != nil {
+		return x.Set
+	}
+	return nil
+}
+
+func (x *PatchRuntimeConfigRequest) GetUnset() []string {
+	if x != nil {
+		return x.Unset
+	}
+	return nil
+}
+
+type PatchRuntimeConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*RuntimeConfigEntry  `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchRuntimeConfigResponse) Reset() {
+	*x = PatchRuntimeConfigResponse{}
+	mi := &file_akari_v1_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchRuntimeConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchRuntimeConfigResponse) ProtoMessage() {}
+
+func (x *PatchRuntimeConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchRuntimeConfigResponse.ProtoReflect.Descriptor instead.
+func (*PatchRuntimeConfigResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PatchRuntimeConfigResponse) GetEntries() []*RuntimeConfigEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+var File_akari_v1_admin_proto protoreflect.FileDescriptor
+
+const file_akari_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x14akari/v1/admin.proto\x12\bakari.v1\"\x1a\n" +
+	"\x18GetRateLimitStatsRequest\"\xa8\x01\n" +
+	"\x19GetRateLimitStatsResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\x03R\aallowed\x12!\n" +
+	"\flimited_user\x18\x02 \x01(\x03R\vlimitedUser\x12'\n" +
+	"\x0flimited_channel\x18\x03 \x01(\x03R\x0elimitedChannel\x12%\n" +
+	"\x0elimited_global\x18\x04 \x01(\x03R\rlimitedGlobal\"1\n" +
+	"\fSleepRequest\x12!\n" +
+	"\fcharacter_id\x18\x01 \x01(\tR\vcharacterId\"\x0f\n" +
+	"\rSleepResponse\"T\n" +
+	"\x12RuntimeConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\"\x19\n" +
+	"\x17GetRuntimeConfigRequest\"R\n" +
+	"\x18GetRuntimeConfigResponse\x126\n" +
+	"\aentries\x18\x01 \x03(\v2\x1c.akari.v1.RuntimeConfigEntryR\aentries\"\xa9\x01\n" +
+	"\x19PatchRuntimeConfigRequest\x12>\n" +
+	"\x03set\x18\x01 \x03(\v2,.akari.v1.PatchRuntimeConfigRequest.SetEntryR\x03set\x12\x14\n" +
+	"\x05unset\x18\x02 \x03(\tR\x05unset\x1a6\n" +
+	"\bSetEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"T\n" +
+	"\x1aPatchRuntimeConfigResponse\x126\n" +
+	"\aentries\x18\x01 \x03(\v2\x1c.akari.v1.RuntimeConfigEntryR\aentries2\xec\x02\n" +
+	"\fAdminService\x12a\n" +
+	"\x11GetRateLimitStats\x12\".akari.v1.GetRateLimitStatsRequest\x1a#.akari.v1.GetRateLimitStatsResponse\"\x03\x90\x02\x01\x128\n" +
+	"\x05Sleep\x12\x16.akari.v1.SleepRequest\x1a\x17.akari.v1.SleepResponse\x12^\n" +
+	"\x10GetRuntimeConfig\x12!.akari.v1.GetRuntimeConfigRequest\x1a\".akari.v1.GetRuntimeConfigResponse\"\x03\x90\x02\x01\x12_\n" +
+	"\x12PatchRuntimeConfig\x12#.akari.v1.PatchRuntimeConfigRequest\x1a$.akari.v1.PatchRuntimeConfigResponseB\x93\x01\n" +
+	"\fcom.akari.v1B\n" +
+	"AdminProtoP\x01Z6github.com/kizuna-org/akari/gen/proto/akari/v1;akariv1\xa2\x02\x03AXX\xaa\x02\bAkari.V1\xca\x02\bAkari\\V1\xe2\x02\x14Akari\\V1\\GPBMetadata\xea\x02\tAkari::V1b\x06proto3"
+
+var (
+	file_akari_v1_admin_proto_rawDescOnce sync.Once
+	file_akari_v1_admin_proto_rawDescData []byte
+)
+
+func file_akari_v1_admin_proto_rawDescGZIP() []byte {
+	file_akari_v1_admin_proto_rawDescOnce.Do(func() {
+		file_akari_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_akari_v1_admin_proto_rawDesc), len(file_akari_v1_admin_proto_rawDesc)))
+	})
+	return file_akari_v1_admin_proto_rawDescData
+}
+
+var file_akari_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_akari_v1_admin_proto_goTypes = []any{
+	(*GetRateLimitStatsRequest)(nil),   // 0: akari.v1.GetRateLimitStatsRequest
+	(*GetRateLimitStatsResponse)(nil),  // 1: akari.v1.GetRateLimitStatsResponse
+	(*SleepRequest)(nil),               // 2: akari.v1.SleepRequest
+	(*SleepResponse)(nil),              // 3: akari.v1.SleepResponse
+	(*RuntimeConfigEntry)(nil),         // 4: akari.v1.RuntimeConfigEntry
+	(*GetRuntimeConfigRequest)(nil),    // 5: akari.v1.GetRuntimeConfigRequest
+	(*GetRuntimeConfigResponse)(nil),   // 6: akari.v1.GetRuntimeConfigResponse
+	(*PatchRuntimeConfigRequest)(nil),  // 7: akari.v1.PatchRuntimeConfigRequest
+	(*PatchRuntimeConfigResponse)(nil), // 8: akari.v1.PatchRuntimeConfigResponse
+	nil,                                // 9: akari.v1.PatchRuntimeConfigRequest.SetEntry
+}
+var file_akari_v1_admin_proto_depIdxs = []int32{
+	4, // 0: akari.v1.GetRuntimeConfigResponse.entries:type_name -> akari.v1.RuntimeConfigEntry
+	9, // 1: akari.v1.PatchRuntimeConfigRequest.set:type_name -> akari.v1.PatchRuntimeConfigRequest.SetEntry
+	4, // 2: akari.v1.PatchRuntimeConfigResponse.entries:type_name -> akari.v1.RuntimeConfigEntry
+	0, // 3: akari.v1.AdminService.GetRateLimitStats:input_type -> akari.v1.GetRateLimitStatsRequest
+	2, // 4: akari.v1.AdminService.Sleep:input_type -> akari.v1.SleepRequest
+	5, // 5: akari.v1.AdminService.GetRuntimeConfig:input_type -> akari.v1.GetRuntimeConfigRequest
+	7, // 6: akari.v1.AdminService.PatchRuntimeConfig:input_type -> akari.v1.PatchRuntimeConfigRequest
+	1, // 7: akari.v1.AdminService.GetRateLimitStats:output_type -> akari.v1.GetRateLimitStatsResponse
+	3, // 8: akari.v1.AdminService.Sleep:output_type -> akari.v1.SleepResponse
+	6, // 9: akari.v1.AdminService.GetRuntimeConfig:output_type -> akari.v1.GetRuntimeConfigResponse
+	8, // 10: akari.v1.AdminService.PatchRuntimeConfig:output_type -> akari.v1.PatchRuntimeConfigResponse
+	7, // [7:11] is the sub-list for method output_type
+	3, // [3:7] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_akari_v1_admin_proto_init() }
+func file_akari_v1_admin_proto_init() {
+	if File_akari_v1_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_akari_v1_admin_proto_rawDesc), len(file_akari_v1_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_akari_v1_admin_proto_goTypes,
+		DependencyIndexes: file_akari_v1_admin_proto_depIdxs,
+		MessageInfos:      file_akari_v1_admin_proto_msgTypes,
+	}.Build()
+	File_akari_v1_admin_proto = out.File
+	file_akari_v1_admin_proto_goTypes = nil
+	file_akari_v1_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,113 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: akari/v1/account.proto
+
+package akariv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/kizuna-org/akari/gen/proto/akari/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// AccountServiceName is the fully-qualified name of the AccountService service.
+	AccountServiceName = "akari.v1.AccountService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AccountServiceCreateLinkCodeProcedure is the fully-qualified name of the AccountService's
+	// CreateLinkCode RPC.
+	AccountServiceCreateLinkCodeProcedure = "/akari.v1.AccountService/CreateLinkCode"
+)
+
+// AccountServiceClient is a client for the akari.v1.AccountService service.
+type AccountServiceClient interface {
+	// CreateLinkCode issues a one-time code that links the caller's account to
+	// the Discord user who redeems it with /link before it expires.
+	CreateLinkCode(context.Context, *connect.Request[v1.CreateLinkCodeRequest]) (*connect.Response[v1.CreateLinkCodeResponse], error)
+}
+
+// NewAccountServiceClient constructs a client for the akari.v1.AccountService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAccountServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AccountServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	accountServiceMethods := v1.File_akari_v1_account_proto.Services().ByName("AccountService").Methods()
+	return &accountServiceClient{
+		createLinkCode: connect.NewClient[v1.CreateLinkCodeRequest, v1.CreateLinkCodeResponse](
+			httpClient,
+			baseURL+AccountServiceCreateLinkCodeProcedure,
+			connect.WithSchema(accountServiceMethods.ByName("CreateLinkCode")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// accountServiceClient implements AccountServiceClient.
+type accountServiceClient struct {
+	createLinkCode *connect.Client[v1.CreateLinkCodeRequest, v1.CreateLinkCodeResponse]
+}
+
+// CreateLinkCode calls akari.v1.AccountService.CreateLinkCode.
+func (c *accountServiceClient) CreateLinkCode(ctx context.Context, req *connect.Request[v1.CreateLinkCodeRequest]) (*connect.Response[v1.CreateLinkCodeResponse], error) {
+	return c.createLinkCode.CallUnary(ctx, req)
+}
+
+// AccountServiceHandler is an implementation of the akari.v1.AccountService service.
+type AccountServiceHandler interface {
+	// CreateLinkCode issues a one-time code that links the caller's account to
+	// the Discord user who redeems it with /link before it expires.
+	CreateLinkCode(context.Context, *connect.Request[v1.CreateLinkCodeRequest]) (*connect.Response[v1.CreateLinkCodeResponse], error)
+}
+
+// NewAccountServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAccountServiceHandler(svc AccountServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	accountServiceMethods := v1.File_akari_v1_account_proto.Services().ByName("AccountService").Methods()
+	accountServiceCreateLinkCodeHandler := connect.NewUnaryHandler(
+		AccountServiceCreateLinkCodeProcedure,
+		svc.CreateLinkCode,
+		connect.WithSchema(accountServiceMethods.ByName("CreateLinkCode")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/akari.v1.AccountService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AccountServiceCreateLinkCodeProcedure:
+			accountServiceCreateLinkCodeHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAccountServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAccountServiceHandler struct{}
+
+func (UnimplementedAccountServiceHandler) CreateLinkCode(context.Context, *connect.Request[v1.CreateLinkCodeRequest]) (*connect.Response[v1.CreateLinkCodeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.AccountService.CreateLinkCode is not implemented"))
+}
@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: akari/v1/admin.proto
+
+package akariv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/kizuna-org/akari/gen/proto/akari/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// AdminServiceName is the fully-qualified name of the AdminService service.
+	AdminServiceName = "akari.v1.AdminService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AdminServiceGetRateLimitStatsProcedure is the fully-qualified name of the AdminService's
+	// GetRateLimitStats RPC.
+	AdminServiceGetRateLimitStatsProcedure = "/akari.v1.AdminService/GetRateLimitStats"
+	// AdminServiceSleepProcedure is the fully-qualified name of the AdminService's Sleep RPC.
+	AdminServiceSleepProcedure = "/akari.v1.AdminService/Sleep"
+	// AdminServiceGetRuntimeConfigProcedure is the fully-qualified name of the AdminService's
+	// GetRuntimeConfig RPC.
+	AdminServiceGetRuntimeConfigProcedure = "/akari.v1.AdminService/GetRuntimeConfig"
+	// AdminServicePatchRuntimeConfigProcedure is the fully-qualified name of the AdminService's
+	// PatchRuntimeConfig RPC.
+	AdminServicePatchRuntimeConfigProcedure = "/akari.v1.AdminService/PatchRuntimeConfig"
+)
+
+// AdminServiceClient is a client for the akari.v1.AdminService service.
+type AdminServiceClient interface {
+	// GetRateLimitStats returns the reply rate limiter counters.
+	GetRateLimitStats(context.Context, *connect.Request[v1.GetRateLimitStatsRequest]) (*connect.Response[v1.GetRateLimitStatsResponse], error)
+	// Sleep puts a character to sleep now and returns once kiseki has
+	// consolidated its memories.
+	Sleep(context.Context, *connect.Request[v1.SleepRequest]) (*connect.Response[v1.SleepResponse], error)
+	// GetRuntimeConfig returns the settings that can change without a
+	// redeploy, with the layer each value comes from.
+	GetRuntimeConfig(context.Context, *connect.Request[v1.GetRuntimeConfigRequest]) (*connect.Response[v1.GetRuntimeConfigResponse], error)
+	// PatchRuntimeConfig sets and removes runtime overrides. The patch is
+	// rejected as a whole if any resulting value is invalid.
+	PatchRuntimeConfig(context.Context, *connect.Request[v1.PatchRuntimeConfigRequest]) (*connect.Response[v1.PatchRuntimeConfigResponse], error)
+}
+
+// NewAdminServiceClient constructs a client for the akari.v1.AdminService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAdminServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AdminServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	adminServiceMethods := v1.File_akari_v1_admin_proto.Services().ByName("AdminService").Methods()
+	return &adminServiceClient{
+		getRateLimitStats: connect.NewClient[v1.GetRateLimitStatsRequest, v1.GetRateLimitStatsResponse](
+			httpClient,
+			baseURL+AdminServiceGetRateLimitStatsProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("GetRateLimitStats")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		sleep: connect.NewClient[v1.SleepRequest, v1.SleepResponse](
+			httpClient,
+			baseURL+AdminServiceSleepProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("Sleep")),
+			connect.WithClientOptions(opts...),
+		),
+		getRuntimeConfig: connect.NewClient[v1.GetRuntimeConfigRequest, v1.GetRuntimeConfigResponse](
+			httpClient,
+			baseURL+AdminServiceGetRuntimeConfigProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("GetRuntimeConfig")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		patchRuntimeConfig: connect.NewClient[v1.PatchRuntimeConfigRequest, v1.PatchRuntimeConfigResponse](
+			httpClient,
+			baseURL+AdminServicePatchRuntimeConfigProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("PatchRuntimeConfig")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// adminServiceClient implements AdminServiceClient.
+type adminServiceClient struct {
+	getRateLimitStats  *connect.Client[v1.GetRateLimitStatsRequest, v1.GetRateLimitStatsResponse]
+	sleep              *connect.Client[v1.SleepRequest, v1.SleepResponse]
+	getRuntimeConfig   *connect.Client[v1.GetRuntimeConfigRequest, v1.GetRuntimeConfigResponse]
+	patchRuntimeConfig *connect.Client[v1.PatchRuntimeConfigRequest, v1.PatchRuntimeConfigResponse]
+}
+
+// GetRateLimitStats calls akari.v1.AdminService.GetRateLimitStats.
+func (c *adminServiceClient) GetRateLimitStats(ctx context.Context, req *connect.Request[v1.GetRateLimitStatsRequest]) (*connect.Response[v1.GetRateLimitStatsResponse], error) {
+	return c.getRateLimitStats.CallUnary(ctx, req)
+}
+
+// Sleep calls akari.v1.AdminService.Sleep.
+func (c *adminServiceClient) Sleep(ctx context.Context, req *connect.Request[v1.SleepRequest]) (*connect.Response[v1.SleepResponse], error) {
+	return c.sleep.CallUnary(ctx, req)
+}
+
+// GetRuntimeConfig calls akari.v1.AdminService.GetRuntimeConfig.
+func (c *adminServiceClient) GetRuntimeConfig(ctx context.Context, req *connect.Request[v1.GetRuntimeConfigRequest]) (*connect.Response[v1.GetRuntimeConfigResponse], error) {
+	return c.getRuntimeConfig.CallUnary(ctx, req)
+}
+
+// PatchRuntimeConfig calls akari.v1.AdminService.PatchRuntimeConfig.
+func (c *adminServiceClient) PatchRuntimeConfig(ctx context.Context, req *connect.Request[v1.PatchRuntimeConfigRequest]) (*connect.Response[v1.PatchRuntimeConfigResponse], error) {
+	return c.patchRuntimeConfig.CallUnary(ctx, req)
+}
+
+// AdminServiceHandler is an implementation of the akari.v1.AdminService service.
+type AdminServiceHandler interface {
+	// GetRateLimitStats returns the reply rate limiter counters.
+	GetRateLimitStats(context.Context, *connect.Request[v1.GetRateLimitStatsRequest]) (*connect.Response[v1.GetRateLimitStatsResponse], error)
+	// Sleep puts a character to sleep now and returns once kiseki has
+	// consolidated its memories.
+	Sleep(context.Context, *connect.Request[v1.SleepRequest]) (*connect.Response[v1.SleepResponse], error)
+	// GetRuntimeConfig returns the settings that can change without a
+	// redeploy, with the layer each value comes from.
+	GetRuntimeConfig(context.Context, *connect.Request[v1.GetRuntimeConfigRequest]) (*connect.Response[v1.GetRuntimeConfigResponse], error)
+	// PatchRuntimeConfig sets and removes runtime overrides. The patch is
+	// rejected as a whole if any resulting value is invalid.
+	PatchRuntimeConfig(context.Context, *connect.Request[v1.PatchRuntimeConfigRequest]) (*connect.Response[v1.PatchRuntimeConfigResponse], error)
+}
+
+// NewAdminServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	adminServiceMethods := v1.File_akari_v1_admin_proto.Services().ByName("AdminService").Methods()
+	adminServiceGetRateLimitStatsHandler := connect.NewUnaryHandler(
+		AdminServiceGetRateLimitStatsProcedure,
+		svc.GetRateLimitStats,
+		connect.WithSchema(adminServiceMethods.ByName("GetRateLimitStats")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSleepHandler := connect.NewUnaryHandler(
+		AdminServiceSleepProcedure,
+		svc.Sleep,
+		connect.WithSchema(adminServiceMethods.ByName("Sleep")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceGetRuntimeConfigHandler := connect.NewUnaryHandler(
+		AdminServiceGetRuntimeConfigProcedure,
+		svc.GetRuntimeConfig,
+		connect.WithSchema(adminServiceMethods.ByName("GetRuntimeConfig")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServicePatchRuntimeConfigHandler := connect.NewUnaryHandler(
+		AdminServicePatchRuntimeConfigProcedure,
+		svc.PatchRuntimeConfig,
+		connect.WithSchema(adminServiceMethods.ByName("PatchRuntimeConfig")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/akari.v1.AdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AdminServiceGetRateLimitStatsProcedure:
+			adminServiceGetRateLimitStatsHandler.ServeHTTP(w, r)
+		case AdminServiceSleepProcedure:
+			adminServiceSleepHandler.ServeHTTP(w, r)
+		case AdminServiceGetRuntimeConfigProcedure:
+			adminServiceGetRuntimeConfigHandler.ServeHTTP(w, r)
+		case AdminServicePatchRuntimeConfigProcedure:
+			adminServicePatchRuntimeConfigHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAdminServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAdminServiceHandler struct{}
+
+func (UnimplementedAdminServiceHandler) GetRateLimitStats(context.Context, *connect.Request[v1.GetRateLimitStatsRequest]) (*connect.Response[v1.GetRateLimitStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.AdminService.GetRateLimitStats is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) Sleep(context.Context, *connect.Request[v1.SleepRequest]) (*connect.Response[v1.SleepResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.AdminService.Sleep is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) GetRuntimeConfig(context.Context, *connect.Request[v1.GetRuntimeConfigRequest]) (*connect.Response[v1.GetRuntimeConfigResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.AdminService.GetRuntimeConfig is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) PatchRuntimeConfig(context.Context, *connect.Request[v1.PatchRuntimeConfigRequest]) (*connect.Response[v1.PatchRuntimeConfigResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.AdminService.PatchRuntimeConfig is not implemented"))
+}
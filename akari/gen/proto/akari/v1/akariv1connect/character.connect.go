@@ -0,0 +1,146 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: akari/v1/character.proto
+
+package akariv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/kizuna-org/akari/gen/proto/akari/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// CharacterServiceName is the fully-qualified name of the CharacterService service.
+	CharacterServiceName = "akari.v1.CharacterService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// CharacterServiceListCharactersProcedure is the fully-qualified name of the CharacterService's
+	// ListCharacters RPC.
+	CharacterServiceListCharactersProcedure = "/akari.v1.CharacterService/ListCharacters"
+	// CharacterServiceGetCharacterProcedure is the fully-qualified name of the CharacterService's
+	// GetCharacter RPC.
+	CharacterServiceGetCharacterProcedure = "/akari.v1.CharacterService/GetCharacter"
+)
+
+// CharacterServiceClient is a client for the akari.v1.CharacterService service.
+type CharacterServiceClient interface {
+	// ListCharacters returns every hosted character.
+	ListCharacters(context.Context, *connect.Request[v1.ListCharactersRequest]) (*connect.Response[v1.ListCharactersResponse], error)
+	// GetCharacter returns a single character by its kiseki ID.
+	GetCharacter(context.Context, *connect.Request[v1.GetCharacterRequest]) (*connect.Response[v1.GetCharacterResponse], error)
+}
+
+// NewCharacterServiceClient constructs a client for the akari.v1.CharacterService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewCharacterServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) CharacterServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	characterServiceMethods := v1.File_akari_v1_character_proto.Services().ByName("CharacterService").Methods()
+	return &characterServiceClient{
+		listCharacters: connect.NewClient[v1.ListCharactersRequest, v1.ListCharactersResponse](
+			httpClient,
+			baseURL+CharacterServiceListCharactersProcedure,
+			connect.WithSchema(characterServiceMethods.ByName("ListCharacters")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getCharacter: connect.NewClient[v1.GetCharacterRequest, v1.GetCharacterResponse](
+			httpClient,
+			baseURL+CharacterServiceGetCharacterProcedure,
+			connect.WithSchema(characterServiceMethods.ByName("GetCharacter")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// characterServiceClient implements CharacterServiceClient.
+type characterServiceClient struct {
+	listCharacters *connect.Client[v1.ListCharactersRequest, v1.ListCharactersResponse]
+	getCharacter   *connect.Client[v1.GetCharacterRequest, v1.GetCharacterResponse]
+}
+
+// ListCharacters calls akari.v1.CharacterService.ListCharacters.
+func (c *characterServiceClient) ListCharacters(ctx context.Context, req *connect.Request[v1.ListCharactersRequest]) (*connect.Response[v1.ListCharactersResponse], error) {
+	return c.listCharacters.CallUnary(ctx, req)
+}
+
+// GetCharacter calls akari.v1.CharacterService.GetCharacter.
+func (c *characterServiceClient) GetCharacter(ctx context.Context, req *connect.Request[v1.GetCharacterRequest]) (*connect.Response[v1.GetCharacterResponse], error) {
+	return c.getCharacter.CallUnary(ctx, req)
+}
+
+// CharacterServiceHandler is an implementation of the akari.v1.CharacterService service.
+type CharacterServiceHandler interface {
+	// ListCharacters returns every hosted character.
+	ListCharacters(context.Context, *connect.Request[v1.ListCharactersRequest]) (*connect.Response[v1.ListCharactersResponse], error)
+	// GetCharacter returns a single character by its kiseki ID.
+	GetCharacter(context.Context, *connect.Request[v1.GetCharacterRequest]) (*connect.Response[v1.GetCharacterResponse], error)
+}
+
+// NewCharacterServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewCharacterServiceHandler(svc CharacterServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	characterServiceMethods := v1.File_akari_v1_character_proto.Services().ByName("CharacterService").Methods()
+	characterServiceListCharactersHandler := connect.NewUnaryHandler(
+		CharacterServiceListCharactersProcedure,
+		svc.ListCharacters,
+		connect.WithSchema(characterServiceMethods.ByName("ListCharacters")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	characterServiceGetCharacterHandler := connect.NewUnaryHandler(
+		CharacterServiceGetCharacterProcedure,
+		svc.GetCharacter,
+		connect.WithSchema(characterServiceMethods.ByName("GetCharacter")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/akari.v1.CharacterService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case CharacterServiceListCharactersProcedure:
+			characterServiceListCharactersHandler.ServeHTTP(w, r)
+		case CharacterServiceGetCharacterProcedure:
+			characterServiceGetCharacterHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedCharacterServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedCharacterServiceHandler struct{}
+
+func (UnimplementedCharacterServiceHandler) ListCharacters(context.Context, *connect.Request[v1.ListCharactersRequest]) (*connect.Response[v1.ListCharactersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.CharacterService.ListCharacters is not implemented"))
+}
+
+func (UnimplementedCharacterServiceHandler) GetCharacter(context.Context, *connect.Request[v1.GetCharacterRequest]) (*connect.Response[v1.GetCharacterResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.CharacterService.GetCharacter is not implemented"))
+}
@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: akari/v1/character_admin.proto
+
+package akariv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/kizuna-org/akari/gen/proto/akari/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// CharacterAdminServiceName is the fully-qualified name of the CharacterAdminService service.
+	CharacterAdminServiceName = "akari.v1.CharacterAdminService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// CharacterAdminServiceListCharacterProfilesProcedure is the fully-qualified name of the
+	// CharacterAdminService's ListCharacterProfiles RPC.
+	CharacterAdminServiceListCharacterProfilesProcedure = "/akari.v1.CharacterAdminService/ListCharacterProfiles"
+	// CharacterAdminServiceCreateCharacterProcedure is the fully-qualified name of the
+	// CharacterAdminService's CreateCharacter RPC.
+	CharacterAdminServiceCreateCharacterProcedure = "/akari.v1.CharacterAdminService/CreateCharacter"
+	// CharacterAdminServiceUpdateCharacterProcedure is the fully-qualified name of the
+	// CharacterAdminService's UpdateCharacter RPC.
+	CharacterAdminServiceUpdateCharacterProcedure = "/akari.v1.CharacterAdminService/UpdateCharacter"
+	// CharacterAdminServiceSetChannelEnabledProcedure is the fully-qualified name of the
+	// CharacterAdminService's SetChannelEnabled RPC.
+	CharacterAdminServiceSetChannelEnabledProcedure = "/akari.v1.CharacterAdminService/SetChannelEnabled"
+	// CharacterAdminServicePreviewSystemPromptProcedure is the fully-qualified name of the
+	// CharacterAdminService's PreviewSystemPrompt RPC.
+	CharacterAdminServicePreviewSystemPromptProcedure = "/akari.v1.CharacterAdminService/PreviewSystemPrompt"
+)
+
+// CharacterAdminServiceClient is a client for the akari.v1.CharacterAdminService service.
+type CharacterAdminServiceClient interface {
+	// ListCharacterProfiles returns the editable definition of every
+	// character.
+	ListCharacterProfiles(context.Context, *connect.Request[v1.ListCharacterProfilesRequest]) (*connect.Response[v1.ListCharacterProfilesResponse], error)
+	// CreateCharacter starts hosting a kiseki character.
+	CreateCharacter(context.Context, *connect.Request[v1.CreateCharacterRequest]) (*connect.Response[v1.CreateCharacterResponse], error)
+	// UpdateCharacter replaces the fields named in update_mask.
+	UpdateCharacter(context.Context, *connect.Request[v1.UpdateCharacterRequest]) (*connect.Response[v1.UpdateCharacterResponse], error)
+	// SetChannelEnabled adds or removes a Discord channel from the channels a
+	// character replies in. Enabling a channel for a character that replies
+	// everywhere restricts it to that channel.
+	SetChannelEnabled(context.Context, *connect.Request[v1.SetChannelEnabledRequest]) (*connect.Response[v1.SetChannelEnabledResponse], error)
+	// PreviewSystemPrompt renders a character's system prompt, optionally with
+	// an unsaved persona or prompt template.
+	PreviewSystemPrompt(context.Context, *connect.Request[v1.PreviewSystemPromptRequest]) (*connect.Response[v1.PreviewSystemPromptResponse], error)
+}
+
+// NewCharacterAdminServiceClient constructs a client for the akari.v1.CharacterAdminService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewCharacterAdminServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) CharacterAdminServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	characterAdminServiceMethods := v1.File_akari_v1_character_admin_proto.Services().ByName("CharacterAdminService").Methods()
+	return &characterAdminServiceClient{
+		listCharacterProfiles: connect.NewClient[v1.ListCharacterProfilesRequest, v1.ListCharacterProfilesResponse](
+			httpClient,
+			baseURL+CharacterAdminServiceListCharacterProfilesProcedure,
+			connect.WithSchema(characterAdminServiceMethods.ByName("ListCharacterProfiles")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		createCharacter: connect.NewClient[v1.CreateCharacterRequest, v1.CreateCharacterResponse](
+			httpClient,
+			baseURL+CharacterAdminServiceCreateCharacterProcedure,
+			connect.WithSchema(characterAdminServiceMethods.ByName("CreateCharacter")),
+			connect.WithClientOptions(opts...),
+		),
+		updateCharacter: connect.NewClient[v1.UpdateCharacterRequest, v1.UpdateCharacterResponse](
+			httpClient,
+			baseURL+CharacterAdminServiceUpdateCharacterProcedure,
+			connect.WithSchema(characterAdminServiceMethods.ByName("UpdateCharacter")),
+			connect.WithClientOptions(opts...),
+		),
+		setChannelEnabled: connect.NewClient[v1.SetChannelEnabledRequest, v1.SetChannelEnabledResponse](
+			httpClient,
+			baseURL+CharacterAdminServiceSetChannelEnabledProcedure,
+			connect.WithSchema(characterAdminServiceMethods.ByName("SetChannelEnabled")),
+			connect.WithClientOptions(opts...),
+		),
+		previewSystemPrompt: connect.NewClient[v1.PreviewSystemPromptRequest, v1.PreviewSystemPromptResponse](
+			httpClient,
+			baseURL+CharacterAdminServicePreviewSystemPromptProcedure,
+			connect.WithSchema(characterAdminServiceMethods.ByName("PreviewSystemPrompt")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// characterAdminServiceClient implements CharacterAdminServiceClient.
+type characterAdminServiceClient struct {
+	listCharacterProfiles *connect.Client[v1.ListCharacterProfilesRequest, v1.ListCharacterProfilesResponse]
+	createCharacter       *connect.Client[v1.CreateCharacterRequest, v1.CreateCharacterResponse]
+	updateCharacter       *connect.Client[v1.UpdateCharacterRequest, v1.UpdateCharacterResponse]
+	setChannelEnabled     *connect.Client[v1.SetChannelEnabledRequest, v1.SetChannelEnabledResponse]
+	previewSystemPrompt   *connect.Client[v1.PreviewSystemPromptRequest, v1.PreviewSystemPromptResponse]
+}
+
+// ListCharacterProfiles calls akari.v1.CharacterAdminService.ListCharacterProfiles.
+func (c *characterAdminServiceClient) ListCharacterProfiles(ctx context.Context, req *connect.Request[v1.ListCharacterProfilesRequest]) (*connect.Response[v1.ListCharacterProfilesResponse], error) {
+	return c.listCharacterProfiles.CallUnary(ctx, req)
+}
+
+// CreateCharacter calls akari.v1.CharacterAdminService.CreateCharacter.
+func (c *characterAdminServiceClient) CreateCharacter(ctx context.Context, req *connect.Request[v1.CreateCharacterRequest]) (*connect.Response[v1.CreateCharacterResponse], error) {
+	return c.createCharacter.CallUnary(ctx, req)
+}
+
+// UpdateCharacter calls akari.v1.CharacterAdminService.UpdateCharacter.
+func (c *characterAdminServiceClient) UpdateCharacter(ctx context.Context, req *connect.Request[v1.UpdateCharacterRequest]) (*connect.Response[v1.UpdateCharacterResponse], error) {
+	return c.updateCharacter.CallUnary(ctx, req)
+}
+
+// SetChannelEnabled calls akari.v1.CharacterAdminService.SetChannelEnabled.
+func (c *characterAdminServiceClient) SetChannelEnabled(ctx context.Context, req *connect.Request[v1.SetChannelEnabledRequest]) (*connect.Response[v1.SetChannelEnabledResponse], error) {
+	return c.setChannelEnabled.CallUnary(ctx, req)
+}
+
+// PreviewSystemPrompt calls akari.v1.CharacterAdminService.PreviewSystemPrompt.
+func (c *characterAdminServiceClient) PreviewSystemPrompt(ctx context.Context, req *connect.Request[v1.PreviewSystemPromptRequest]) (*connect.Response[v1.PreviewSystemPromptResponse], error) {
+	return c.previewSystemPrompt.CallUnary(ctx, req)
+}
+
+// CharacterAdminServiceHandler is an implementation of the akari.v1.CharacterAdminService service.
+type CharacterAdminServiceHandler interface {
+	// ListCharacterProfiles returns the editable definition of every
+	// character.
+	ListCharacterProfiles(context.Context, *connect.Request[v1.ListCharacterProfilesRequest]) (*connect.Response[v1.ListCharacterProfilesResponse], error)
+	// CreateCharacter starts hosting a kiseki character.
+	CreateCharacter(context.Context, *connect.Request[v1.CreateCharacterRequest]) (*connect.Response[v1.CreateCharacterResponse], error)
+	// UpdateCharacter replaces the fields named in update_mask.
+	UpdateCharacter(context.Context, *connect.Request[v1.UpdateCharacterRequest]) (*connect.Response[v1.UpdateCharacterResponse], error)
+	// SetChannelEnabled adds or removes a Discord channel from the channels a
+	// character replies in. Enabling a channel for a character that replies
+	// everywhere restricts it to that channel.
+	SetChannelEnabled(context.Context, *connect.Request[v1.SetChannelEnabledRequest]) (*connect.Response[v1.SetChannelEnabledResponse], error)
+	// PreviewSystemPrompt renders a character's system prompt, optionally with
+	// an unsaved persona or prompt template.
+	PreviewSystemPrompt(context.Context, *connect.Request[v1.PreviewSystemPromptRequest]) (*connect.Response[v1.PreviewSystemPromptResponse], error)
+}
+
+// NewCharacterAdminServiceHandler builds an HTTP handler from the service implementation. It
+// returns the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewCharacterAdminServiceHandler(svc CharacterAdminServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	characterAdminServiceMethods := v1.File_akari_v1_character_admin_proto.Services().ByName("CharacterAdminService").Methods()
+	characterAdminServiceListCharacterProfilesHandler := connect.NewUnaryHandler(
+		CharacterAdminServiceListCharacterProfilesProcedure,
+		svc.ListCharacterProfiles,
+		connect.WithSchema(characterAdminServiceMethods.ByName("ListCharacterProfiles")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	characterAdminServiceCreateCharacterHandler := connect.NewUnaryHandler(
+		CharacterAdminServiceCreateCharacterProcedure,
+		svc.CreateCharacter,
+		connect.WithSchema(characterAdminServiceMethods.ByName("CreateCharacter")),
+		connect.WithHandlerOptions(opts...),
+	)
+	characterAdminServiceUpdateCharacterHandler := connect.NewUnaryHandler(
+		CharacterAdminServiceUpdateCharacterProcedure,
+		svc.UpdateCharacter,
+		connect.WithSchema(characterAdminServiceMethods.ByName("UpdateCharacter")),
+		connect.WithHandlerOptions(opts...),
+	)
+	characterAdminServiceSetChannelEnabledHandler := connect.NewUnaryHandler(
+		CharacterAdminServiceSetChannelEnabledProcedure,
+		svc.SetChannelEnabled,
+		connect.WithSchema(characterAdminServiceMethods.ByName("SetChannelEnabled")),
+		connect.WithHandlerOptions(opts...),
+	)
+	characterAdminServicePreviewSystemPromptHandler := connect.NewUnaryHandler(
+		CharacterAdminServicePreviewSystemPromptProcedure,
+		svc.PreviewSystemPrompt,
+		connect.WithSchema(characterAdminServiceMethods.ByName("PreviewSystemPrompt")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/akari.v1.CharacterAdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case CharacterAdminServiceListCharacterProfilesProcedure:
+			characterAdminServiceListCharacterProfilesHandler.ServeHTTP(w, r)
+		case CharacterAdminServiceCreateCharacterProcedure:
+			characterAdminServiceCreateCharacterHandler.ServeHTTP(w, r)
+		case CharacterAdminServiceUpdateCharacterProcedure:
+			characterAdminServiceUpdateCharacterHandler.ServeHTTP(w, r)
+		case CharacterAdminServiceSetChannelEnabledProcedure:
+			characterAdminServiceSetChannelEnabledHandler.ServeHTTP(w, r)
+		case CharacterAdminServicePreviewSystemPromptProcedure:
+			characterAdminServicePreviewSystemPromptHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedCharacterAdminServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedCharacterAdminServiceHandler struct{}
+
+func (UnimplementedCharacterAdminServiceHandler) ListCharacterProfiles(context.Context, *connect.Request[v1.ListCharacterProfilesRequest]) (*connect.Response[v1.ListCharacterProfilesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.CharacterAdminService.ListCharacterProfiles is not implemented"))
+}
+
+func (UnimplementedCharacterAdminServiceHandler) CreateCharacter(context.Context, *connect.Request[v1.CreateCharacterRequest]) (*connect.Response[v1.CreateCharacterResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.CharacterAdminService.CreateCharacter is not implemented"))
+}
+
+func (UnimplementedCharacterAdminServiceHandler) UpdateCharacter(context.Context, *connect.Request[v1.UpdateCharacterRequest]) (*connect.Response[v1.UpdateCharacterResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.CharacterAdminService.UpdateCharacter is not implemented"))
+}
+
+func (UnimplementedCharacterAdminServiceHandler) SetChannelEnabled(context.Context, *connect.Request[v1.SetChannelEnabledRequest]) (*connect.Response[v1.SetChannelEnabledResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.CharacterAdminService.SetChannelEnabled is not implemented"))
+}
+
+func (UnimplementedCharacterAdminServiceHandler) PreviewSystemPrompt(context.Context, *connect.Request[v1.PreviewSystemPromptRequest]) (*connect.Response[v1.PreviewSystemPromptResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.CharacterAdminService.PreviewSystemPrompt is not implemented"))
+}
@@ -0,0 +1,218 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: akari/v1/conversation.proto
+
+package akariv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/kizuna-org/akari/gen/proto/akari/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// ConversationServiceName is the fully-qualified name of the ConversationService service.
+	ConversationServiceName = "akari.v1.ConversationService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// ConversationServiceReplyProcedure is the fully-qualified name of the ConversationService's Reply
+	// RPC.
+	ConversationServiceReplyProcedure = "/akari.v1.ConversationService/Reply"
+	// ConversationServiceRecallProcedure is the fully-qualified name of the ConversationService's
+	// Recall RPC.
+	ConversationServiceRecallProcedure = "/akari.v1.ConversationService/Recall"
+	// ConversationServiceExportConversationsProcedure is the fully-qualified name of the
+	// ConversationService's ExportConversations RPC.
+	ConversationServiceExportConversationsProcedure = "/akari.v1.ConversationService/ExportConversations"
+	// ConversationServiceImportConversationsProcedure is the fully-qualified name of the
+	// ConversationService's ImportConversations RPC.
+	ConversationServiceImportConversationsProcedure = "/akari.v1.ConversationService/ImportConversations"
+)
+
+// ConversationServiceClient is a client for the akari.v1.ConversationService service.
+type ConversationServiceClient interface {
+	// Reply generates the character's reply to a message, exactly as if it had
+	// been sent on Discord.
+	Reply(context.Context, *connect.Request[v1.ReplyRequest]) (*connect.Response[v1.ReplyResponse], error)
+	// Recall returns the long-term memories a character associates with a
+	// query.
+	Recall(context.Context, *connect.Request[v1.RecallRequest]) (*connect.Response[v1.RecallResponse], error)
+	// ExportConversations streams the recorded turns of a user or a channel,
+	// oldest first, as a JSON Lines archive. Admin only.
+	ExportConversations(context.Context, *connect.Request[v1.ExportConversationsRequest]) (*connect.ServerStreamForClient[v1.ExportConversationsResponse], error)
+	// ImportConversations reads an archive written by ExportConversations.
+	// Turns that are already stored are skipped, so an interrupted import can
+	// be retried. Admin only.
+	ImportConversations(context.Context) *connect.ClientStreamForClient[v1.ImportConversationsRequest, v1.ImportConversationsResponse]
+}
+
+// NewConversationServiceClient constructs a client for the akari.v1.ConversationService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewConversationServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) ConversationServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	conversationServiceMethods := v1.File_akari_v1_conversation_proto.Services().ByName("ConversationService").Methods()
+	return &conversationServiceClient{
+		reply: connect.NewClient[v1.ReplyRequest, v1.ReplyResponse](
+			httpClient,
+			baseURL+ConversationServiceReplyProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("Reply")),
+			connect.WithClientOptions(opts...),
+		),
+		recall: connect.NewClient[v1.RecallRequest, v1.RecallResponse](
+			httpClient,
+			baseURL+ConversationServiceRecallProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("Recall")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		exportConversations: connect.NewClient[v1.ExportConversationsRequest, v1.ExportConversationsResponse](
+			httpClient,
+			baseURL+ConversationServiceExportConversationsProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("ExportConversations")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		importConversations: connect.NewClient[v1.ImportConversationsRequest, v1.ImportConversationsResponse](
+			httpClient,
+			baseURL+ConversationServiceImportConversationsProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("ImportConversations")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// conversationServiceClient implements ConversationServiceClient.
+type conversationServiceClient struct {
+	reply               *connect.Client[v1.ReplyRequest, v1.ReplyResponse]
+	recall              *connect.Client[v1.RecallRequest, v1.RecallResponse]
+	exportConversations *connect.Client[v1.ExportConversationsRequest, v1.ExportConversationsResponse]
+	importConversations *connect.Client[v1.ImportConversationsRequest, v1.ImportConversationsResponse]
+}
+
+// Reply calls akari.v1.ConversationService.Reply.
+func (c *conversationServiceClient) Reply(ctx context.Context, req *connect.Request[v1.ReplyRequest]) (*connect.Response[v1.ReplyResponse], error) {
+	return c.reply.CallUnary(ctx, req)
+}
+
+// Recall calls akari.v1.ConversationService.Recall.
+func (c *conversationServiceClient) Recall(ctx context.Context, req *connect.Request[v1.RecallRequest]) (*connect.Response[v1.RecallResponse], error) {
+	return c.recall.CallUnary(ctx, req)
+}
+
+// ExportConversations calls akari.v1.ConversationService.ExportConversations.
+func (c *conversationServiceClient) ExportConversations(ctx context.Context, req *connect.Request[v1.ExportConversationsRequest]) (*connect.ServerStreamForClient[v1.ExportConversationsResponse], error) {
+	return c.exportConversations.CallServerStream(ctx, req)
+}
+
+// ImportConversations calls akari.v1.ConversationService.ImportConversations.
+func (c *conversationServiceClient) ImportConversations(ctx context.Context) *connect.ClientStreamForClient[v1.ImportConversationsRequest, v1.ImportConversationsResponse] {
+	return c.importConversations.CallClientStream(ctx)
+}
+
+// ConversationServiceHandler is an implementation of the akari.v1.ConversationService service.
+type ConversationServiceHandler interface {
+	// Reply generates the character's reply to a message, exactly as if it had
+	// been sent on Discord.
+	Reply(context.Context, *connect.Request[v1.ReplyRequest]) (*connect.Response[v1.ReplyResponse], error)
+	// Recall returns the long-term memories a character associates with a
+	// query.
+	Recall(context.Context, *connect.Request[v1.RecallRequest]) (*connect.Response[v1.RecallResponse], error)
+	// ExportConversations streams the recorded turns of a user or a channel,
+	// oldest first, as a JSON Lines archive. Admin only.
+	ExportConversations(context.Context, *connect.Request[v1.ExportConversationsRequest], *connect.ServerStream[v1.ExportConversationsResponse]) error
+	// ImportConversations reads an archive written by ExportConversations.
+	// Turns that are already stored are skipped, so an interrupted import can
+	// be retried. Admin only.
+	ImportConversations(context.Context, *connect.ClientStream[v1.ImportConversationsRequest]) (*connect.Response[v1.ImportConversationsResponse], error)
+}
+
+// NewConversationServiceHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewConversationServiceHandler(svc ConversationServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	conversationServiceMethods := v1.File_akari_v1_conversation_proto.Services().ByName("ConversationService").Methods()
+	conversationServiceReplyHandler := connect.NewUnaryHandler(
+		ConversationServiceReplyProcedure,
+		svc.Reply,
+		connect.WithSchema(conversationServiceMethods.ByName("Reply")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceRecallHandler := connect.NewUnaryHandler(
+		ConversationServiceRecallProcedure,
+		svc.Recall,
+		connect.WithSchema(conversationServiceMethods.ByName("Recall")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceExportConversationsHandler := connect.NewServerStreamHandler(
+		ConversationServiceExportConversationsProcedure,
+		svc.ExportConversations,
+		connect.WithSchema(conversationServiceMethods.ByName("ExportConversations")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceImportConversationsHandler := connect.NewClientStreamHandler(
+		ConversationServiceImportConversationsProcedure,
+		svc.ImportConversations,
+		connect.WithSchema(conversationServiceMethods.ByName("ImportConversations")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/akari.v1.ConversationService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ConversationServiceReplyProcedure:
+			conversationServiceReplyHandler.ServeHTTP(w, r)
+		case ConversationServiceRecallProcedure:
+			conversationServiceRecallHandler.ServeHTTP(w, r)
+		case ConversationServiceExportConversationsProcedure:
+			conversationServiceExportConversationsHandler.ServeHTTP(w, r)
+		case ConversationServiceImportConversationsProcedure:
+			conversationServiceImportConversationsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedConversationServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedConversationServiceHandler struct{}
+
+func (UnimplementedConversationServiceHandler) Reply(context.Context, *connect.Request[v1.ReplyRequest]) (*connect.Response[v1.ReplyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.ConversationService.Reply is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) Recall(context.Context, *connect.Request[v1.RecallRequest]) (*connect.Response[v1.RecallResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.ConversationService.Recall is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) ExportConversations(context.Context, *connect.Request[v1.ExportConversationsRequest], *connect.ServerStream[v1.ExportConversationsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.ConversationService.ExportConversations is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) ImportConversations(context.Context, *connect.ClientStream[v1.ImportConversationsRequest]) (*connect.Response[v1.ImportConversationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akari.v1.ConversationService.ImportConversations is not implemented"))
+}
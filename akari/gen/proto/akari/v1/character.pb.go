@@ -0,0 +1,343 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: akari/v1/character.proto
+
+package akariv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Character struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Kiseki character ID.
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Cron expression of the character's bedtime, empty when it never sleeps.
+	SleepSchedule string `protobuf:"bytes,3,opt,name=sleep_schedule,json=sleepSchedule,proto3" json:"sleep_schedule,omitempty"`
+	// IANA time zone the sleep schedule is evaluated in.
+	Timezone      string `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Character) Reset() {
+	*x = Character{}
+	mi := &file_akari_v1_character_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Character) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Character) ProtoMessage() {}
+
+func (x *Character) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Character.ProtoReflect.Descriptor instead.
+func (*Character) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Character) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Character) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Character) GetSleepSchedule() string {
+	if x != nil {
+		return x.SleepSchedule
+	}
+	return ""
+}
+
+func (x *Character) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type ListCharactersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCharactersRequest) Reset() {
+	*x = ListCharactersRequest{}
+	mi := &file_akari_v1_character_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCharactersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCharactersRequest) ProtoMessage() {}
+
+func (x *ListCharactersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCharactersRequest.ProtoReflect.Descriptor instead.
+func (*ListCharactersRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_proto_rawDescGZIP(), []int{1}
+}
+
+type ListCharactersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Characters    []*Character           `protobuf:"bytes,1,rep,name=characters,proto3" json:"characters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCharactersResponse) Reset() {
+	*x = ListCharactersResponse{}
+	mi := &file_akari_v1_character_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCharactersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCharactersResponse) ProtoMessage() {}
+
+func (x *ListCharactersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCharactersResponse.ProtoReflect.Descriptor instead.
+func (*ListCharactersResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListCharactersResponse) GetCharacters() []*Character {
+	if x != nil {
+		return x.Characters
+	}
+	return nil
+}
+
+type GetCharacterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCharacterRequest) Reset() {
+	*x = GetCharacterRequest{}
+	mi := &file_akari_v1_character_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCharacterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCharacterRequest) ProtoMessage() {}
+
+func (x *GetCharacterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCharacterRequest.ProtoReflect.Descriptor instead.
+func (*GetCharacterRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCharacterRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCharacterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Character     *Character             `protobuf:"bytes,1,opt,name=character,proto3" json:"character,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCharacterResponse) Reset() {
+	*x = GetCharacterResponse{}
+	mi := &file_akari_v1_character_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCharacterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCharacterResponse) ProtoMessage() {}
+
+func (x *GetCharacterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCharacterResponse.ProtoReflect.Descriptor instead.
+func (*GetCharacterResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCharacterResponse) GetCharacter() *Character {
+	if x != nil {
+		return x.Character
+	}
+	return nil
+}
+
+var File_akari_v1_character_proto protoreflect.FileDescriptor
+
+const file_akari_v1_character_proto_rawDesc = "" +
+	"\n" +
+	"\x18akari/v1/character.proto\x12\bakari.v1\"r\n" +
+	"\tCharacter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\x0esleep_schedule\x18\x03 \x01(\tR\rsleepSchedule\x12\x1a\n" +
+	"\btimezone\x18\x04 \x01(\tR\btimezone\"\x17\n" +
+	"\x15ListCharactersRequest\"M\n" +
+	"\x16ListCharactersResponse\x123\n" +
+	"\n" +
+	"characters\x18\x01 \x03(\v2\x13.akari.v1.CharacterR\n" +
+	"characters\"%\n" +
+	"\x13GetCharacterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x14GetCharacterResponse\x121\n" +
+	"\tcharacter\x18\x01 \x01(\v2\x13.akari.v1.CharacterR\tcharacter2\xc0\x01\n" +
+	"\x10CharacterService\x12X\n" +
+	"\x0eListCharacters\x12\x1f.akari.v1.ListCharactersRequest\x1a .akari.v1.ListCharactersResponse\"\x03\x90\x02\x01\x12R\n" +
+	"\fGetCharacter\x12\x1d.akari.v1.GetCharacterRequest\x1a\x1e.akari.v1.GetCharacterResponse\"\x03\x90\x02\x01B\x97\x01\n" +
+	"\fcom.akari.v1B\x0eCharacterProtoP\x01Z6github.com/kizuna-org/akari/gen/proto/akari/v1;akariv1\xa2\x02\x03AXX\xaa\x02\bAkari.V1\xca\x02\bAkari\\V1\xe2\x02\x14Akari\\V1\\GPBMetadata\xea\x02\tAkari::V1b\x06proto3"
+
+var (
+	file_akari_v1_character_proto_rawDescOnce sync.Once
+	file_akari_v1_character_proto_rawDescData []byte
+)
+
+func file_akari_v1_character_proto_rawDescGZIP() []byte {
+	file_akari_v1_character_proto_rawDescOnce.Do(func() {
+		file_akari_v1_character_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_akari_v1_character_proto_rawDesc), len(file_akari_v1_character_proto_rawDesc)))
+	})
+	return file_akari_v1_character_proto_rawDescData
+}
+
+var file_akari_v1_character_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_akari_v1_character_proto_goTypes = []any{
+	(*Character)(nil),              // 0: akari.v1.Character
+	(*ListCharactersRequest)(nil),  // 1: akari.v1.ListCharactersRequest
+	(*ListCharactersResponse)(nil), // 2: akari.v1.ListCharactersResponse
+	(*GetCharacterRequest)(nil),    // 3: akari.v1.GetCharacterRequest
+	(*GetCharacterResponse)(nil),   // 4: akari.v1.GetCharacterResponse
+}
+var file_akari_v1_character_proto_depIdxs = []int32{
+	0, // 0: akari.v1.ListCharactersResponse.characters:type_name -> akari.v1.Character
+	0, // 1: akari.v1.GetCharacterResponse.character:type_name -> akari.v1.Character
+	1, // 2: akari.v1.CharacterService.ListCharacters:input_type -> akari.v1.ListCharactersRequest
+	3, // 3: akari.v1.CharacterService.GetCharacter:input_type -> akari.v1.GetCharacterRequest
+	2, // 4: akari.v1.CharacterService.ListCharacters:output_type -> akari.v1.ListCharactersResponse
+	4, // 5: akari.v1.CharacterService.GetCharacter:output_type -> akari.v1.GetCharacterResponse
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_akari_v1_character_proto_init() }
+func file_akari_v1_character_proto_init() {
+	if File_akari_v1_character_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_akari_v1_character_proto_rawDesc), len(file_akari_v1_character_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_akari_v1_character_proto_goTypes,
+		DependencyIndexes: file_akari_v1_character_proto_depIdxs,
+		MessageInfos:      file_akari_v1_character_proto_msgTypes,
+	}.Build()
+	File_akari_v1_character_proto = out.File
+	file_akari_v1_character_proto_goTypes = nil
+	file_akari_v1_character_proto_depIdxs = nil
+}
@@ -0,0 +1,727 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: akari/v1/character_admin.proto
+
+package akariv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CharacterProfile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Kiseki character ID.
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Free-form description of the character added to the system prompt.
+	Persona string `protobuf:"bytes,3,opt,name=persona,proto3" json:"persona,omitempty"`
+	// Go text/template rendering the system prompt from .Name, .Persona and
+	// .Memories. Empty uses the built-in template.
+	PromptTemplate string `protobuf:"bytes,4,opt,name=prompt_template,json=promptTemplate,proto3" json:"prompt_template,omitempty"`
+	// Guild channels the character replies in. Empty means every channel;
+	// direct messages are always answered.
+	ChannelIds []string `protobuf:"bytes,5,rep,name=channel_ids,json=channelIds,proto3" json:"channel_ids,omitempty"`
+	// Names of the tools the character's model may call.
+	Tools         []string `protobuf:"bytes,6,rep,name=tools,proto3" json:"tools,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CharacterProfile) Reset() {
+	*x = CharacterProfile{}
+	mi := &file_akari_v1_character_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CharacterProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CharacterProfile) ProtoMessage() {}
+
+func (x *CharacterProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CharacterProfile.ProtoReflect.Descriptor instead.
+func (*CharacterProfile) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CharacterProfile) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CharacterProfile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CharacterProfile) GetPersona() string {
+	if x != nil {
+		return x.Persona
+	}
+	return ""
+}
+
+func (x *CharacterProfile) GetPromptTemplate() string {
+	if x != nil {
+		return x.PromptTemplate
+	}
+	return ""
+}
+
+func (x *CharacterProfile) GetChannelIds() []string {
+	if x != nil {
+		return x.ChannelIds
+	}
+	return nil
+}
+
+func (x *CharacterProfile) GetTools() []string {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+type ListCharacterProfilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCharacterProfilesRequest) Reset() {
+	*x = ListCharacterProfilesRequest{}
+	mi := &file_akari_v1_character_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCharacterProfilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCharacterProfilesRequest) ProtoMessage() {}
+
+func (x *ListCharacterProfilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCharacterProfilesRequest.ProtoReflect.Descriptor instead.
+func (*ListCharacterProfilesRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_admin_proto_rawDescGZIP(), []int{1}
+}
+
+type ListCharacterProfilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profiles      []*CharacterProfile    `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCharacterProfilesResponse) Reset() {
+	*x = ListCharacterProfilesResponse{}
+	mi := &file_akari_v1_character_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCharacterProfilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCharacterProfilesResponse) ProtoMessage() {}
+
+func (x *ListCharacterProfilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCharacterProfilesResponse.ProtoReflect.Descriptor instead.
+func (*ListCharacterProfilesResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListCharacterProfilesResponse) GetProfiles() []*CharacterProfile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+type CreateCharacterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       *CharacterProfile      `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCharacterRequest) Reset() {
+	*x = CreateCharacterRequest{}
+	mi := &file_akari_v1_character_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCharacterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCharacterRequest) ProtoMessage() {}
+
+func (x *CreateCharacterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCharacterRequest.ProtoReflect.Descriptor instead.
+func (*CreateCharacterRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateCharacterRequest) GetProfile() *CharacterProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type CreateCharacterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       *CharacterProfile      `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCharacterResponse) Reset() {
+	*x = CreateCharacterResponse{}
+	mi := &file_akari_v1_character_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCharacterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCharacterResponse) ProtoMessage() {}
+
+func (x *CreateCharacterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCharacterResponse.ProtoReflect.Descriptor instead.
+func (*CreateCharacterResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateCharacterResponse) GetProfile() *CharacterProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type UpdateCharacterRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Profile *CharacterProfile      `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	// Paths of the profile fields to replace: name, persona, prompt_template,
+	// channel_ids and tools.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCharacterRequest) Reset() {
+	*x = UpdateCharacterRequest{}
+	mi := &file_akari_v1_character_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCharacterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCharacterRequest) ProtoMessage() {}
+
+func (x *UpdateCharacterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCharacterRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCharacterRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateCharacterRequest) GetProfile() *CharacterProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *UpdateCharacterRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateCharacterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       *CharacterProfile      `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCharacterResponse) Reset() {
+	*x = UpdateCharacterResponse{}
+	mi := &file_akari_v1_character_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCharacterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCharacterResponse) ProtoMessage() {}
+
+func (x *UpdateCharacterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCharacterResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCharacterResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateCharacterResponse) GetProfile() *CharacterProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type SetChannelEnabledRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CharacterId   string                 `protobuf:"bytes,1,opt,name=character_id,json=characterId,proto3" json:"character_id,omitempty"`
+	ChannelId     string                 `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetChannelEnabledRequest) Reset() {
+	*x = SetChannelEnabledRequest{}
+	mi := &file_akari_v1_character_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetChannelEnabledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetChannelEnabledRequest) ProtoMessage() {}
+
+func (x *SetChannelEnabledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetChannelEnabledRequest.ProtoReflect.Descriptor instead.
+func (*SetChannelEnabledRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetChannelEnabledRequest) GetCharacterId() string {
+	if x != nil {
+		return x.CharacterId
+	}
+	return ""
+}
+
+func (x *SetChannelEnabledRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *SetChannelEnabledRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetChannelEnabledResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       *CharacterProfile      `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetChannelEnabledResponse) Reset() {
+	*x = SetChannelEnabledResponse{}
+	mi := &file_akari_v1_character_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetChannelEnabledResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetChannelEnabledResponse) ProtoMessage() {}
+
+func (x *SetChannelEnabledResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetChannelEnabledResponse.ProtoReflect.Descriptor instead.
+func (*SetChannelEnabledResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetChannelEnabledResponse) GetProfile() *CharacterProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type PreviewSystemPromptRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	CharacterId string                 `protobuf:"bytes,1,opt,name=character_id,json=characterId,proto3" json:"character_id,omitempty"`
+	// Overrides the saved persona when set.
+	Persona *string `protobuf:"bytes,2,opt,name=persona,proto3,oneof" json:"persona,omitempty"`
+	// Overrides the saved prompt template when set.
+	PromptTemplate *string `protobuf:"bytes,3,opt,name=prompt_template,json=promptTemplate,proto3,oneof" json:"prompt_template,omitempty"`
+	// Sample memories to render, as recalled from kiseki.
+	Memories      []string `protobuf:"bytes,4,rep,name=memories,proto3" json:"memories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewSystemPromptRequest) Reset() {
+	*x = PreviewSystemPromptRequest{}
+	mi := &file_akari_v1_character_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewSystemPromptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewSystemPromptRequest) ProtoMessage() {}
+
+func (x *PreviewSystemPromptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewSystemPromptRequest.ProtoReflect.Descriptor instead.
+func (*PreviewSystemPromptRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PreviewSystemPromptRequest) GetCharacterId() string {
+	if x != nil {
+		return x.CharacterId
+	}
+	return ""
+}
+
+func (x *PreviewSystemPromptRequest) GetPersona() string {
+	if x != nil && x.Persona != nil {
+		return *x.Persona
+	}
+	return ""
+}
+
+func (x *PreviewSystemPromptRequest) GetPromptTemplate() string {
+	if x != nil && x.PromptTemplate != nil {
+		return *x.PromptTemplate
+	}
+	return ""
+}
+
+func (x *PreviewSystemPromptRequest) GetMemories() []string {
+	if x != nil {
+		return x.Memories
+	}
+	return nil
+}
+
+type PreviewSystemPromptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SystemPrompt  string                 `protobuf:"bytes,1,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewSystemPromptResponse) Reset() {
+	*x = PreviewSystemPromptResponse{}
+	mi := &file_akari_v1_character_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewSystemPromptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewSystemPromptResponse) ProtoMessage() {}
+
+func (x *PreviewSystemPromptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_character_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewSystemPromptResponse.ProtoReflect.Descriptor instead.
+func (*PreviewSystemPromptResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_character_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PreviewSystemPromptResponse) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+var File_akari_v1_character_admin_proto protoreflect.FileDescriptor
+
+const file_akari_v1_character_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x1eakari/v1/character_admin.proto\x12\bakari.v1\x1a google/protobuf/field_mask.proto\"\xb0\x01\n" +
+	"\x10CharacterProfile\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\apersona\x18\x03 \x01(\tR\apersona\x12'\n" +
+	"\x0fprompt_template\x18\x04 \x01(\tR\x0epromptTemplate\x12\x1f\n" +
+	"\vchannel_ids\x18\x05 \x03(\tR\n" +
+	"channelIds\x12\x14\n" +
+	"\x05tools\x18\x06 \x03(\tR\x05tools\"\x1e\n" +
+	"\x1cListCharacterProfilesRequest\"W\n" +
+	"\x1dListCharacterProfilesResponse\x126\n" +
+	"\bprofiles\x18\x01 \x03(\v2\x1a.akari.v1.CharacterProfileR\bprofiles\"N\n" +
+	"\x16CreateCharacterRequest\x124\n" +
+	"\aprofile\x18\x01 \x01(\v2\x1a.akari.v1.CharacterProfileR\aprofile\"O\n" +
+	"\x17CreateCharacterResponse\x124\n" +
+	"\aprofile\x18\x01 \x01(\v2\x1a.akari.v1.CharacterProfileR\aprofile\"\x8b\x01\n" +
+	"\x16UpdateCharacterRequest\x124\n" +
+	"\aprofile\x18\x01 \x01(\v2\x1a.akari.v1.CharacterProfileR\aprofile\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"O\n" +
+	"\x17UpdateCharacterResponse\x124\n" +
+	"\aprofile\x18\x01 \x01(\v2\x1a.akari.v1.CharacterProfileR\aprofile\"v\n" +
+	"\x18SetChannelEnabledRequest\x12!\n" +
+	"\fcharacter_id\x18\x01 \x01(\tR\vcharacterId\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x02 \x01(\tR\tchannelId\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\"Q\n" +
+	"\x19SetChannelEnabledResponse\x124\n" +
+	"\aprofile\x18\x01 \x01(\v2\x1a.akari.v1.CharacterProfileR\aprofile\"\xc8\x01\n" +
+	"\x1aPreviewSystemPromptRequest\x12!\n" +
+	"\fcharacter_id\x18\x01 \x01(\tR\vcharacterId\x12\x1d\n" +
+	"\apersona\x18\x02 \x01(\tH\x00R\apersona\x88\x01\x01\x12,\n" +
+	"\x0fprompt_template\x18\x03 \x01(\tH\x01R\x0epromptTemplate\x88\x01\x01\x12\x1a\n" +
+	"\bmemories\x18\x04 \x03(\tR\bmemoriesB\n" +
+	"\n" +
+	"\b_personaB\x12\n" +
+	"\x10_prompt_template\"B\n" +
+	"\x1bPreviewSystemPromptResponse\x12#\n" +
+	"\rsystem_prompt\x18\x01 \x01(\tR\fsystemPrompt2\xfd\x03\n" +
+	"\x15CharacterAdminService\x12m\n" +
+	"\x15ListCharacterProfiles\x12&.akari.v1.ListCharacterProfilesRequest\x1a'.akari.v1.ListCharacterProfilesResponse\"\x03\x90\x02\x01\x12V\n" +
+	"\x0fCreateCharacter\x12 .akari.v1.CreateCharacterRequest\x1a!.akari.v1.CreateCharacterResponse\x12V\n" +
+	"\x0fUpdateCharacter\x12 .akari.v1.UpdateCharacterRequest\x1a!.akari.v1.UpdateCharacterResponse\x12\\\n" +
+	"\x11SetChannelEnabled\x12\".akari.v1.SetChannelEnabledRequest\x1a#.akari.v1.SetChannelEnabledResponse\x12g\n" +
+	"\x13PreviewSystemPrompt\x12$.akari.v1.PreviewSystemPromptRequest\x1a%.akari.v1.PreviewSystemPromptResponse\"\x03\x90\x02\x01B\x9c\x01\n" +
+	"\fcom.akari.v1B\x13CharacterAdminProtoP\x01Z6github.com/kizuna-org/akari/gen/proto/akari/v1;akariv1\xa2\x02\x03AXX\xaa\x02\bAkari.V1\xca\x02\bAkari\\V1\xe2\x02\x14Akari\\V1\\GPBMetadata\xea\x02\tAkari::V1b\x06proto3"
+
+var (
+	file_akari_v1_character_admin_proto_rawDescOnce sync.Once
+	file_akari_v1_character_admin_proto_rawDescData []byte
+)
+
+func file_akari_v1_character_admin_proto_rawDescGZIP() []byte {
+	file_akari_v1_character_admin_proto_rawDescOnce.Do(func() {
+		file_akari_v1_character_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_akari_v1_character_admin_proto_rawDesc), len(file_akari_v1_character_admin_proto_rawDesc)))
+	})
+	return file_akari_v1_character_admin_proto_rawDescData
+}
+
+var file_akari_v1_character_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_akari_v1_character_admin_proto_goTypes = []any{
+	(*CharacterProfile)(nil),              // 0: akari.v1.CharacterProfile
+	(*ListCharacterProfilesRequest)(nil),  // 1: akari.v1.ListCharacterProfilesRequest
+	(*ListCharacterProfilesResponse)(nil), // 2: akari.v1.ListCharacterProfilesResponse
+	(*CreateCharacterRequest)(nil),        // 3: akari.v1.CreateCharacterRequest
+	(*CreateCharacterResponse)(nil),       // 4: akari.v1.CreateCharacterResponse
+	(*UpdateCharacterRequest)(nil),        // 5: akari.v1.UpdateCharacterRequest
+	(*UpdateCharacterResponse)(nil),       // 6: akari.v1.UpdateCharacterResponse
+	(*SetChannelEnabledRequest)(nil),      // 7: akari.v1.SetChannelEnabledRequest
+	(*SetChannelEnabledResponse)(nil),     // 8: akari.v1.SetChannelEnabledResponse
+	(*PreviewSystemPromptRequest)(nil),    // 9: akari.v1.PreviewSystemPromptRequest
+	(*PreviewSystemPromptResponse)(nil),   // 10: akari.v1.PreviewSystemPromptResponse
+	(*fieldmaskpb.FieldMask)(nil),         // 11: google.protobuf.FieldMask
+}
+var file_akari_v1_character_admin_proto_depIdxs = []int32{
+	0,  // 0: akari.v1.ListCharacterProfilesResponse.profiles:type_name -> akari.v1.CharacterProfile
+	0,  // 1: akari.v1.CreateCharacterRequest.profile:type_name -> akari.v1.CharacterProfile
+	0,  // 2: akari.v1.CreateCharacterResponse.profile:type_name -> akari.v1.CharacterProfile
+	0,  // 3: akari.v1.UpdateCharacterRequest.profile:type_name -> akari.v1.CharacterProfile
+	11, // 4: akari.v1.UpdateCharacterRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 5: akari.v1.UpdateCharacterResponse.profile:type_name -> akari.v1.CharacterProfile
+	0,  // 6: akari.v1.SetChannelEnabledResponse.profile:type_name -> akari.v1.CharacterProfile
+	1,  // 7: akari.v1.CharacterAdminService.ListCharacterProfiles:input_type -> akari.v1.ListCharacterProfilesRequest
+	3,  // 8: akari.v1.CharacterAdminService.CreateCharacter:input_type -> akari.v1.CreateCharacterRequest
+	5,  // 9: akari.v1.CharacterAdminService.UpdateCharacter:input_type -> akari.v1.UpdateCharacterRequest
+	7,  // 10: akari.v1.CharacterAdminService.SetChannelEnabled:input_type -> akari.v1.SetChannelEnabledRequest
+	9,  // 11: akari.v1.CharacterAdminService.PreviewSystemPrompt:input_type -> akari.v1.PreviewSystemPromptRequest
+	2,  // 12: akari.v1.CharacterAdminService.ListCharacterProfiles:output_type -> akari.v1.ListCharacterProfilesResponse
+	4,  // 13: akari.v1.CharacterAdminService.CreateCharacter:output_type -> akari.v1.CreateCharacterResponse
+	6,  // 14: akari.v1.CharacterAdminService.UpdateCharacter:output_type -> akari.v1.UpdateCharacterResponse
+	8,  // 15: akari.v1.CharacterAdminService.SetChannelEnabled:output_type -> akari.v1.SetChannelEnabledResponse
+	10, // 16: akari.v1.CharacterAdminService.PreviewSystemPrompt:output_type -> akari.v1.PreviewSystemPromptResponse
+	12, // [12:17] is the sub-list for method output_type
+	7,  // [7:12] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_akari_v1_character_admin_proto_init() }
+func file_akari_v1_character_admin_proto_init() {
+	if File_akari_v1_character_admin_proto != nil {
+		return
+	}
+	file_akari_v1_character_admin_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_akari_v1_character_admin_proto_rawDesc), len(file_akari_v1_character_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_akari_v1_character_admin_proto_goTypes,
+		DependencyIndexes: file_akari_v1_character_admin_proto_depIdxs,
+		MessageInfos:      file_akari_v1_character_admin_proto_msgTypes,
+	}.Build()
+	File_akari_v1_character_admin_proto = out.File
+	file_akari_v1_character_admin_proto_goTypes = nil
+	file_akari_v1_character_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,612 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: akari/v1/conversation.proto
+
+package akariv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReplyRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// The author of the message, the authenticated caller when empty, known by
+	// the Discord user ID their account is linked to. Only an admin may name
+	// another author.
+	AuthorId   string `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	AuthorName string `protobuf:"bytes,3,opt,name=author_name,json=authorName,proto3" json:"author_name,omitempty"`
+	Content    string `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	// The hosted character to reply as; the primary character when empty.
+	CharacterId   string `protobuf:"bytes,5,opt,name=character_id,json=characterId,proto3" json:"character_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplyRequest) Reset() {
+	*x = ReplyRequest{}
+	mi := &file_akari_v1_conversation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplyRequest) ProtoMessage() {}
+
+func (x *ReplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_conversation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplyRequest.ProtoReflect.Descriptor instead.
+func (*ReplyRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_conversation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReplyRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *ReplyRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ReplyRequest) GetAuthorName() string {
+	if x != nil {
+		return x.AuthorName
+	}
+	return ""
+}
+
+func (x *ReplyRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ReplyRequest) GetCharacterId() string {
+	if x != nil {
+		return x.CharacterId
+	}
+	return ""
+}
+
+type ReplyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty when the author is rate limited and was already told so.
+	Content       string `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplyResponse) Reset() {
+	*x = ReplyResponse{}
+	mi := &file_akari_v1_conversation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplyResponse) ProtoMessage() {}
+
+func (x *ReplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_conversation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplyResponse.ProtoReflect.Descriptor instead.
+func (*ReplyResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_conversation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReplyResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type RecallRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The hosted character whose memories to search, the primary character
+	// when empty. Only an admin may name another character.
+	CharacterId   string `protobuf:"bytes,1,opt,name=character_id,json=characterId,proto3" json:"character_id,omitempty"`
+	Query         string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecallRequest) Reset() {
+	*x = RecallRequest{}
+	mi := &file_akari_v1_conversation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecallRequest) ProtoMessage() {}
+
+func (x *RecallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_conversation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecallRequest.ProtoReflect.Descriptor instead.
+func (*RecallRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_conversation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RecallRequest) GetCharacterId() string {
+	if x != nil {
+		return x.CharacterId
+	}
+	return ""
+}
+
+func (x *RecallRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+type RecallResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Memories      []*Memory              `protobuf:"bytes,1,rep,name=memories,proto3" json:"memories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecallResponse) Reset() {
+	*x = RecallResponse{}
+	mi := &file_akari_v1_conversation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecallResponse) ProtoMessage() {}
+
+func (x *RecallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_conversation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecallResponse.ProtoReflect.Descriptor instead.
+func (*RecallResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_conversation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecallResponse) GetMemories() []*Memory {
+	if x != nil {
+		return x.Memories
+	}
+	return nil
+}
+
+type Memory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          string                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	MemorizedAt   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=memorized_at,json=memorizedAt,proto3" json:"memorized_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Memory) Reset() {
+	*x = Memory{}
+	mi := &file_akari_v1_conversation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Memory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Memory) ProtoMessage() {}
+
+func (x *Memory) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_conversation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Memory.ProtoReflect.Descriptor instead.
+func (*Memory) Descriptor() ([]byte, []int) {
+	return file_akari_v1_conversation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Memory) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *Memory) GetMemorizedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MemorizedAt
+	}
+	return nil
+}
+
+type ExportConversationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At least one of user_id and channel_id is required; both narrow the
+	// export when set.
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ChannelId     string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	CharacterId   string `protobuf:"bytes,3,opt,name=character_id,json=characterId,proto3" json:"character_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportConversationsRequest) Reset() {
+	*x = ExportConversationsRequest{}
+	mi := &file_akari_v1_conversation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportConversationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportConversationsRequest) ProtoMessage() {}
+
+func (x *ExportConversationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_conversation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportConversationsRequest.ProtoReflect.Descriptor instead.
+func (*ExportConversationsRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_conversation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExportConversationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExportConversationsRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *ExportConversationsRequest) GetCharacterId() string {
+	if x != nil {
+		return x.CharacterId
+	}
+	return ""
+}
+
+type ExportConversationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whole JSON Lines records, one turn per line.
+	Data          []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportConversationsResponse) Reset() {
+	*x = ExportConversationsResponse{}
+	mi := &file_akari_v1_conversation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportConversationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportConversationsResponse) ProtoMessage() {}
+
+func (x *ExportConversationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_conversation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportConversationsResponse.ProtoReflect.Descriptor instead.
+func (*ExportConversationsResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_conversation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExportConversationsResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportConversationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The next chunk of the archive. Records may span chunks.
+	Data          []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportConversationsRequest) Reset() {
+	*x = ImportConversationsRequest{}
+	mi := &file_akari_v1_conversation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportConversationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportConversationsRequest) ProtoMessage() {}
+
+func (x *ImportConversationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_conversation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportConversationsRequest.ProtoReflect.Descriptor instead.
+func (*ImportConversationsRequest) Descriptor() ([]byte, []int) {
+	return file_akari_v1_conversation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ImportConversationsRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportConversationsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Imported int64                  `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	// Turns that were already stored.
+	Skipped       int64 `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportConversationsResponse) Reset() {
+	*x = ImportConversationsResponse{}
+	mi := &file_akari_v1_conversation_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportConversationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportConversationsResponse) ProtoMessage() {}
+
+func (x *ImportConversationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akari_v1_conversation_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportConversationsResponse.ProtoReflect.Descriptor instead.
+func (*ImportConversationsResponse) Descriptor() ([]byte, []int) {
+	return file_akari_v1_conversation_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ImportConversationsResponse) GetImported() int64 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportConversationsResponse) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+var File_akari_v1_conversation_proto protoreflect.FileDescriptor
+
+const file_akari_v1_conversation_proto_rawDesc = "" +
+	"\n" +
+	"\x1bakari/v1/conversation.proto\x12\bakari.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa8\x01\n" +
+	"\fReplyRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x1f\n" +
+	"\vauthor_name\x18\x03 \x01(\tR\n" +
+	"authorName\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x12!\n" +
+	"\fcharacter_id\x18\x05 \x01(\tR\vcharacterId\")\n" +
+	"\rReplyResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\"H\n" +
+	"\rRecallRequest\x12!\n" +
+	"\fcharacter_id\x18\x01 \x01(\tR\vcharacterId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\">\n" +
+	"\x0eRecallResponse\x12,\n" +
+	"\bmemories\x18\x01 \x03(\v2\x10.akari.v1.MemoryR\bmemories\"[\n" +
+	"\x06Memory\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\tR\x04data\x12=\n" +
+	"\fmemorized_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vmemorizedAt\"w\n" +
+	"\x1aExportConversationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x02 \x01(\tR\tchannelId\x12!\n" +
+	"\fcharacter_id\x18\x03 \x01(\tR\vcharacterId\"1\n" +
+	"\x1bExportConversationsResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"0\n" +
+	"\x1aImportConversationsRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"S\n" +
+	"\x1bImportConversationsResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\x03R\bimported\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x03R\askipped2\xe2\x02\n" +
+	"\x13ConversationService\x128\n" +
+	"\x05Reply\x12\x16.akari.v1.ReplyRequest\x1a\x17.akari.v1.ReplyResponse\x12@\n" +
+	"\x06Recall\x12\x17.akari.v1.RecallRequest\x1a\x18.akari.v1.RecallResponse\"\x03\x90\x02\x01\x12i\n" +
+	"\x13ExportConversations\x12$.akari.v1.ExportConversationsRequest\x1a%.akari.v1.ExportConversationsResponse\"\x03\x90\x02\x010\x01\x12d\n" +
+	"\x13ImportConversations\x12$.akari.v1.ImportConversationsRequest\x1a%.akari.v1.ImportConversationsResponse(\x01B\x9a\x01\n" +
+	"\fcom.akari.v1B\x11ConversationProtoP\x01Z6github.com/kizuna-org/akari/gen/proto/akari/v1;akariv1\xa2\x02\x03AXX\xaa\x02\bAkari.V1\xca\x02\bAkari\\V1\xe2\x02\x14Akari\\V1\\GPBMetadata\xea\x02\tAkari::V1b\x06proto3"
+
+var (
+	file_akari_v1_conversation_proto_rawDescOnce sync.Once
+	file_akari_v1_conversation_proto_rawDescData []byte
+)
+
+func file_akari_v1_conversation_proto_rawDescGZIP() []byte {
+	file_akari_v1_conversation_proto_rawDescOnce.Do(func() {
+		file_akari_v1_conversation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_akari_v1_conversation_proto_rawDesc), len(file_akari_v1_conversation_proto_rawDesc)))
+	})
+	return file_akari_v1_conversation_proto_rawDescData
+}
+
+var file_akari_v1_conversation_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_akari_v1_conversation_proto_goTypes = []any{
+	(*ReplyRequest)(nil),                // 0: akari.v1.ReplyRequest
+	(*ReplyResponse)(nil),               // 1: akari.v1.ReplyResponse
+	(*RecallRequest)(nil),               // 2: akari.v1.RecallRequest
+	(*RecallResponse)(nil),              // 3: akari.v1.RecallResponse
+	(*Memory)(nil),                      // 4: akari.v1.Memory
+	(*ExportConversationsRequest)(nil),  // 5: akari.v1.ExportConversationsRequest
+	(*ExportConversationsResponse)(nil), // 6: akari.v1.ExportConversationsResponse
+	(*ImportConversationsRequest)(nil),  // 7: akari.v1.ImportConversationsRequest
+	(*ImportConversationsResponse)(nil), // 8: akari.v1.ImportConversationsResponse
+	(*timestamppb.Timestamp)(nil),       // 9: google.protobuf.Timestamp
+}
+var file_akari_v1_conversation_proto_depIdxs = []int32{
+	4, // 0: akari.v1.RecallResponse.memories:type_name -> akari.v1.Memory
+	9, // 1: akari.v1.Memory.memorized_at:type_name -> google.protobuf.Timestamp
+	0, // 2: akari.v1.ConversationService.Reply:input_type -> akari.v1.ReplyRequest
+	2, // 3: akari.v1.ConversationService.Recall:input_type -> akari.v1.RecallRequest
+	5, // 4: akari.v1.ConversationService.ExportConversations:input_type -> akari.v1.ExportConversationsRequest
+	7, // 5: akari.v1.ConversationService.ImportConversations:input_type -> akari.v1.ImportConversationsRequest
+	1, // 6: akari.v1.ConversationService.Reply:output_type -> akari.v1.ReplyResponse
+	3, // 7: akari.v1.ConversationService.Recall:output_type -> akari.v1.RecallResponse
+	6, // 8: akari.v1.ConversationService.ExportConversations:output_type -> akari.v1.ExportConversationsResponse
+	8, // 9: akari.v1.ConversationService.ImportConversations:output_type -> akari.v1.ImportConversationsResponse
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_akari_v1_conversation_proto_init() }
+func file_akari_v1_conversation_proto_init() {
+	if File_akari_v1_conversation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_akari_v1_conversation_proto_rawDesc), len(file_akari_v1_conversation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_akari_v1_conversation_proto_goTypes,
+		DependencyIndexes: file_akari_v1_conversation_proto_depIdxs,
+		MessageInfos:      file_akari_v1_conversation_proto_msgTypes,
+	}.Build()
+	File_akari_v1_conversation_proto = out.File
+	file_akari_v1_conversation_proto_goTypes = nil
+	file_akari_v1_conversation_proto_depIdxs = nil
+}
@@ -0,0 +1,90 @@
+package account
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// CodeTTL is how long a link code may be redeemed on Discord.
+	CodeTTL = 10 * time.Minute
+
+	codeBytes = 5
+)
+
+var (
+	ErrInvalidCode = errors.New("invalid or expired link code")
+	ErrNotLinked   = errors.New("account not linked to a Discord user")
+)
+
+// Pending is a link code waiting to be redeemed on Discord for the account
+// of Subject.
+type Pending struct {
+	Subject   string    `json:"subject"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Linker links akari accounts, the subjects API keys and JWTs authenticate,
+// to Discord users. The account asks for a code through the API and the
+// Discord user redeems it with /link, which proves both belong to the same
+// person.
+type Linker struct {
+	store Store
+	now   func() time.Time
+}
+
+func NewLinker(store Store) *Linker {
+	return &Linker{store: store, now: time.Now}
+}
+
+// CreateCode issues a code that links the account of subject to whoever
+// redeems it within CodeTTL.
+func (l *Linker) CreateCode(ctx context.Context, subject string) (string, time.Time, error) {
+	random := make([]byte, codeBytes)
+
+	_, err := rand.Read(random)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("generate link code: %w", err)
+	}
+
+	code := base32.StdEncoding.EncodeToString(random)
+	expiresAt := l.now().Add(CodeTTL)
+
+	err = l.store.SaveCode(ctx, code, Pending{Subject: subject, ExpiresAt: expiresAt})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return code, expiresAt, nil
+}
+
+// Link redeems a code for a Discord user and returns the subject of the
+// account it was issued to. A code can be redeemed once.
+func (l *Linker) Link(ctx context.Context, code string, discordUserID string) (string, error) {
+	pending, err := l.store.TakeCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return "", err
+	}
+
+	if !l.now().Before(pending.ExpiresAt) {
+		return "", ErrInvalidCode
+	}
+
+	err = l.store.SaveLink(ctx, pending.Subject, discordUserID)
+	if err != nil {
+		return "", err
+	}
+
+	return pending.Subject, nil
+}
+
+// DiscordUser returns the ID of the Discord user the account of subject is
+// linked to, or ErrNotLinked.
+func (l *Linker) DiscordUser(ctx context.Context, subject string) (string, error) {
+	return l.store.DiscordUser(ctx, subject)
+}
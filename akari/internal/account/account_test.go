@@ -0,0 +1,80 @@
+package account
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testSubject = "alice"
+	testUserID  = "123456789"
+)
+
+func TestLinker(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		redeem  func(code string) string
+		after   time.Duration
+		wantErr error
+	}{
+		{name: "link", redeem: func(code string) string { return code }, after: time.Minute, wantErr: nil},
+		{
+			name:    "typed loosely",
+			redeem:  func(code string) string { return " " + strings.ToLower(code) + " " },
+			after:   0,
+			wantErr: nil,
+		},
+		{name: "unknown code", redeem: func(string) string { return "AAAAAAAA" }, after: 0, wantErr: ErrInvalidCode},
+		{name: "expired", redeem: func(code string) string { return code }, after: CodeTTL, wantErr: ErrInvalidCode},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			linker := NewLinker(NewMemoryStore())
+			linker.now = func() time.Time { return now }
+
+			code, expiresAt, err := linker.CreateCode(t.Context(), testSubject)
+			if err != nil {
+				t.Fatalf("CreateCode() error = %v", err)
+			}
+
+			if !expiresAt.Equal(now.Add(CodeTTL)) {
+				t.Fatalf("CreateCode() expires at %v, want %v", expiresAt, now.Add(CodeTTL))
+			}
+
+			linker.now = func() time.Time { return now.Add(testCase.after) }
+
+			subject, err := linker.Link(t.Context(), testCase.redeem(code), testUserID)
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("Link() error = %v, want %v", err, testCase.wantErr)
+			}
+
+			userID, err := linker.DiscordUser(t.Context(), testSubject)
+			if testCase.wantErr != nil {
+				if !errors.Is(err, ErrNotLinked) {
+					t.Fatalf("DiscordUser() = %q, %v, want %v", userID, err, ErrNotLinked)
+				}
+
+				return
+			}
+
+			if subject != testSubject || userID != testUserID {
+				t.Fatalf("Link() = %q and DiscordUser() = %q, %v, want %q and %q",
+					subject, userID, err, testSubject, testUserID)
+			}
+
+			_, err = linker.Link(t.Context(), code, "someone else")
+			if !errors.Is(err, ErrInvalidCode) {
+				t.Fatalf("Link() of a redeemed code error = %v, want %v", err, ErrInvalidCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kizuna-org/akari/internal/appstate"
+)
+
+// App state key prefixes of pending codes, by code, and of links, by
+// subject.
+const (
+	codeKeyPrefix = "account.link_code."
+	linkKeyPrefix = "account.discord_user."
+)
+
+// Store persists pending link codes and the links they create, so that a
+// code issued by one replica can be redeemed through another.
+type Store interface {
+	SaveCode(ctx context.Context, code string, pending Pending) error
+	// TakeCode returns and removes a pending code, or ErrInvalidCode.
+	TakeCode(ctx context.Context, code string) (Pending, error)
+	SaveLink(ctx context.Context, subject string, discordUserID string) error
+	// DiscordUser returns the Discord user linked to subject, or
+	// ErrNotLinked.
+	DiscordUser(ctx context.Context, subject string) (string, error)
+}
+
+func NewStore(states *appstate.Store) Store {
+	return &StateStore{states: states}
+}
+
+// StateStore keeps codes and links in the app_states table.
+type StateStore struct {
+	states *appstate.Store
+}
+
+func (s *StateStore) SaveCode(ctx context.Context, code string, pending Pending) error {
+	value, err := json.Marshal(pending)
+	if err != nil {
+		return fmt.Errorf("encode link code: %w", err)
+	}
+
+	err = s.states.Set(ctx, codeKeyPrefix+code, string(value))
+	if err != nil {
+		return fmt.Errorf("save link code: %w", err)
+	}
+
+	return nil
+}
+
+func (s *StateStore) TakeCode(ctx context.Context, code string) (Pending, error) {
+	value, err := s.states.Get(ctx, codeKeyPrefix+code)
+	if errors.Is(err, appstate.ErrNotFound) {
+		return Pending{}, ErrInvalidCode
+	}
+
+	if err != nil {
+		return Pending{}, fmt.Errorf("load link code: %w", err)
+	}
+
+	err = s.states.Delete(ctx, codeKeyPrefix+code)
+	if err != nil {
+		return Pending{}, fmt.Errorf("delete link code: %w", err)
+	}
+
+	var pending Pending
+
+	err = json.Unmarshal([]byte(value), &pending)
+	if err != nil {
+		return Pending{}, fmt.Errorf("decode link code: %w", err)
+	}
+
+	return pending, nil
+}
+
+func (s *StateStore) SaveLink(ctx context.Context, subject string, discordUserID string) error {
+	err := s.states.Set(ctx, linkKeyPrefix+subject, discordUserID)
+	if err != nil {
+		return fmt.Errorf("save account link: %w", err)
+	}
+
+	return nil
+}
+
+func (s *StateStore) DiscordUser(ctx context.Context, subject string) (string, error) {
+	discordUserID, err := s.states.Get(ctx, linkKeyPrefix+subject)
+	if errors.Is(err, appstate.ErrNotFound) {
+		return "", ErrNotLinked
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("load account link: %w", err)
+	}
+
+	return discordUserID, nil
+}
+
+// MemoryStore keeps codes and links in memory, for tests and local runs.
+type MemoryStore struct {
+	mu    sync.Mutex
+	codes map[string]Pending
+	links map[string]string
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{mu: sync.Mutex{}, codes: map[string]Pending{}, links: map[string]string{}}
+}
+
+func (s *MemoryStore) SaveCode(_ context.Context, code string, pending Pending) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.codes[code] = pending
+
+	return nil
+}
+
+func (s *MemoryStore) TakeCode(_ context.Context, code string) (Pending, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending, ok := s.codes[code]
+	if !ok {
+		return Pending{}, ErrInvalidCode
+	}
+
+	delete(s.codes, code)
+
+	return pending, nil
+}
+
+func (s *MemoryStore) SaveLink(_ context.Context, subject string, discordUserID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.links[subject] = discordUserID
+
+	return nil
+}
+
+func (s *MemoryStore) DiscordUser(_ context.Context, subject string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	discordUserID, ok := s.links[subject]
+	if !ok {
+		return "", ErrNotLinked
+	}
+
+	return discordUserID, nil
+}
@@ -1,23 +1,135 @@
 package app
 
 import (
+	"github.com/kizuna-org/akari/internal/account"
+	"github.com/kizuna-org/akari/internal/appstate"
+	"github.com/kizuna-org/akari/internal/attachment"
+	"github.com/kizuna-org/akari/internal/auth"
+	"github.com/kizuna-org/akari/internal/character"
+	"github.com/kizuna-org/akari/internal/chat"
+	"github.com/kizuna-org/akari/internal/command"
 	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/conversation"
 	"github.com/kizuna-org/akari/internal/database"
+	"github.com/kizuna-org/akari/internal/discord"
+	"github.com/kizuna-org/akari/internal/health"
+	"github.com/kizuna-org/akari/internal/job"
+	"github.com/kizuna-org/akari/internal/kiseki"
+	"github.com/kizuna-org/akari/internal/llm"
+	"github.com/kizuna-org/akari/internal/logging"
+	"github.com/kizuna-org/akari/internal/memory"
+	"github.com/kizuna-org/akari/internal/metrics"
+	"github.com/kizuna-org/akari/internal/ratelimit"
+	"github.com/kizuna-org/akari/internal/reminder"
+	"github.com/kizuna-org/akari/internal/rpc"
+	"github.com/kizuna-org/akari/internal/secret"
 	"github.com/kizuna-org/akari/internal/server"
+	"github.com/kizuna-org/akari/internal/settings"
+	"github.com/kizuna-org/akari/internal/sleep"
+	"github.com/kizuna-org/akari/internal/tool"
+	"github.com/kizuna-org/akari/internal/tracing"
 	"go.uber.org/fx"
 )
 
 func New() *fx.App {
 	return fx.New(
+		fx.WithLogger(logging.NewEventLogger),
 		fx.Provide(
 			config.Load,
+			logging.New,
+			metrics.NewRegistry,
+			metrics.NewRPCInterceptor,
+			tracing.NewProvider,
+			tracing.NewRPCInterceptor,
+			secret.NewProvider,
+			secret.NewStore,
+			fx.Annotate(metrics.NewRoutes, fx.ResultTags(`group:"routes,flatten"`)),
+			database.NewDB,
 			database.NewClient,
-			server.NewMux,
+			fx.Annotate(server.NewMux, fx.ParamTags(`group:"routes"`)),
 			server.NewHTTPServer,
+			kiseki.NewClient,
+			memory.NewService,
+			ratelimit.NewLimiter,
+			llm.New,
+			character.NewStore,
+			character.NewRegistry,
+			conversation.NewStore,
+			job.NewStore,
+			job.NewQueue,
+			fx.Annotate(job.NewScheduler, fx.ParamTags(``, ``, `group:"job_handlers"`)),
+			reminder.NewService,
+			asJobHandler(reminder.NewHandler),
+			fx.Annotate(tool.NewRunner, fx.ParamTags(``, ``, ``, `group:"tools"`)),
+			asTool(tool.NewCurrentTime),
+			asTool(tool.NewRecallMemory),
+			asTool(tool.NewRecallPerson),
+			asTool(reminder.NewTool),
+			attachment.NewFetcher,
+			chat.NewResponder,
+			discord.NewBots,
+			appstate.NewStore,
+			account.NewStore,
+			account.NewLinker,
+			settings.NewStore,
+			fx.Annotate(settings.NewManager, fx.ParamTags(``, ``, `group:"settings_subscribers"`)),
+			asSettingsSubscriber(ratelimit.NewSettingsSubscriber),
+			asSettingsSubscriber(logging.NewSettingsSubscriber),
+			sleep.NewSchedulers,
+			command.NewRouters,
+			asCommand(command.NewRemember),
+			asCommand(command.NewForget),
+			asCommand(command.NewPersona),
+			asCommand(command.NewRemind),
+			asCommand(command.NewLink),
+			rpc.NewCharacterServer,
+			rpc.NewConversationServer,
+			rpc.NewAdminServer,
+			rpc.NewCharacterAdminServer,
+			rpc.NewAccountServer,
+			auth.NewInterceptor,
+			fx.Annotate(rpc.NewRoutes, fx.ResultTags(`group:"routes,flatten"`)),
+			health.NewChecker,
+			asHealthCheck(health.NewDatabaseCheck),
+			asHealthCheck(health.NewDiscordCheck),
+			fx.Annotate(health.NewRoutes, fx.ResultTags(`group:"routes,flatten"`)),
 		),
 		fx.Invoke(
+			logging.SetDefault,
+			tracing.RegisterLifecycle,
+			secret.RegisterLifecycle,
+			database.RegisterMetrics,
+			ratelimit.RegisterMetrics,
 			database.RegisterLifecycle,
+			character.RegisterLifecycle,
+			settings.RegisterLifecycle,
+			chat.RegisterLifecycle,
 			server.RegisterLifecycle,
+			discord.RegisterLifecycle,
+			sleep.RegisterLifecycle,
+			job.RegisterLifecycle,
+			command.RegisterLifecycle,
+			health.RegisterLifecycle,
 		),
 	)
 }
+
+func asCommand(constructor any) any {
+	return fx.Annotate(constructor, fx.ResultTags(`group:"commands"`))
+}
+
+func asTool(constructor any) any {
+	return fx.Annotate(constructor, fx.ResultTags(`group:"tools"`))
+}
+
+func asJobHandler(constructor any) any {
+	return fx.Annotate(constructor, fx.ResultTags(`group:"job_handlers"`))
+}
+
+func asSettingsSubscriber(constructor any) any {
+	return fx.Annotate(constructor, fx.ResultTags(`group:"settings_subscribers"`))
+}
+
+func asHealthCheck(constructor any) any {
+	return fx.Annotate(constructor, fx.ResultTags(`group:"health_checks"`))
+}
@@ -0,0 +1,64 @@
+package appstate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kizuna-org/akari/gen/ent"
+	"github.com/kizuna-org/akari/gen/ent/appstate"
+)
+
+var ErrNotFound = errors.New("app state not found")
+
+// Store persists small pieces of process state, such as scheduler
+// bookkeeping, as key/value pairs.
+type Store struct {
+	client *ent.Client
+}
+
+func NewStore(client *ent.Client) *Store {
+	return &Store{client: client}
+}
+
+func (s *Store) Get(ctx context.Context, key string) (string, error) {
+	state, err := s.client.AppState.Query().
+		Where(appstate.KeyEQ(key)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return "", ErrNotFound
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("get app state %q: %w", key, err)
+	}
+
+	return state.Value, nil
+}
+
+// Set stores value under key, replacing any previous value in a single
+// upsert so concurrent writers of a new key cannot both insert it.
+func (s *Store) Set(ctx context.Context, key string, value string) error {
+	err := s.client.AppState.Create().
+		SetKey(key).
+		SetValue(value).
+		OnConflictColumns(appstate.FieldKey).
+		UpdateNewValues().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("set app state %q: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	_, err := s.client.AppState.Delete().
+		Where(appstate.KeyEQ(key)).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("delete app state %q: %w", key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/metrics"
+	"github.com/kizuna-org/akari/internal/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ErrNotAllowed = errors.New("attachment type is not allowed")
+	ErrTooLarge   = errors.New("attachment is too large")
+	ErrDownload   = errors.New("attachment download failed")
+)
+
+// File describes a file attached to a message. Size is the size Discord
+// reports, in bytes.
+type File struct {
+	Filename    string
+	ContentType string
+	Size        int
+	URL         string
+}
+
+// Download is the content of a file, with the type it was served as.
+type Download struct {
+	File
+	MIMEType string
+	Data     []byte
+}
+
+// Fetcher downloads the attachments of messages within the configured
+// limits, so the model can be shown them.
+type Fetcher struct {
+	client    *http.Client
+	mimeTypes []string
+	maxBytes  int
+	maxCount  int
+	fetches   *prometheus.CounterVec
+}
+
+func NewFetcher(cfg config.Config, registry *prometheus.Registry) (*Fetcher, error) {
+	fetches, err := metrics.Register(registry, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "attachments",
+		Name:      "fetches_total",
+		Help:      "Message attachments considered for the model, by outcome (downloaded, skipped or failed).",
+	}, []string{"outcome"}))
+	if err != nil {
+		return nil, err
+	}
+
+	client := new(http.Client)
+	client.Timeout = cfg.Attachments.Timeout
+	client.Transport = tracing.NewTransport(http.DefaultTransport)
+
+	return &Fetcher{
+		client:    client,
+		mimeTypes: cfg.Attachments.MIMETypes,
+		maxBytes:  cfg.Attachments.MaxBytes,
+		maxCount:  cfg.Attachments.MaxCount,
+		fetches:   fetches,
+	}, nil
+}
+
+// Fetch downloads the files of an allowed type and size, up to the maximum
+// count, in order. Other files and failed downloads are logged and left out:
+// a reply to the text is better than none.
+func (f *Fetcher) Fetch(ctx context.Context, files []File) []Download {
+	var downloads []Download
+
+	for _, file := range files {
+		if len(downloads) == f.maxCount {
+			f.fetches.WithLabelValues("skipped").Inc()
+			slog.InfoContext(ctx, "attachment skipped", "filename", file.Filename, "reason", "too many attachments")
+
+			continue
+		}
+
+		err := f.check(file.ContentType, file.Size)
+		if err != nil {
+			f.fetches.WithLabelValues("skipped").Inc()
+			slog.InfoContext(ctx, "attachment skipped", "filename", file.Filename, "reason", err)
+
+			continue
+		}
+
+		download, err := f.download(ctx, file)
+		if err != nil {
+			f.fetches.WithLabelValues("failed").Inc()
+			slog.WarnContext(ctx, "download attachment failed", "filename", file.Filename, "error", err)
+
+			continue
+		}
+
+		f.fetches.WithLabelValues("downloaded").Inc()
+
+		downloads = append(downloads, download)
+	}
+
+	return downloads
+}
+
+// check reports whether a file of contentType and size may be downloaded.
+// A size below zero is unknown.
+func (f *Fetcher) check(contentType string, size int) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !slices.Contains(f.mimeTypes, mediaType) {
+		return fmt.Errorf("%w: %q", ErrNotAllowed, contentType)
+	}
+
+	if size > f.maxBytes {
+		return fmt.Errorf("%w: %d bytes, at most %d", ErrTooLarge, size, f.maxBytes)
+	}
+
+	return nil
+}
+
+// download fetches a file, checking what the server sends rather than
+// trusting the message's description of it.
+func (f *Fetcher) download(ctx context.Context, file File) (Download, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, file.URL, nil)
+	if err != nil {
+		return Download{}, fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return Download{}, fmt.Errorf("send request: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return Download{}, fmt.Errorf("%w: %s", ErrDownload, resp.Status)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+
+	err = f.check(contentType, int(resp.ContentLength))
+	if err != nil {
+		return Download{}, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(f.maxBytes)+1))
+	if err != nil {
+		return Download{}, fmt.Errorf("read body: %w", err)
+	}
+
+	if len(data) > f.maxBytes {
+		return Download{}, fmt.Errorf("%w: more than %d bytes", ErrTooLarge, f.maxBytes)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	return Download{File: file, MIMEType: mediaType, Data: data}, nil
+}
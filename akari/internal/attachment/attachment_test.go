@@ -0,0 +1,103 @@
+package attachment
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestFetcherFetch(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cat.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte("png"))
+		case "/big.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte(strings.Repeat("a", 16)))
+		case "/disguised.png":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	var cfg config.Config
+	cfg.Attachments = config.Attachments{
+		MIMETypes: []string{"image/png", "image/jpeg"},
+		MaxBytes:  8,
+		MaxCount:  2,
+		Timeout:   time.Second,
+	}
+
+	fetcher, err := NewFetcher(cfg, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("NewFetcher() error = %v", err)
+	}
+
+	file := func(name string, contentType string, size int) File {
+		return File{Filename: name, ContentType: contentType, Size: size, URL: server.URL + "/" + name}
+	}
+
+	tests := []struct {
+		name  string
+		files []File
+		want  []string
+	}{
+		{
+			name:  "downloads allowed types",
+			files: []File{file("cat.png", "image/png", 3), file("notes.txt", "text/plain", 3)},
+			want:  []string{"cat.png:image/png:png"},
+		},
+		{
+			name:  "skips files reported too large",
+			files: []File{file("cat.png", "image/png", 9)},
+			want:  nil,
+		},
+		{
+			name:  "checks what is served",
+			files: []File{file("big.png", "image/png", 3), file("disguised.png", "image/png", 3)},
+			want:  nil,
+		},
+		{
+			name:  "skips failed downloads",
+			files: []File{file("missing.png", "image/png", 3), file("cat.png", "image/png", 3)},
+			want:  []string{"cat.png:image/png:png"},
+		},
+		{
+			name: "caps the count",
+			files: []File{
+				file("cat.png", "image/png", 3),
+				file("cat.png", "image/png; name=cat", 3),
+				file("cat.png", "image/png", 3),
+			},
+			want: []string{"cat.png:image/png:png", "cat.png:image/png:png"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			downloads := fetcher.Fetch(t.Context(), testCase.files)
+
+			got := make([]string, 0, len(downloads))
+			for _, download := range downloads {
+				got = append(got, download.Filename+":"+download.MIMEType+":"+string(download.Data))
+			}
+
+			if strings.Join(got, ",") != strings.Join(testCase.want, ",") {
+				t.Fatalf("Fetch() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
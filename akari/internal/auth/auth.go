@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"connectrpc.com/connect"
+	"github.com/kizuna-org/akari/gen/proto/akari/v1/akariv1connect"
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/secret"
+)
+
+const bearerPrefix = "Bearer "
+
+var (
+	ErrMissingToken     = errors.New("missing bearer token")
+	ErrInvalidToken     = errors.New("invalid bearer token")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrInvalidAPIKey    = errors.New("invalid API key entry")
+	ErrUnknownRole      = errors.New("unknown role")
+)
+
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+func parseRole(value string) (Role, error) {
+	switch role := Role(value); role {
+	case RoleUser, RoleAdmin:
+		return role, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownRole, value)
+	}
+}
+
+// allows reports whether r may call procedures that require role.
+func (r Role) allows(role Role) bool {
+	return r == RoleAdmin || r == role
+}
+
+// Principal is the authenticated caller of a procedure.
+type Principal struct {
+	Subject string
+	Role    Role
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the caller authenticated by the Interceptor.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+
+	return principal, ok
+}
+
+type rule struct {
+	prefix string
+	role   Role
+}
+
+// rules lists the role required by procedure prefix. The first match wins
+// and any other procedure requires RoleUser.
+var rules = []rule{
+	{prefix: "/" + akariv1connect.AdminServiceName + "/", role: RoleAdmin},
+	{prefix: "/" + akariv1connect.CharacterAdminServiceName + "/", role: RoleAdmin},
+	{prefix: akariv1connect.ConversationServiceExportConversationsProcedure, role: RoleAdmin},
+	{prefix: akariv1connect.ConversationServiceImportConversationsProcedure, role: RoleAdmin},
+}
+
+func requiredRole(procedure string) Role {
+	for _, rule := range rules {
+		if strings.HasPrefix(procedure, rule.prefix) {
+			return rule.role
+		}
+	}
+
+	return RoleUser
+}
+
+type apiKey struct {
+	name string
+	role Role
+	key  []byte
+}
+
+// Interceptor authenticates Connect requests by bearer token, either a static
+// API key or a JWT, and enforces the role each procedure requires.
+type Interceptor struct {
+	keys atomic.Pointer[[]apiKey]
+	jwt  *verifier
+}
+
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+// NewInterceptor authenticates with the API keys held by secrets, picking up
+// rotated keys as they change.
+func NewInterceptor(cfg config.Config, secrets *secret.Store) (*Interceptor, error) {
+	keys, err := parseAPIKeys(secrets.Get(secret.APIKeys))
+	if err != nil {
+		return nil, err
+	}
+
+	var jwtVerifier *verifier
+
+	if cfg.Auth.JWKSFile != "" {
+		jwtVerifier, err = newVerifier(cfg.Auth.JWKSFile, cfg.Auth.JWTIssuer, cfg.Auth.JWTAudience)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	interceptor := &Interceptor{keys: atomic.Pointer[[]apiKey]{}, jwt: jwtVerifier}
+	interceptor.keys.Store(&keys)
+
+	secrets.Subscribe(secret.APIKeys, func(value string) {
+		keys, err := parseAPIKeys(value)
+		if err != nil {
+			slog.Error("rotated API keys are invalid, keeping the previous keys", "error", err)
+
+			return
+		}
+
+		interceptor.keys.Store(&keys)
+	})
+
+	return interceptor, nil
+}
+
+// parseAPIKeys parses comma-separated name:role:key entries.
+func parseAPIKeys(value string) ([]apiKey, error) {
+	var keys []apiKey
+
+	for entry := range strings.SplitSeq(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, rest, _ := strings.Cut(entry, ":")
+		roleName, key, _ := strings.Cut(rest, ":")
+
+		if name == "" || key == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidAPIKey, name)
+		}
+
+		role, err := parseRole(roleName)
+		if err != nil {
+			return nil, fmt.Errorf("parse API key %q: %w", name, err)
+		}
+
+		keys = append(keys, apiKey{name: name, role: role, key: []byte(key)})
+	}
+
+	return keys, nil
+}
+
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+
+		ctx, err := i.authorize(ctx, req.Spec().Procedure, req.Header())
+		if err != nil {
+			return nil, err
+		}
+
+		return next(ctx, req)
+	}
+}
+
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := i.authorize(ctx, conn.Spec().Procedure, conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+
+		return next(ctx, conn)
+	}
+}
+
+func (i *Interceptor) authorize(ctx context.Context, procedure string, header http.Header) (context.Context, error) {
+	token, ok := strings.CutPrefix(header.Get("Authorization"), bearerPrefix)
+	if !ok || token == "" {
+		return ctx, connect.NewError(connect.CodeUnauthenticated, ErrMissingToken)
+	}
+
+	principal, err := i.authenticate(token)
+	if err != nil {
+		return ctx, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if !principal.Role.allows(requiredRole(procedure)) {
+		return ctx, connect.NewError(
+			connect.CodePermissionDenied,
+			fmt.Errorf("%w: %s may not call %s", ErrPermissionDenied, principal.Subject, procedure),
+		)
+	}
+
+	return WithPrincipal(ctx, principal), nil
+}
+
+func (i *Interceptor) authenticate(token string) (Principal, error) {
+	for _, key := range *i.keys.Load() {
+		if subtle.ConstantTimeCompare(key.key, []byte(token)) == 1 {
+			return Principal{Subject: key.name, Role: key.role}, nil
+		}
+	}
+
+	if i.jwt == nil {
+		return Principal{}, ErrInvalidToken
+	}
+
+	return i.jwt.verify(token)
+}
@@ -0,0 +1,335 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/secret"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	testIssuer         = "https://auth.example.com"
+	testAudience       = "akari"
+	testAdminProcedure = "/akari.v1.AdminService/Test"
+	testUserProcedure  = "/akari.v1.CharacterService/Test"
+)
+
+type testKeys struct {
+	ed25519 ed25519.PrivateKey
+	ecdsa   *ecdsa.PrivateKey
+}
+
+func newTestKeys(t *testing.T) (testKeys, string) {
+	t.Helper()
+
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	ecPrivate, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	point, err := ecPrivate.PublicKey.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+
+	encode := base64.RawURLEncoding.EncodeToString
+	size := (len(point) - 1) / 2
+	set := map[string][]map[string]string{"keys": {
+		{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": encode(edPublic)},
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": encode(point[1 : 1+size]), "y": encode(point[1+size:])},
+	}}
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+
+	err = os.WriteFile(path, data, 0o600)
+	if err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	return testKeys{ed25519: edPrivate, ecdsa: ecPrivate}, path
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key crypto.Signer, tokenClaims claims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, tokenClaims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+
+	return signed
+}
+
+func validClaims(role string) claims {
+	return claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    testIssuer,
+			Subject:   "alice",
+			Audience:  jwt.ClaimStrings{testAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			NotBefore: nil,
+			IssuedAt:  nil,
+			ID:        "",
+		},
+		Role: role,
+	}
+}
+
+func TestParseAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr error
+	}{
+		{name: "empty", value: "", want: 0, wantErr: nil},
+		{name: "two keys", value: "ops:admin:secret, bot:user:token", want: 2, wantErr: nil},
+		{name: "missing key", value: "ops:admin", want: 0, wantErr: ErrInvalidAPIKey},
+		{name: "unknown role", value: "ops:root:secret", want: 0, wantErr: ErrUnknownRole},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys, err := parseAPIKeys(testCase.value)
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("parseAPIKeys() error = %v, want %v", err, testCase.wantErr)
+			}
+
+			if len(keys) != testCase.want {
+				t.Fatalf("parseAPIKeys() = %d keys, want %d", len(keys), testCase.want)
+			}
+		})
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	t.Parallel()
+
+	keys, path := newTestKeys(t)
+
+	verifier, err := newVerifier(path, testIssuer, testAudience)
+	if err != nil {
+		t.Fatalf("newVerifier() error = %v", err)
+	}
+
+	expired := validClaims("")
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+
+	otherIssuer := validClaims("")
+	otherIssuer.Issuer = "https://evil.example.com"
+
+	noSubject := validClaims("")
+	noSubject.Subject = ""
+
+	tests := []struct {
+		name    string
+		token   string
+		want    Principal
+		wantErr bool
+	}{
+		{
+			name:  "ed25519 user",
+			token: sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed25519, validClaims("")),
+			want:  Principal{Subject: "alice", Role: RoleUser},
+		},
+		{
+			name:  "ecdsa admin",
+			token: sign(t, jwt.SigningMethodES256, "ec", keys.ecdsa, validClaims("admin")),
+			want:  Principal{Subject: "alice", Role: RoleAdmin},
+		},
+		{
+			name:    "expired",
+			token:   sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed25519, expired),
+			wantErr: true,
+		},
+		{
+			name:    "other issuer",
+			token:   sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed25519, otherIssuer),
+			wantErr: true,
+		},
+		{
+			name:    "no subject",
+			token:   sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed25519, noSubject),
+			wantErr: true,
+		},
+		{
+			name:    "unknown role",
+			token:   sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed25519, validClaims("root")),
+			wantErr: true,
+		},
+		{
+			name:    "key id mismatch",
+			token:   sign(t, jwt.SigningMethodEdDSA, "ec", keys.ed25519, validClaims("")),
+			wantErr: true,
+		},
+		{name: "not a jwt", token: "garbage", wantErr: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := verifier.verify(testCase.token)
+			if testCase.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Fatalf("verify() error = %v, want %v", err, ErrInvalidToken)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("verify() error = %v", err)
+			}
+
+			if got != testCase.want {
+				t.Fatalf("verify() = %+v, want %+v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestInterceptor(t *testing.T) {
+	t.Parallel()
+
+	keys, path := newTestKeys(t)
+
+	var cfg config.Config
+	cfg.Auth.APIKeys = "ops:admin:admin-key,bot:user:user-key"
+	cfg.Auth.JWTIssuer = testIssuer
+	cfg.Auth.JWKSFile = path
+
+	secrets, err := secret.NewStore(cfg, secret.NoProvider{})
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	interceptor, err := NewInterceptor(cfg, secrets)
+	if err != nil {
+		t.Fatalf("NewInterceptor() error = %v", err)
+	}
+
+	principals := make(chan Principal, 1)
+	mux := http.NewServeMux()
+
+	for _, procedure := range []string{testAdminProcedure, testUserProcedure} {
+		mux.Handle(procedure, connect.NewUnaryHandler(
+			procedure,
+			func(ctx context.Context, _ *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+				principal, _ := PrincipalFrom(ctx)
+				principals <- principal
+
+				return connect.NewResponse(&emptypb.Empty{}), nil
+			},
+			connect.WithInterceptors(interceptor),
+		))
+	}
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	userJWT := sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed25519, validClaims(""))
+
+	tests := []struct {
+		name      string
+		procedure string
+		token     string
+		want      Principal
+		wantCode  connect.Code
+	}{
+		{name: "missing token", procedure: testUserProcedure, token: "", wantCode: connect.CodeUnauthenticated},
+		{name: "invalid token", procedure: testUserProcedure, token: "nope", wantCode: connect.CodeUnauthenticated},
+		{
+			name:      "user key on user procedure",
+			procedure: testUserProcedure,
+			token:     "user-key",
+			want:      Principal{Subject: "bot", Role: RoleUser},
+		},
+		{
+			name:      "user key on admin procedure",
+			procedure: testAdminProcedure,
+			token:     "user-key",
+			wantCode:  connect.CodePermissionDenied,
+		},
+		{
+			name:      "admin key on admin procedure",
+			procedure: testAdminProcedure,
+			token:     "admin-key",
+			want:      Principal{Subject: "ops", Role: RoleAdmin},
+		},
+		{
+			name:      "user jwt",
+			procedure: testUserProcedure,
+			token:     userJWT,
+			want:      Principal{Subject: "alice", Role: RoleUser},
+		},
+		{
+			name:      "user jwt on admin procedure",
+			procedure: testAdminProcedure,
+			token:     userJWT,
+			wantCode:  connect.CodePermissionDenied,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := connect.NewClient[emptypb.Empty, emptypb.Empty](server.Client(), server.URL+testCase.procedure)
+
+			req := connect.NewRequest(&emptypb.Empty{})
+			if testCase.token != "" {
+				req.Header().Set("Authorization", bearerPrefix+testCase.token)
+			}
+
+			_, err := client.CallUnary(t.Context(), req)
+			if testCase.wantCode != 0 {
+				if connect.CodeOf(err) != testCase.wantCode {
+					t.Fatalf("CallUnary() error = %v, want code %v", err, testCase.wantCode)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("CallUnary() error = %v", err)
+			}
+
+			if got := <-principals; got != testCase.want {
+				t.Fatalf("principal = %+v, want %+v", got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// uncompressedPoint prefixes an uncompressed elliptic curve point (SEC 1).
+const uncompressedPoint = 0x04
+
+var (
+	ErrIssuerRequired = errors.New("AUTH_JWT_ISSUER is required with AUTH_JWKS_FILE")
+	ErrEmptyKeySet    = errors.New("JWKS has no keys")
+	ErrUnsupportedKey = errors.New("unsupported JWK")
+	ErrUnknownKey     = errors.New("unknown signing key")
+	ErrMissingSubject = errors.New("token has no subject")
+)
+
+var signingMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// claims are the JWT claims akari reads. Tokens without a role claim
+// authenticate a regular user.
+type claims struct {
+	jwt.RegisteredClaims
+
+	Role string `json:"role,omitempty"`
+}
+
+type verifier struct {
+	keys   map[string]crypto.PublicKey
+	parser *jwt.Parser
+}
+
+func newVerifier(jwksFile string, issuer string, audience string) (*verifier, error) {
+	if issuer == "" {
+		return nil, ErrIssuerRequired
+	}
+
+	keys, err := loadJWKS(jwksFile)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithValidMethods(signingMethods),
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+
+	return &verifier{keys: keys, parser: jwt.NewParser(options...)}, nil
+}
+
+func (v *verifier) verify(token string) (Principal, error) {
+	parsed := new(claims)
+
+	_, err := v.parser.ParseWithClaims(token, parsed, v.key)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if parsed.Subject == "" {
+		return Principal{}, fmt.Errorf("%w: %w", ErrInvalidToken, ErrMissingSubject)
+	}
+
+	role := RoleUser
+	if parsed.Role != "" {
+		role, err = parseRole(parsed.Role)
+		if err != nil {
+			return Principal{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+		}
+	}
+
+	return Principal{Subject: parsed.Subject, Role: role}, nil
+}
+
+// key selects the verification key by the token's kid header. A token
+// without a kid is accepted when the set holds a single key.
+func (v *verifier) key(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, nil
+		}
+	}
+
+	key, ok := v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+
+	return key, nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func loadJWKS(path string) (map[string]crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read JWKS: %w", err)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	err = json.Unmarshal(data, &set)
+	if err != nil {
+		return nil, fmt.Errorf("decode JWKS: %w", err)
+	}
+
+	if len(set.Keys) == 0 {
+		return nil, ErrEmptyKeySet
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+
+	for _, entry := range set.Keys {
+		key, err := entry.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("parse JWK %q: %w", entry.Kid, err)
+		}
+
+		keys[entry.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch {
+	case k.Kty == "RSA":
+		return k.rsaKey()
+	case k.Kty == "EC":
+		return k.ecdsaKey()
+	case k.Kty == "OKP" && k.Crv == "Ed25519":
+		x, err := decodeSegment(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: Ed25519 key is %d bytes", ErrUnsupportedKey, len(x))
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("%w: kty %q crv %q", ErrUnsupportedKey, k.Kty, k.Crv)
+	}
+}
+
+func (k jwk) rsaKey() (*rsa.PublicKey, error) {
+	n, err := decodeSegment(k.N)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := decodeSegment(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() {
+		return nil, fmt.Errorf("%w: RSA exponent too large", ErrUnsupportedKey)
+	}
+
+	key := new(rsa.PublicKey)
+	key.N = new(big.Int).SetBytes(n)
+	key.E = int(exponent.Int64())
+
+	return key, nil
+}
+
+func (k jwk) ecdsaKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("%w: curve %q", ErrUnsupportedKey, k.Crv)
+	}
+
+	x, err := decodeSegment(k.X)
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := decodeSegment(k.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	size := (curve.Params().BitSize + 7) / 8 //nolint:mnd // bits to bytes, rounded up.
+	if len(x) != size || len(y) != size {
+		return nil, fmt.Errorf("%w: %s coordinates must be %d bytes", ErrUnsupportedKey, k.Crv, size)
+	}
+
+	point := append([]byte{uncompressedPoint}, append(x, y...)...)
+
+	key, err := ecdsa.ParseUncompressedPublicKey(curve, point)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s key: %w", k.Crv, err)
+	}
+
+	return key, nil
+}
+
+func decodeSegment(value string) ([]byte, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("decode JWK field: %w", err)
+	}
+
+	return data, nil
+}
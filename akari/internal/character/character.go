@@ -0,0 +1,91 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// DefaultPromptTemplate renders the system prompt of characters without a
+// template of their own.
+const DefaultPromptTemplate = "You are {{.Name}}, a friendly companion chatting on Discord." +
+	"{{with .Persona}}\n\n{{.}}{{end}}" +
+	"{{with .Memories}}\n\nThings you remember:{{range .}}\n- {{.}}{{end}}{{end}}"
+
+var (
+	ErrNotFound        = errors.New("character not found")
+	ErrExists          = errors.New("character already exists")
+	ErrInvalidTemplate = errors.New("invalid prompt template")
+	ErrInvalid         = errors.New("invalid character")
+)
+
+// Character is a hosted persona as edited at runtime. ChannelIDs lists the
+// guild channels the character replies in; when empty it replies everywhere.
+// Tools names the tools its model may call.
+type Character struct {
+	ID             string
+	Name           string
+	Persona        string
+	PromptTemplate string
+	ChannelIDs     []string
+	Tools          []string
+}
+
+// PromptData is what a prompt template is executed with.
+type PromptData struct {
+	Name     string
+	Persona  string
+	Memories []string
+}
+
+// Listens reports whether the character replies in a guild channel.
+func (c Character) Listens(channelID string) bool {
+	return len(c.ChannelIDs) == 0 || slices.Contains(c.ChannelIDs, channelID)
+}
+
+// SystemPrompt renders the character's prompt template with what it
+// remembers about the conversation.
+func (c Character) SystemPrompt(memories []string) (string, error) {
+	return render(c.PromptTemplate, PromptData{Name: c.Name, Persona: c.Persona, Memories: memories})
+}
+
+// Validate checks the fields an admin may edit, including that the prompt
+// template parses and executes against sample data.
+func (c Character) Validate() error {
+	if c.ID == "" || strings.TrimSpace(c.Name) == "" {
+		return fmt.Errorf("%w: id and name are required", ErrInvalid)
+	}
+
+	_, err := c.SystemPrompt([]string{"Alice: I like tea."})
+
+	return err
+}
+
+func (c Character) clone() Character {
+	c.ChannelIDs = slices.Clone(c.ChannelIDs)
+	c.Tools = slices.Clone(c.Tools)
+
+	return c
+}
+
+func render(text string, data PromptData) (string, error) {
+	if text == "" {
+		text = DefaultPromptTemplate
+	}
+
+	tmpl, err := template.New("prompt").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+
+	var prompt strings.Builder
+
+	err = tmpl.Execute(&prompt, data)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+
+	return prompt.String(), nil
+}
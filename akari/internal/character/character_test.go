@@ -0,0 +1,201 @@
+package character
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/kizuna-org/akari/internal/config"
+)
+
+const testID = "0193b1c6-6f5e-7a51-9a3c-3f0d1c2b4e5f"
+
+func newTestRegistry(t *testing.T) (*Registry, *MemoryStore) {
+	t.Helper()
+
+	var cfg config.Config
+	cfg.Character.ID = testID
+	cfg.Character.Name = "Akari"
+
+	store := NewMemoryStore()
+	registry := NewRegistry(cfg, store)
+
+	err := registry.Load(t.Context())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	return registry, store
+}
+
+func TestListens(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		channels []string
+		channel  string
+		want     bool
+	}{
+		{name: "no allowlist", channels: nil, channel: "general", want: true},
+		{name: "listed", channels: []string{"general"}, channel: "general", want: true},
+		{name: "not listed", channels: []string{"general"}, channel: "random", want: false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			character := Character{
+				ID:             testID,
+				Name:           "Akari",
+				Persona:        "",
+				PromptTemplate: "",
+				ChannelIDs:     testCase.channels,
+				Tools:          nil,
+			}
+			if got := character.Listens(testCase.channel); got != testCase.want {
+				t.Fatalf("Listens() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRegistryLoadSeedsConfiguredCharacter(t *testing.T) {
+	t.Parallel()
+
+	registry, store := newTestRegistry(t)
+
+	stored, err := store.List(t.Context())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(stored) != 1 || stored[0].Name != "Akari" {
+		t.Fatalf("stored = %+v, want the configured character", stored)
+	}
+
+	_, err = registry.Update(t.Context(), testID, func(target *Character) { target.Name = "Hikari" })
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// A restart keeps the edited name instead of re-seeding from config, but
+	// takes the configured tools while none are stored.
+	reloaded := NewRegistry(config.Config{
+		Character: config.Character{ID: testID, Name: "Akari", Tools: []string{"current_time"}},
+	}, store)
+
+	err = reloaded.Load(t.Context())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got := reloaded.Primary(); got.Name != "Hikari" || !slices.Equal(got.Tools, []string{"current_time"}) {
+		t.Fatalf("Primary() = %+v, want Hikari with the configured tools", got)
+	}
+}
+
+func TestRegistryUpdate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		id      string
+		edit    func(*Character)
+		wantErr error
+	}{
+		{
+			name:    "persona",
+			id:      testID,
+			edit:    func(target *Character) { target.Persona = "You love green tea." },
+			wantErr: nil,
+		},
+		{
+			name:    "unknown character",
+			id:      "unknown",
+			edit:    func(*Character) {},
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "unparsable template",
+			id:      testID,
+			edit:    func(target *Character) { target.PromptTemplate = "{{.Name" },
+			wantErr: ErrInvalidTemplate,
+		},
+		{
+			name:    "unknown template field",
+			id:      testID,
+			edit:    func(target *Character) { target.PromptTemplate = "{{.Mood}}" },
+			wantErr: ErrInvalidTemplate,
+		},
+		{
+			name:    "blank name",
+			id:      testID,
+			edit:    func(target *Character) { target.Name = " " },
+			wantErr: ErrInvalid,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			registry, _ := newTestRegistry(t)
+			before := registry.Primary()
+
+			_, err := registry.Update(t.Context(), testCase.id, testCase.edit)
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("Update() error = %v, want %v", err, testCase.wantErr)
+			}
+
+			if testCase.wantErr != nil && registry.Primary().PromptTemplate != before.PromptTemplate {
+				t.Fatal("Update() changed the character despite failing")
+			}
+		})
+	}
+}
+
+func TestRegistryRoute(t *testing.T) {
+	t.Parallel()
+
+	var cfg config.Config
+	cfg.Character = config.Character{ID: testID, Name: "Akari"}
+	cfg.Characters = []config.Character{{ID: "luna", Name: "Luna"}, {ID: "sol", Name: "Sol"}}
+
+	registry := NewRegistry(cfg, NewMemoryStore())
+
+	err := registry.Load(t.Context())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	for _, id := range []string{"luna", "sol"} {
+		_, err = registry.Update(t.Context(), id, func(target *Character) { target.ChannelIDs = []string{"night"} })
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+
+	shared := func(id string) bool { return id != "luna" }
+
+	tests := []struct {
+		name      string
+		channelID string
+		want      string
+	}{
+		{name: "listed channel", channelID: "night", want: "sol"},
+		{name: "other channel", channelID: "day", want: testID},
+		{name: "direct message", channelID: "", want: testID},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := registry.Route(testCase.channelID, shared).ID; got != testCase.want {
+				t.Fatalf("Route(%q) = %q, want %q", testCase.channelID, got, testCase.want)
+			}
+		})
+	}
+}
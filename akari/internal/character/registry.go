@@ -0,0 +1,247 @@
+package character
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"go.uber.org/fx"
+)
+
+type Store interface {
+	List(ctx context.Context) ([]Character, error)
+	Create(ctx context.Context, character Character) error
+	Update(ctx context.Context, character Character) error
+}
+
+// Registry holds the current definition of every character. Reads are served
+// from memory, so edits made through it apply to the next reply without a
+// restart.
+type Registry struct {
+	store   Store
+	primary string
+	seeds   []Character
+
+	// writeMu serializes edits so a read-modify-write is not lost; mu only
+	// guards the map and is never held across a store call.
+	writeMu    sync.Mutex
+	mu         sync.RWMutex
+	characters map[string]Character
+}
+
+func NewRegistry(cfg config.Config, store Store) *Registry {
+	seeds := make([]Character, 0, len(cfg.Characters)+1)
+	characters := make(map[string]Character, len(cfg.Characters)+1)
+
+	for _, hosted := range append([]config.Character{cfg.Character}, cfg.Characters...) {
+		seed := Character{
+			ID:             hosted.ID,
+			Name:           hosted.Name,
+			Persona:        "",
+			PromptTemplate: "",
+			ChannelIDs:     nil,
+			Tools:          hosted.Tools,
+		}
+		seeds = append(seeds, seed)
+		characters[seed.ID] = seed
+	}
+
+	return &Registry{
+		store:      store,
+		primary:    cfg.Character.ID,
+		seeds:      seeds,
+		writeMu:    sync.Mutex{},
+		mu:         sync.RWMutex{},
+		characters: characters,
+	}
+}
+
+func RegisterLifecycle(lc fx.Lifecycle, registry *Registry) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			err := registry.Load(ctx)
+			if err != nil {
+				return err
+			}
+
+			slog.Info("characters loaded", "count", len(registry.List()))
+
+			return nil
+		},
+		OnStop: nil,
+	})
+}
+
+// Load reads every character from the store. The characters configured by
+// CHARACTER_ID and listed in the AKARI_CONFIG file are created from the
+// configuration the first time; afterwards the stored definition wins,
+// except that a configured character without stored tools takes the tools
+// its configuration lists, as characters stored before they had tools do.
+func (r *Registry) Load(ctx context.Context) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	stored, err := r.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	characters := make(map[string]Character, len(stored)+1)
+	for _, character := range stored {
+		characters[character.ID] = character
+	}
+
+	for _, seed := range r.seeds {
+		if stored, ok := characters[seed.ID]; ok {
+			if len(stored.Tools) == 0 {
+				stored.Tools = seed.Tools
+				characters[seed.ID] = stored
+			}
+
+			continue
+		}
+
+		if seed.ID != "" {
+			err = r.store.Create(ctx, seed)
+			if err != nil && !errors.Is(err, ErrExists) {
+				return err
+			}
+		}
+
+		characters[seed.ID] = seed
+	}
+
+	r.mu.Lock()
+	r.characters = characters
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Primary returns the character configured by CHARACTER_ID, which replies
+// wherever no other character is routed.
+func (r *Registry) Primary() Character {
+	character, _ := r.Get(r.primary)
+
+	return character
+}
+
+// Hosted returns the IDs of the characters this instance replies as, the
+// primary first.
+func (r *Registry) Hosted() []string {
+	ids := make([]string, 0, len(r.seeds))
+	for _, seed := range r.seeds {
+		ids = append(ids, seed.ID)
+	}
+
+	return ids
+}
+
+// Route picks the character that replies in a channel of a bot shared by the
+// characters for which shares reports true: the first of them, by ID, that
+// lists the channel, or else the primary.
+func (r *Registry) Route(channelID string, shares func(id string) bool) Character {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, id := range slices.Sorted(maps.Keys(r.characters)) {
+		character := r.characters[id]
+		if id != r.primary && shares(id) && slices.Contains(character.ChannelIDs, channelID) {
+			return character.clone()
+		}
+	}
+
+	return r.characters[r.primary].clone()
+}
+
+func (r *Registry) Get(id string) (Character, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	character, ok := r.characters[id]
+
+	return character.clone(), ok
+}
+
+// List returns the characters sorted by ID.
+func (r *Registry) List() []Character {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	characters := make([]Character, 0, len(r.characters))
+	for _, id := range slices.Sorted(maps.Keys(r.characters)) {
+		if id != "" {
+			characters = append(characters, r.characters[id].clone())
+		}
+	}
+
+	return characters
+}
+
+func (r *Registry) Create(ctx context.Context, character Character) (Character, error) {
+	character.Name = strings.TrimSpace(character.Name)
+
+	err := character.Validate()
+	if err != nil {
+		return Character{}, err
+	}
+
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	if _, ok := r.Get(character.ID); ok {
+		return Character{}, fmt.Errorf("%w: %s", ErrExists, character.ID)
+	}
+
+	err = r.store.Create(ctx, character)
+	if err != nil {
+		return Character{}, err
+	}
+
+	r.set(character)
+
+	return character.clone(), nil
+}
+
+// Update applies edit to the current definition of a character and saves
+// the result if it is still valid.
+func (r *Registry) Update(ctx context.Context, id string, edit func(*Character)) (Character, error) {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	character, ok := r.Get(id)
+	if !ok || id == "" {
+		return Character{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	edit(&character)
+	character.ID = id
+	character.Name = strings.TrimSpace(character.Name)
+
+	err := character.Validate()
+	if err != nil {
+		return Character{}, err
+	}
+
+	err = r.store.Update(ctx, character)
+	if err != nil {
+		return Character{}, err
+	}
+
+	r.set(character)
+
+	return character.clone(), nil
+}
+
+func (r *Registry) set(character Character) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.characters[character.ID] = character.clone()
+}
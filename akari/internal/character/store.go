@@ -0,0 +1,137 @@
+package character
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/kizuna-org/akari/gen/ent"
+)
+
+func NewStore(client *ent.Client) Store {
+	return NewEntStore(client)
+}
+
+// EntStore persists characters in Postgres.
+type EntStore struct {
+	client *ent.Client
+}
+
+func NewEntStore(client *ent.Client) *EntStore {
+	return &EntStore{client: client}
+}
+
+func (s *EntStore) List(ctx context.Context) ([]Character, error) {
+	rows, err := s.client.Character.Query().All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list characters: %w", err)
+	}
+
+	characters := make([]Character, 0, len(rows))
+	for _, row := range rows {
+		characters = append(characters, fromEnt(row))
+	}
+
+	return characters, nil
+}
+
+func (s *EntStore) Create(ctx context.Context, character Character) error {
+	err := s.client.Character.Create().
+		SetID(character.ID).
+		SetName(character.Name).
+		SetPersona(character.Persona).
+		SetPromptTemplate(character.PromptTemplate).
+		SetChannelIds(character.ChannelIDs).
+		SetTools(character.Tools).
+		Exec(ctx)
+	if ent.IsConstraintError(err) {
+		return fmt.Errorf("%w: %s", ErrExists, character.ID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("create character %s: %w", character.ID, err)
+	}
+
+	return nil
+}
+
+func (s *EntStore) Update(ctx context.Context, character Character) error {
+	err := s.client.Character.UpdateOneID(character.ID).
+		SetName(character.Name).
+		SetPersona(character.Persona).
+		SetPromptTemplate(character.PromptTemplate).
+		SetChannelIds(character.ChannelIDs).
+		SetTools(character.Tools).
+		Exec(ctx)
+	if ent.IsNotFound(err) {
+		return fmt.Errorf("%w: %s", ErrNotFound, character.ID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("update character %s: %w", character.ID, err)
+	}
+
+	return nil
+}
+
+func fromEnt(row *ent.Character) Character {
+	return Character{
+		ID:             row.ID,
+		Name:           row.Name,
+		Persona:        row.Persona,
+		PromptTemplate: row.PromptTemplate,
+		ChannelIDs:     row.ChannelIds,
+		Tools:          row.Tools,
+	}
+}
+
+// MemoryStore keeps characters in memory, for tests and tools that run
+// without a database.
+type MemoryStore struct {
+	mu         sync.Mutex
+	characters map[string]Character
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{mu: sync.Mutex{}, characters: make(map[string]Character)}
+}
+
+func (s *MemoryStore) List(context.Context) ([]Character, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	characters := make([]Character, 0, len(s.characters))
+	for _, id := range slices.Sorted(maps.Keys(s.characters)) {
+		characters = append(characters, s.characters[id].clone())
+	}
+
+	return characters, nil
+}
+
+func (s *MemoryStore) Create(_ context.Context, character Character) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.characters[character.ID]; ok {
+		return fmt.Errorf("%w: %s", ErrExists, character.ID)
+	}
+
+	s.characters[character.ID] = character.clone()
+
+	return nil
+}
+
+func (s *MemoryStore) Update(_ context.Context, character Character) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.characters[character.ID]; !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, character.ID)
+	}
+
+	s.characters[character.ID] = character.clone()
+
+	return nil
+}
@@ -0,0 +1,424 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kizuna-org/akari/internal/attachment"
+	"github.com/kizuna-org/akari/internal/character"
+	"github.com/kizuna-org/akari/internal/conversation"
+	"github.com/kizuna-org/akari/internal/kiseki"
+	"github.com/kizuna-org/akari/internal/llm"
+	"github.com/kizuna-org/akari/internal/memory"
+	"github.com/kizuna-org/akari/internal/ratelimit"
+	"github.com/kizuna-org/akari/internal/settings"
+	"github.com/kizuna-org/akari/internal/tool"
+	"go.uber.org/fx"
+)
+
+const (
+	tiredReply = "Ah... I'm feeling a little tired right now. Let me rest for a moment, and we can talk again soon!"
+
+	describeInstruction = "Describe this image in a sentence or two, plainly, so you can remember what it showed."
+
+	// maxDescribing bounds the image descriptions generated at once over
+	// all replies being remembered.
+	maxDescribing = 2
+)
+
+// Message is an incoming chat message addressed to a character. GuildID
+// is empty for direct messages, and an empty CharacterID addresses the
+// primary character. Attachments are downloaded, within the configured
+// limits, only once the reply is allowed.
+type Message struct {
+	CharacterID string
+	GuildID     string
+	ChannelID   string
+	AuthorID    string
+	AuthorName  string
+	Content     string
+	Attachments []attachment.File
+}
+
+// Progress follows a reply while it is generated.
+type Progress interface {
+	// Generating is called once the reply is allowed and being generated,
+	// before any text is available.
+	Generating()
+	// Partial is called with the reply so far each time the model adds text.
+	Partial(reply string)
+}
+
+type Responder struct {
+	model         llm.Model
+	memory        *memory.Service
+	limiter       *ratelimit.Limiter
+	characters    *character.Registry
+	conversations conversation.Store
+	settings      *settings.Manager
+	tools         *tool.Runner
+	attachments   *attachment.Fetcher
+
+	// giveUp is closed when remembering must give up at shutdown.
+	giveUp      chan struct{}
+	remembering sync.WaitGroup
+	describing  chan struct{}
+}
+
+func NewResponder(
+	model llm.Model,
+	memories *memory.Service,
+	limiter *ratelimit.Limiter,
+	characters *character.Registry,
+	conversations conversation.Store,
+	runtime *settings.Manager,
+	tools *tool.Runner,
+	attachments *attachment.Fetcher,
+) *Responder {
+	return &Responder{
+		model:         model,
+		memory:        memories,
+		limiter:       limiter,
+		characters:    characters,
+		conversations: conversations,
+		settings:      runtime,
+		tools:         tools,
+		attachments:   attachments,
+		giveUp:        make(chan struct{}),
+		remembering:   sync.WaitGroup{},
+		describing:    make(chan struct{}, maxDescribing),
+	}
+}
+
+// RegisterLifecycle waits at shutdown for the turns being remembered to be
+// recorded and memorised, and cancels them once the stop timeout runs out.
+// It is registered before the Discord bots and the API server, so it stops
+// after them, once no new reply can start.
+func RegisterLifecycle(lc fx.Lifecycle, responder *Responder) {
+	lc.Append(fx.Hook{
+		OnStart: nil,
+		OnStop: func(stopCtx context.Context) error {
+			done := make(chan struct{})
+
+			go func() {
+				defer close(done)
+
+				responder.remembering.Wait()
+			}()
+
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				close(responder.giveUp)
+
+				return fmt.Errorf("drain remembered turns: %w", stopCtx.Err())
+			}
+		},
+	})
+}
+
+// Reply recalls what the character knows, generates a reply and records and
+// memorizes the turn in the background. The reply is empty in guild channels the
+// character does not listen to or the runtime settings do not allow. When the
+// sender is rate limited the reply is a short in-character refusal, or empty
+// if they were already told.
+func (r *Responder) Reply(ctx context.Context, msg Message) (string, error) {
+	return r.ReplyStream(ctx, msg, nil)
+}
+
+// ReplyStream replies like Reply, streaming the reply to progress as it is
+// generated. progress may be nil.
+func (r *Responder) ReplyStream(ctx context.Context, msg Message, progress Progress) (string, error) {
+	current, err := r.character(msg.CharacterID)
+	if err != nil {
+		return "", err
+	}
+
+	runtime := r.settings.Current()
+
+	if msg.GuildID != "" && (!current.Listens(msg.ChannelID) || !runtime.AllowsChannel(msg.ChannelID)) {
+		return "", nil
+	}
+
+	decision := r.limiter.Allow(current.ID, msg.AuthorID, msg.ChannelID)
+	if !decision.Allowed {
+		slog.InfoContext(ctx, "reply rate limited",
+			"character_id", current.ID, "scope", decision.Scope, "user_id", msg.AuthorID)
+
+		if decision.Notify {
+			return tiredReply, nil
+		}
+
+		return "", nil
+	}
+
+	if progress != nil {
+		progress.Generating()
+	}
+
+	downloads := r.attachments.Fetch(ctx, msg.Attachments)
+	fragments := r.memory.Recall(ctx, current.ID, msg.Content)
+
+	caller := tool.Caller{
+		CharacterID: current.ID,
+		ChannelID:   msg.ChannelID,
+		UserID:      msg.AuthorID,
+		UserName:    msg.AuthorName,
+	}
+
+	resp, err := r.generate(ctx, caller, llm.Request{
+		System: systemPrompt(ctx, current, fragments),
+		Messages: []llm.Message{
+			{Role: llm.RoleUser, Text: msg.Content, Attachments: inline(downloads), Calls: nil, Results: nil},
+		},
+		Temperature: runtime.Temperature,
+		Tools:       nil,
+	}, progress)
+	if err != nil {
+		return "", fmt.Errorf("generate reply: %w", err)
+	}
+
+	r.remembering.Go(func() {
+		// Remembering outlives the request, keeping its values such as the
+		// trace, until the app stops.
+		ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		defer cancel()
+
+		go func() {
+			select {
+			case <-r.giveUp:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		r.remember(ctx, current.ID, msg, downloads, fragments, resp.Text)
+	})
+
+	return resp.Text, nil
+}
+
+// generate has the model reply with the caller's tools. Each model call
+// streams its text from scratch, so text the model writes before calling a
+// tool is replaced by the next round's rather than prefixed to it.
+func (r *Responder) generate(
+	ctx context.Context,
+	caller tool.Caller,
+	req llm.Request,
+	progress Progress,
+) (llm.Response, error) {
+	if progress == nil {
+		return r.tools.Generate(ctx, caller, req, r.model.Generate)
+	}
+
+	var reply strings.Builder
+
+	return r.tools.Generate(ctx, caller, req, func(ctx context.Context, req llm.Request) (llm.Response, error) {
+		reply.Reset()
+
+		return r.model.Stream(ctx, req, func(text string) {
+			reply.WriteString(text)
+			progress.Partial(reply.String())
+		})
+	})
+}
+
+// remember saves the turn to the conversation history and to kiseki, with
+// a description of each image. Both are best effort: a failure is logged and
+// the reply is still sent.
+func (r *Responder) remember(
+	ctx context.Context,
+	characterID string,
+	msg Message,
+	downloads []attachment.Download,
+	fragments []kiseki.Fragment,
+	reply string,
+) {
+	err := r.conversations.Record(ctx, conversation.Turn{
+		ID:          uuid.Nil,
+		CharacterID: characterID,
+		GuildID:     msg.GuildID,
+		ChannelID:   msg.ChannelID,
+		AuthorID:    msg.AuthorID,
+		AuthorName:  msg.AuthorName,
+		Message:     msg.Content,
+		Attachments: attachmentData(msg.Attachments),
+		Reply:       reply,
+		Memories:    memoryData(fragments),
+		CreatedAt:   time.Time{},
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "record conversation turn failed", "channel_id", msg.ChannelID, "error", err)
+	}
+
+	if !r.memory.Enabled() {
+		return
+	}
+
+	r.memory.Memorize(ctx, memory.Turn{
+		CharacterID: characterID,
+		AuthorID:    msg.AuthorID,
+		AuthorName:  msg.AuthorName,
+		Message:     msg.Content,
+		Images:      r.describe(ctx, downloads),
+		Reply:       reply,
+	})
+}
+
+// describe has the model describe each downloaded image, so what it showed
+// can be memorised as text. At most maxDescribing descriptions are generated
+// at once over all replies. Images it fails to describe are left out.
+func (r *Responder) describe(ctx context.Context, downloads []attachment.Download) []kiseki.ImageRef {
+	var images []kiseki.ImageRef
+
+	for _, download := range downloads {
+		if !strings.HasPrefix(download.MIMEType, "image/") {
+			continue
+		}
+
+		resp, err := r.describeOne(ctx, download)
+		if err != nil {
+			slog.WarnContext(ctx, "describe image failed", "filename", download.Filename, "error", err)
+
+			continue
+		}
+
+		images = append(images, kiseki.ImageRef{
+			URL:         download.URL,
+			MIMEType:    download.MIMEType,
+			Filename:    download.Filename,
+			Description: resp.Text,
+		})
+	}
+
+	return images
+}
+
+func (r *Responder) describeOne(ctx context.Context, download attachment.Download) (llm.Response, error) {
+	select {
+	case r.describing <- struct{}{}:
+	case <-ctx.Done():
+		return llm.Response{}, fmt.Errorf("wait to describe image: %w", ctx.Err())
+	}
+
+	defer func() { <-r.describing }()
+
+	resp, err := r.model.Generate(ctx, llm.Request{
+		System: "",
+		Messages: []llm.Message{{
+			Role:        llm.RoleUser,
+			Text:        describeInstruction,
+			Attachments: inline([]attachment.Download{download}),
+			Calls:       nil,
+			Results:     nil,
+		}},
+		Temperature: nil,
+		Tools:       nil,
+	})
+	if err != nil {
+		return llm.Response{}, fmt.Errorf("describe image: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Speak has a character write a message on its own initiative, such as a
+// reminder, as instruction describes. It is neither rate limited nor
+// recorded as a turn.
+func (r *Responder) Speak(ctx context.Context, characterID string, instruction string) (string, error) {
+	current, err := r.character(characterID)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := r.model.Generate(ctx, llm.Request{
+		System: systemPrompt(ctx, current, nil),
+		Messages: []llm.Message{
+			{Role: llm.RoleUser, Text: instruction, Attachments: nil, Calls: nil, Results: nil},
+		},
+		Temperature: r.settings.Current().Temperature,
+		Tools:       nil,
+	})
+	if err != nil {
+		return "", fmt.Errorf("generate message: %w", err)
+	}
+
+	return resp.Text, nil
+}
+
+// Persona describes a character as it is presented to the model.
+func (r *Responder) Persona(characterID string) (string, error) {
+	current, err := r.character(characterID)
+	if err != nil {
+		return "", err
+	}
+
+	return systemPrompt(context.Background(), current, nil), nil
+}
+
+// character returns the hosted character with the ID, or the primary one
+// when the ID is empty.
+func (r *Responder) character(id string) (character.Character, error) {
+	if id == "" {
+		return r.characters.Primary(), nil
+	}
+
+	current, ok := r.characters.Get(id)
+	if !ok {
+		return character.Character{}, fmt.Errorf("%w: %s", character.ErrNotFound, id)
+	}
+
+	return current, nil
+}
+
+// systemPrompt renders the character's prompt template, falling back to the
+// default template if a saved one fails to execute.
+func systemPrompt(ctx context.Context, current character.Character, fragments []kiseki.Fragment) string {
+	memories := memoryData(fragments)
+
+	prompt, err := current.SystemPrompt(memories)
+	if err == nil {
+		return prompt
+	}
+
+	slog.WarnContext(ctx, "render prompt template failed", "character_id", current.ID, "error", err)
+
+	current.PromptTemplate = ""
+	prompt, _ = current.SystemPrompt(memories)
+
+	return prompt
+}
+
+func inline(downloads []attachment.Download) []llm.Attachment {
+	attachments := make([]llm.Attachment, 0, len(downloads))
+	for _, download := range downloads {
+		attachments = append(attachments, llm.Attachment{MIMEType: download.MIMEType, Data: download.Data})
+	}
+
+	return attachments
+}
+
+func attachmentData(files []attachment.File) []conversation.Attachment {
+	attachments := make([]conversation.Attachment, 0, len(files))
+	for _, file := range files {
+		attachments = append(attachments, conversation.Attachment(file))
+	}
+
+	return attachments
+}
+
+func memoryData(fragments []kiseki.Fragment) []string {
+	memories := make([]string, 0, len(fragments))
+	for _, fragment := range fragments {
+		memories = append(memories, fragment.Text())
+	}
+
+	return memories
+}
@@ -0,0 +1,726 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/attachment"
+	"github.com/kizuna-org/akari/internal/character"
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/conversation"
+	"github.com/kizuna-org/akari/internal/kiseki"
+	"github.com/kizuna-org/akari/internal/llm"
+	"github.com/kizuna-org/akari/internal/memory"
+	"github.com/kizuna-org/akari/internal/ratelimit"
+	"github.com/kizuna-org/akari/internal/settings"
+	"github.com/kizuna-org/akari/internal/tool"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestSystemPrompt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		persona   string
+		template  string
+		fragments []kiseki.Fragment
+		want      string
+	}{
+		{
+			name:      "without memories",
+			fragments: nil,
+			want:      "You are Akari, a friendly companion chatting on Discord.",
+		},
+		{
+			name:      "with persona",
+			persona:   "You love green tea.",
+			fragments: nil,
+			want:      "You are Akari, a friendly companion chatting on Discord.\n\nYou love green tea.",
+		},
+		{
+			name:      "falls back to the default template",
+			template:  "{{.Unknown}}",
+			fragments: nil,
+			want:      "You are Akari, a friendly companion chatting on Discord.",
+		},
+		{
+			name: "with memories",
+			fragments: []kiseki.Fragment{
+				kiseki.TextFragment("Alice: I like tea.", kiseki.Meta{}),
+			},
+			want: "You are Akari, a friendly companion chatting on Discord.\n\n" +
+				"Things you remember:\n- Alice: I like tea.",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			current := character.Character{
+				ID:             "",
+				Name:           "Akari",
+				Persona:        testCase.persona,
+				PromptTemplate: testCase.template,
+				ChannelIDs:     nil,
+			}
+
+			got := systemPrompt(t.Context(), current, testCase.fragments)
+			if got != testCase.want {
+				t.Fatalf("systemPrompt() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func newTestSettings(t *testing.T, cfg config.Config, overrides map[string]string) *settings.Manager {
+	t.Helper()
+
+	manager, err := settings.NewManager(cfg, settings.NewMemoryStore(), nil)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	err = manager.Patch(t.Context(), overrides, nil)
+	if err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+
+	return manager
+}
+
+// newTestTools allows the configured characters their configured tools;
+// the responders under test never edit them.
+func newTestTools(t *testing.T, cfg config.Config, tools ...tool.Tool) *tool.Runner {
+	t.Helper()
+
+	characters := character.NewRegistry(cfg, character.NewMemoryStore())
+
+	runner, err := tool.NewRunner(cfg, prometheus.NewRegistry(), characters, tools)
+	if err != nil {
+		t.Fatalf("NewRunner() error = %v", err)
+	}
+
+	return runner
+}
+
+func newTestFetcher(t *testing.T, cfg config.Config) *attachment.Fetcher {
+	t.Helper()
+
+	fetcher, err := attachment.NewFetcher(cfg, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("NewFetcher() error = %v", err)
+	}
+
+	return fetcher
+}
+
+func TestResponderReply(t *testing.T) {
+	t.Parallel()
+
+	client, err := kiseki.NewClient(config.Config{})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var cfg config.Config
+	cfg.Character.Name = "Akari"
+	cfg.RateLimit.User = config.Bucket{PerMinute: 1, Burst: 1}
+
+	responder := NewResponder(
+		llm.NewFake(),
+		memory.NewService(cfg, client),
+		ratelimit.NewLimiter(cfg),
+		character.NewRegistry(cfg, character.NewMemoryStore()),
+		conversation.NewMemoryStore(),
+		newTestSettings(t, cfg, nil),
+		newTestTools(t, cfg),
+		newTestFetcher(t, cfg),
+	)
+	msg := Message{
+		CharacterID: "",
+		GuildID:     "",
+		ChannelID:   "channel",
+		AuthorID:    "user",
+		AuthorName:  "Alice",
+		Content:     "hello",
+		Attachments: nil,
+	}
+
+	for _, want := range []string{"You said: hello", tiredReply, ""} {
+		got, err := responder.Reply(t.Context(), msg)
+		if err != nil {
+			t.Fatalf("Reply() error = %v", err)
+		}
+
+		if got != want {
+			t.Fatalf("Reply() = %q, want %q", got, want)
+		}
+	}
+}
+
+// blockingStore records turns once release is closed or the context is
+// done, and reports on recorded with what the context said.
+type blockingStore struct {
+	conversation.Store
+
+	release  chan struct{}
+	recorded chan error
+}
+
+func (s *blockingStore) Record(ctx context.Context, turn conversation.Turn) error {
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+	}
+
+	s.recorded <- ctx.Err()
+
+	return s.Store.Record(ctx, turn)
+}
+
+func TestRegisterLifecycle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		release      bool
+		wantStopErr  bool
+		wantRecorded error
+	}{
+		{name: "drains remembered turns", release: true, wantStopErr: false, wantRecorded: nil},
+		{name: "gives up at the stop timeout", release: false, wantStopErr: true, wantRecorded: context.Canceled},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := kiseki.NewClient(config.Config{})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			var cfg config.Config
+			cfg.Character.Name = "Akari"
+			cfg.RateLimit.User = config.Bucket{PerMinute: 1, Burst: 1}
+
+			store := &blockingStore{
+				Store:    conversation.NewMemoryStore(),
+				release:  make(chan struct{}),
+				recorded: make(chan error, 1),
+			}
+			responder := NewResponder(
+				llm.NewFake(),
+				memory.NewService(cfg, client),
+				ratelimit.NewLimiter(cfg),
+				character.NewRegistry(cfg, character.NewMemoryStore()),
+				store,
+				newTestSettings(t, cfg, nil),
+				newTestTools(t, cfg),
+				newTestFetcher(t, cfg),
+			)
+
+			lifecycle := fxtest.NewLifecycle(t)
+			RegisterLifecycle(lifecycle, responder)
+			lifecycle.RequireStart()
+
+			_, err = responder.Reply(t.Context(), Message{
+				CharacterID: "",
+				GuildID:     "",
+				ChannelID:   "channel",
+				AuthorID:    "user",
+				AuthorName:  "Alice",
+				Content:     "hello",
+				Attachments: nil,
+			})
+			if err != nil {
+				t.Fatalf("Reply() error = %v", err)
+			}
+
+			if testCase.release {
+				close(store.release)
+			}
+
+			stopCtx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+			defer cancel()
+
+			err = lifecycle.Stop(stopCtx)
+			if (err != nil) != testCase.wantStopErr {
+				t.Fatalf("Stop() error = %v, want error %v", err, testCase.wantStopErr)
+			}
+
+			if got := <-store.recorded; !errors.Is(got, testCase.wantRecorded) {
+				t.Fatalf("recorded with context error %v, want %v", got, testCase.wantRecorded)
+			}
+		})
+	}
+}
+
+func TestDescribeLimit(t *testing.T) {
+	t.Parallel()
+
+	responder := NewResponder(llm.NewFake(), nil, nil, nil, nil, nil, nil, nil)
+	downloads := []attachment.Download{{
+		File:     attachment.File{Filename: "cat.png", ContentType: "image/png", Size: 3, URL: "https://cdn/cat.png"},
+		MIMEType: "image/png",
+		Data:     []byte("png"),
+	}}
+
+	if got := responder.describe(t.Context(), downloads); len(got) != 1 {
+		t.Fatalf("describe() = %v, want one image", got)
+	}
+
+	for range maxDescribing {
+		responder.describing <- struct{}{}
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	if got := responder.describe(ctx, downloads); len(got) != 0 {
+		t.Fatalf("describe() with %d descriptions running = %v, want none", maxDescribing, got)
+	}
+}
+
+// recorder is a Progress that records what it is told.
+type recorder struct {
+	generating int
+	partials   []string
+}
+
+func (r *recorder) Generating() {
+	r.generating++
+}
+
+func (r *recorder) Partial(reply string) {
+	r.partials = append(r.partials, reply)
+}
+
+func TestResponderReplyStream(t *testing.T) {
+	t.Parallel()
+
+	client, err := kiseki.NewClient(config.Config{})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var cfg config.Config
+	cfg.Character.Name = "Akari"
+	cfg.RateLimit.User = config.Bucket{PerMinute: 1, Burst: 1}
+
+	responder := NewResponder(
+		llm.NewFake(),
+		memory.NewService(cfg, client),
+		ratelimit.NewLimiter(cfg),
+		character.NewRegistry(cfg, character.NewMemoryStore()),
+		conversation.NewMemoryStore(),
+		newTestSettings(t, cfg, nil),
+		newTestTools(t, cfg),
+		newTestFetcher(t, cfg),
+	)
+	msg := Message{
+		CharacterID: "",
+		GuildID:     "",
+		ChannelID:   "channel",
+		AuthorID:    "user",
+		AuthorName:  "Alice",
+		Content:     "hello there",
+		Attachments: nil,
+	}
+
+	progress := new(recorder)
+
+	got, err := responder.ReplyStream(t.Context(), msg, progress)
+	if err != nil {
+		t.Fatalf("ReplyStream() error = %v", err)
+	}
+
+	want := []string{"You ", "You said: ", "You said: hello ", "You said: hello there"}
+	if got != want[len(want)-1] || progress.generating != 1 || !slices.Equal(progress.partials, want) {
+		t.Fatalf("ReplyStream() = %q with progress %+v, want %q", got, progress, want)
+	}
+
+	// A rate limited reply is not generated, so nothing is streamed.
+	progress = new(recorder)
+
+	got, err = responder.ReplyStream(t.Context(), msg, progress)
+	if err != nil {
+		t.Fatalf("ReplyStream() error = %v", err)
+	}
+
+	if got != tiredReply || progress.generating != 0 || progress.partials != nil {
+		t.Fatalf("ReplyStream() = %q with progress %+v, want %q unstreamed", got, progress, tiredReply)
+	}
+}
+
+// preambleModel is the fake model, saying it is about to before it calls a
+// tool.
+type preambleModel struct {
+	*llm.Fake
+}
+
+func (m preambleModel) Stream(ctx context.Context, req llm.Request, onText func(text string)) (llm.Response, error) {
+	resp, err := m.Fake.Stream(ctx, req, onText)
+	if err == nil && len(resp.Calls) > 0 {
+		onText("Let me check.")
+	}
+
+	return resp, err
+}
+
+func TestResponderReplyStreamTools(t *testing.T) {
+	t.Parallel()
+
+	client, err := kiseki.NewClient(config.Config{})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var cfg config.Config
+	cfg.Character = config.Character{ID: "akari", Name: "Akari", Tools: []string{"echo"}}
+
+	echo := tool.Tool{
+		Name:        "echo",
+		Description: "Echo the arguments.",
+		Parameters:  map[string]any{"type": "object"},
+		Timeout:     0,
+		Handler: func(_ context.Context, req tool.Request) (any, error) {
+			return req.Arguments, nil
+		},
+	}
+
+	responder := NewResponder(
+		preambleModel{Fake: llm.NewFake()},
+		memory.NewService(cfg, client),
+		ratelimit.NewLimiter(cfg),
+		character.NewRegistry(cfg, character.NewMemoryStore()),
+		conversation.NewMemoryStore(),
+		newTestSettings(t, cfg, nil),
+		newTestTools(t, cfg, echo),
+		newTestFetcher(t, cfg),
+	)
+	msg := Message{
+		CharacterID: "akari",
+		GuildID:     "",
+		ChannelID:   "channel",
+		AuthorID:    "user",
+		AuthorName:  "Alice",
+		Content:     `use echo {"text":"hi"}`,
+		Attachments: nil,
+	}
+
+	progress := new(recorder)
+
+	got, err := responder.ReplyStream(t.Context(), msg, progress)
+	if err != nil {
+		t.Fatalf("ReplyStream() error = %v", err)
+	}
+
+	// The text before the tool call is replaced by the answer, not kept in
+	// front of it.
+	want := []string{"Let me check.", "echo ", "echo returned ", `echo returned {"text":"hi"}`}
+	if got != want[len(want)-1] || !slices.Equal(progress.partials, want) {
+		t.Fatalf("ReplyStream() = %q with progress %q, want %q", got, progress.partials, want)
+	}
+}
+
+func TestResponderReplyAttachments(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("png"))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := kiseki.NewClient(config.Config{})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var cfg config.Config
+	cfg.Character.Name = "Akari"
+	cfg.RateLimit.User = config.Bucket{PerMinute: 1, Burst: 1}
+	cfg.Attachments = config.Attachments{
+		MIMETypes: []string{"image/png"},
+		MaxBytes:  1024,
+		MaxCount:  1,
+		Timeout:   time.Second,
+	}
+
+	responder := NewResponder(
+		llm.NewFake(),
+		memory.NewService(cfg, client),
+		ratelimit.NewLimiter(cfg),
+		character.NewRegistry(cfg, character.NewMemoryStore()),
+		conversation.NewMemoryStore(),
+		newTestSettings(t, cfg, nil),
+		newTestTools(t, cfg),
+		newTestFetcher(t, cfg),
+	)
+
+	got, err := responder.Reply(t.Context(), Message{
+		CharacterID: "",
+		GuildID:     "",
+		ChannelID:   "channel",
+		AuthorID:    "user",
+		AuthorName:  "Alice",
+		Content:     "look",
+		Attachments: []attachment.File{
+			{Filename: "cat.png", ContentType: "image/png", Size: 3, URL: server.URL + "/cat.png"},
+			{Filename: "notes.txt", ContentType: "text/plain", Size: 3, URL: server.URL + "/notes.txt"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Reply() error = %v", err)
+	}
+
+	if want := "You said: look [image/png]"; got != want {
+		t.Fatalf("Reply() = %q, want %q", got, want)
+	}
+}
+
+func TestResponderReplyCharacters(t *testing.T) {
+	t.Parallel()
+
+	client, err := kiseki.NewClient(config.Config{})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var cfg config.Config
+	cfg.Character = config.Character{ID: "akari", Name: "Akari"}
+	cfg.Characters = []config.Character{{ID: "luna", Name: "Luna"}}
+	cfg.RateLimit.User = config.Bucket{PerMinute: 1, Burst: 1}
+
+	responder := NewResponder(
+		llm.NewFake(),
+		memory.NewService(cfg, client),
+		ratelimit.NewLimiter(cfg),
+		character.NewRegistry(cfg, character.NewMemoryStore()),
+		conversation.NewMemoryStore(),
+		newTestSettings(t, cfg, nil),
+		newTestTools(t, cfg),
+		newTestFetcher(t, cfg),
+	)
+
+	tests := []struct {
+		name        string
+		characterID string
+		want        string
+		wantErr     error
+	}{
+		{name: "primary", characterID: "", want: "You said: hello", wantErr: nil},
+		{name: "primary limited", characterID: "akari", want: tiredReply, wantErr: nil},
+		{name: "hosted character", characterID: "luna", want: "You said: hello", wantErr: nil},
+		{name: "unknown character", characterID: "sol", want: "", wantErr: character.ErrNotFound},
+	}
+
+	// The cases share the limiter, so they run in order.
+	for _, testCase := range tests {
+		got, err := responder.Reply(t.Context(), Message{
+			CharacterID: testCase.characterID,
+			GuildID:     "",
+			ChannelID:   "channel",
+			AuthorID:    "user",
+			AuthorName:  "Alice",
+			Content:     "hello",
+			Attachments: nil,
+		})
+		if !errors.Is(err, testCase.wantErr) {
+			t.Fatalf("%s: Reply() error = %v, want %v", testCase.name, err, testCase.wantErr)
+		}
+
+		if got != testCase.want {
+			t.Fatalf("%s: Reply() = %q, want %q", testCase.name, got, testCase.want)
+		}
+	}
+}
+
+func TestResponderReplyTools(t *testing.T) {
+	t.Parallel()
+
+	client, err := kiseki.NewClient(config.Config{})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var cfg config.Config
+	cfg.Character = config.Character{ID: "akari", Name: "Akari", Tools: []string{"echo"}}
+	cfg.Characters = []config.Character{{ID: "luna", Name: "Luna"}}
+
+	echo := tool.Tool{
+		Name:        "echo",
+		Description: "Echo the arguments.",
+		Parameters:  map[string]any{"type": "object"},
+		Timeout:     0,
+		Handler: func(_ context.Context, req tool.Request) (any, error) {
+			return req.Arguments, nil
+		},
+	}
+
+	responder := NewResponder(
+		llm.NewFake(),
+		memory.NewService(cfg, client),
+		ratelimit.NewLimiter(cfg),
+		character.NewRegistry(cfg, character.NewMemoryStore()),
+		conversation.NewMemoryStore(),
+		newTestSettings(t, cfg, nil),
+		newTestTools(t, cfg, echo),
+		newTestFetcher(t, cfg),
+	)
+
+	tests := []struct {
+		name        string
+		characterID string
+		want        string
+	}{
+		{name: "allowed", characterID: "akari", want: `echo returned {"text":"hi"}`},
+		{name: "not allowed", characterID: "luna", want: `You said: use echo {"text":"hi"}`},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := responder.Reply(t.Context(), Message{
+				CharacterID: testCase.characterID,
+				GuildID:     "",
+				ChannelID:   "channel",
+				AuthorID:    "user",
+				AuthorName:  "Alice",
+				Content:     `use echo {"text":"hi"}`,
+				Attachments: nil,
+			})
+			if err != nil {
+				t.Fatalf("Reply() error = %v", err)
+			}
+
+			if got != testCase.want {
+				t.Fatalf("Reply() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestResponderReplyChannels(t *testing.T) {
+	t.Parallel()
+
+	client, err := kiseki.NewClient(config.Config{})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var cfg config.Config
+	cfg.Character.ID = "akari"
+	cfg.Character.Name = "Akari"
+
+	characters := character.NewRegistry(cfg, character.NewMemoryStore())
+
+	err = characters.Load(t.Context())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	_, err = characters.Update(t.Context(), "akari", func(target *character.Character) {
+		target.ChannelIDs = []string{"allowed", "paused"}
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	responder := NewResponder(
+		llm.NewFake(),
+		memory.NewService(cfg, client),
+		ratelimit.NewLimiter(cfg),
+		characters,
+		conversation.NewMemoryStore(),
+		newTestSettings(t, cfg, map[string]string{settings.KeyAllowedChannels: "allowed,dm"}),
+		newTestTools(t, cfg),
+		newTestFetcher(t, cfg),
+	)
+
+	tests := []struct {
+		name      string
+		guildID   string
+		channelID string
+		want      string
+	}{
+		{name: "listened channel", guildID: "guild", channelID: "allowed", want: "You said: hello"},
+		{name: "other channel", guildID: "guild", channelID: "other", want: ""},
+		{name: "channel not allowed at runtime", guildID: "guild", channelID: "paused", want: ""},
+		{name: "direct message", guildID: "", channelID: "dm", want: "You said: hello"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := responder.Reply(t.Context(), Message{
+				CharacterID: "",
+				GuildID:     testCase.guildID,
+				ChannelID:   testCase.channelID,
+				AuthorID:    "user",
+				AuthorName:  "Alice",
+				Content:     "hello",
+				Attachments: nil,
+			})
+			if err != nil {
+				t.Fatalf("Reply() error = %v", err)
+			}
+
+			if got != testCase.want {
+				t.Fatalf("Reply() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestResponderSpeak(t *testing.T) {
+	t.Parallel()
+
+	client, err := kiseki.NewClient(config.Config{})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var cfg config.Config
+	cfg.Character.Name = "Akari"
+	cfg.RateLimit.User = config.Bucket{PerMinute: 1, Burst: 1}
+
+	responder := NewResponder(
+		llm.NewFake(),
+		memory.NewService(cfg, client),
+		ratelimit.NewLimiter(cfg),
+		character.NewRegistry(cfg, character.NewMemoryStore()),
+		conversation.NewMemoryStore(),
+		newTestSettings(t, cfg, nil),
+		newTestTools(t, cfg),
+		newTestFetcher(t, cfg),
+	)
+
+	// Speaking twice would exceed the rate limit of a reply.
+	for range 2 {
+		got, err := responder.Speak(t.Context(), "", "remind Alice to stretch")
+		if err != nil {
+			t.Fatalf("Speak() error = %v", err)
+		}
+
+		if got != "You said: remind Alice to stretch" {
+			t.Fatalf("Speak() = %q, want the fake model's answer", got)
+		}
+	}
+
+	_, err = responder.Speak(t.Context(), "missing", "hello")
+	if !errors.Is(err, character.ErrNotFound) {
+		t.Fatalf("Speak() as an unknown character error = %v, want %v", err, character.ErrNotFound)
+	}
+}
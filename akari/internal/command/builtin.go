@@ -0,0 +1,158 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kizuna-org/akari/internal/account"
+	"github.com/kizuna-org/akari/internal/chat"
+	"github.com/kizuna-org/akari/internal/kiseki"
+	"github.com/kizuna-org/akari/internal/memory"
+	"github.com/kizuna-org/akari/internal/reminder"
+)
+
+const (
+	optionText = "text"
+	optionWhen = "when"
+	optionCode = "code"
+
+	memoryUnavailable = "My long-term memory isn't available right now."
+)
+
+func NewRemember(memories *memory.Service) Command {
+	return Command{
+		Name:        "remember",
+		Description: "Ask Akari to remember something",
+		Options: []Option{
+			{Name: optionText, Description: "What to remember", Type: OptionString, Required: true},
+		},
+		Deferred:  true,
+		Ephemeral: true,
+		Handler: func(ctx context.Context, req Request) (string, error) {
+			text, _ := req.Options.String(optionText)
+
+			err := memories.Remember(ctx, req.CharacterID, req.UserName+": "+text)
+			if errors.Is(err, kiseki.ErrDisabled) {
+				return memoryUnavailable, nil
+			}
+
+			if err != nil {
+				return "", fmt.Errorf("remember: %w", err)
+			}
+
+			return "Got it, I'll remember that.", nil
+		},
+	}
+}
+
+func NewForget(memories *memory.Service) Command {
+	return Command{
+		Name:        "forget",
+		Description: "Ask Akari to forget something you told her",
+		Options: []Option{
+			{
+				Name:        optionText,
+				Description: "What to forget, as you asked her to remember it",
+				Type:        OptionString,
+				Required:    true,
+			},
+		},
+		Deferred:  true,
+		Ephemeral: true,
+		Handler: func(ctx context.Context, req Request) (string, error) {
+			text, _ := req.Options.String(optionText)
+
+			err := memories.Forget(ctx, req.CharacterID, req.UserName+": "+text)
+			if errors.Is(err, kiseki.ErrDisabled) {
+				return memoryUnavailable, nil
+			}
+
+			if err != nil {
+				return "", fmt.Errorf("forget: %w", err)
+			}
+
+			return "Okay, I've forgotten that.", nil
+		},
+	}
+}
+
+func NewRemind(reminders *reminder.Service) Command {
+	return Command{
+		Name:        "remind",
+		Description: "Ask Akari to remind you of something later",
+		Options: []Option{
+			{Name: optionWhen, Description: "When, such as 30m, 2h or 18:30", Type: OptionString, Required: true},
+			{Name: optionText, Description: "What to remind you of", Type: OptionString, Required: true},
+		},
+		Deferred:  false,
+		Ephemeral: true,
+		Handler: func(ctx context.Context, req Request) (string, error) {
+			when, _ := req.Options.String(optionWhen)
+			text, _ := req.Options.String(optionText)
+
+			at, err := reminders.Schedule(ctx, reminder.Reminder{
+				CharacterID: req.CharacterID,
+				ChannelID:   req.ChannelID,
+				UserID:      req.UserID,
+				Text:        text,
+			}, when)
+
+			switch {
+			case errors.Is(err, reminder.ErrEmpty):
+				return "What should I remind you of?", nil
+			case errors.Is(err, reminder.ErrInvalidTime):
+				return "I couldn't tell when that is. Try something like 30m, 2h, 1d, 18:30 or 2026-12-24 09:00.", nil
+			case errors.Is(err, reminder.ErrPast):
+				return "That time has already passed.", nil
+			case errors.Is(err, reminder.ErrTooFar):
+				return "That's too far ahead for me to keep track of.", nil
+			case err != nil:
+				return "", fmt.Errorf("remind: %w", err)
+			}
+
+			return fmt.Sprintf("Okay, I'll remind you <t:%d:f>.", at.Unix()), nil
+		},
+	}
+}
+
+func NewPersona(responder *chat.Responder) Command {
+	return Command{
+		Name:        "persona",
+		Description: "Show how Akari sees herself",
+		Options:     nil,
+		Deferred:    false,
+		Ephemeral:   true,
+		Handler: func(_ context.Context, req Request) (string, error) {
+			return responder.Persona(req.CharacterID)
+		},
+	}
+}
+
+// NewLink links the Discord user to the akari account that requested the
+// code through the AccountService API.
+func NewLink(linker *account.Linker) Command {
+	return Command{
+		Name:        "link",
+		Description: "Link your Discord account to your akari account",
+		Options: []Option{
+			{Name: optionCode, Description: "The code the akari API gave you", Type: OptionString, Required: true},
+		},
+		Deferred:  false,
+		Ephemeral: true,
+		Handler: func(ctx context.Context, req Request) (string, error) {
+			code, _ := req.Options.String(optionCode)
+
+			subject, err := linker.Link(ctx, code, req.UserID)
+			if errors.Is(err, account.ErrInvalidCode) {
+				return "That code is invalid or has expired. Ask the akari API for a new one.", nil
+			}
+
+			if err != nil {
+				return "", fmt.Errorf("link: %w", err)
+			}
+
+			return fmt.Sprintf("Linked your Discord account to the akari account %q.", subject), nil
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kizuna-org/akari/internal/account"
+)
+
+func TestLink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		code     func(issued string) string
+		want     string
+		wantLink bool
+	}{
+		{
+			name:     "issued code",
+			code:     func(issued string) string { return issued },
+			want:     `Linked your Discord account to the akari account "alice".`,
+			wantLink: true,
+		},
+		{
+			name:     "unknown code",
+			code:     func(string) string { return "AAAAAAAA" },
+			want:     "That code is invalid or has expired.",
+			wantLink: false,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			linker := account.NewLinker(account.NewMemoryStore())
+
+			issued, _, err := linker.CreateCode(t.Context(), "alice")
+			if err != nil {
+				t.Fatalf("CreateCode() error = %v", err)
+			}
+
+			option := new(discordgo.ApplicationCommandInteractionDataOption)
+			option.Name = optionCode
+			option.Type = discordgo.ApplicationCommandOptionString
+			option.Value = testCase.code(issued)
+
+			got, err := NewLink(linker).Handler(t.Context(), Request{
+				CharacterID: testCharacterID,
+				GuildID:     testGuildID,
+				ChannelID:   "general",
+				UserID:      "user",
+				UserName:    "alice",
+				Options:     newOptions([]*discordgo.ApplicationCommandInteractionDataOption{option}),
+			})
+			if err != nil || !strings.HasPrefix(got, testCase.want) {
+				t.Fatalf("Handler() = %q, %v, want %q", got, err, testCase.want)
+			}
+
+			userID, err := linker.DiscordUser(t.Context(), "alice")
+			if linked := err == nil && userID == "user"; linked != testCase.wantLink {
+				t.Fatalf("DiscordUser() = %q, %v, want linked %v", userID, err, testCase.wantLink)
+			}
+
+			if !testCase.wantLink && !errors.Is(err, account.ErrNotLinked) {
+				t.Fatalf("DiscordUser() error = %v, want %v", err, account.ErrNotLinked)
+			}
+		})
+	}
+}
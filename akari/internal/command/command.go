@@ -0,0 +1,118 @@
+package command
+
+import (
+	"context"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	OptionString  OptionType = OptionType(discordgo.ApplicationCommandOptionString)
+	OptionInteger OptionType = OptionType(discordgo.ApplicationCommandOptionInteger)
+	OptionBoolean OptionType = OptionType(discordgo.ApplicationCommandOptionBoolean)
+	OptionUser    OptionType = OptionType(discordgo.ApplicationCommandOptionUser)
+)
+
+type OptionType discordgo.ApplicationCommandOptionType
+
+type Option struct {
+	Name        string
+	Description string
+	Type        OptionType
+	Required    bool
+}
+
+// Command is a slash command declared in Go. Deferred commands acknowledge
+// the interaction first and edit the reply once Handler returns, which lets
+// them take longer than Discord's three-second response window.
+type Command struct {
+	Name        string
+	Description string
+	Options     []Option
+	Deferred    bool
+	Ephemeral   bool
+	Handler     Handler
+}
+
+type Handler func(ctx context.Context, req Request) (string, error)
+
+// Request is an invoked command. CharacterID is the character the bot
+// replies as in the channel.
+type Request struct {
+	CharacterID string
+	GuildID     string
+	ChannelID   string
+	UserID      string
+	UserName    string
+	Options     Options
+}
+
+// Options gives typed access to the values a user passed to a command.
+type Options map[string]*discordgo.ApplicationCommandInteractionDataOption
+
+func (o Options) String(name string) (string, bool) {
+	option, ok := o[name]
+	if !ok || option.Type != discordgo.ApplicationCommandOptionString {
+		return "", false
+	}
+
+	return option.StringValue(), true
+}
+
+func (o Options) Integer(name string) (int64, bool) {
+	option, ok := o[name]
+	if !ok || option.Type != discordgo.ApplicationCommandOptionInteger {
+		return 0, false
+	}
+
+	return option.IntValue(), true
+}
+
+func (o Options) Boolean(name string) (bool, bool) {
+	option, ok := o[name]
+	if !ok || option.Type != discordgo.ApplicationCommandOptionBoolean {
+		return false, false
+	}
+
+	return option.BoolValue(), true
+}
+
+// User returns the ID of a user option.
+func (o Options) User(name string) (string, bool) {
+	option, ok := o[name]
+	if !ok || option.Type != discordgo.ApplicationCommandOptionUser {
+		return "", false
+	}
+
+	userID, ok := option.Value.(string)
+
+	return userID, ok
+}
+
+func (c Command) definition() *discordgo.ApplicationCommand {
+	definition := new(discordgo.ApplicationCommand)
+	definition.Type = discordgo.ChatApplicationCommand
+	definition.Name = c.Name
+	definition.Description = c.Description
+	definition.Options = make([]*discordgo.ApplicationCommandOption, 0, len(c.Options))
+
+	for _, option := range c.Options {
+		definitionOption := new(discordgo.ApplicationCommandOption)
+		definitionOption.Type = discordgo.ApplicationCommandOptionType(option.Type)
+		definitionOption.Name = option.Name
+		definitionOption.Description = option.Description
+		definitionOption.Required = option.Required
+		definition.Options = append(definition.Options, definitionOption)
+	}
+
+	return definition
+}
+
+func newOptions(options []*discordgo.ApplicationCommandInteractionDataOption) Options {
+	values := make(Options, len(options))
+	for _, option := range options {
+		values[option.Name] = option
+	}
+
+	return values
+}
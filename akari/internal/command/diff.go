@@ -0,0 +1,43 @@
+package command
+
+import "github.com/bwmarrin/discordgo"
+
+// equalDefinitions compares the parts of command definitions that we declare,
+// ignoring server-assigned fields such as IDs and versions.
+func equalDefinitions(registered, desired []*discordgo.ApplicationCommand) bool {
+	if len(registered) != len(desired) {
+		return false
+	}
+
+	byName := make(map[string]*discordgo.ApplicationCommand, len(registered))
+	for _, command := range registered {
+		byName[command.Name] = command
+	}
+
+	for _, want := range desired {
+		got, ok := byName[want.Name]
+		if !ok || !equalDefinition(got, want) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalDefinition(got, want *discordgo.ApplicationCommand) bool {
+	if got.Description != want.Description || len(got.Options) != len(want.Options) {
+		return false
+	}
+
+	for i, option := range want.Options {
+		registered := got.Options[i]
+		if registered.Name != option.Name ||
+			registered.Description != option.Description ||
+			registered.Type != option.Type ||
+			registered.Required != option.Required {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,238 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/discord"
+	"github.com/kizuna-org/akari/internal/logging"
+	"go.uber.org/fx"
+)
+
+const (
+	handlerTimeout = 10 * time.Minute
+	failureMessage = "Sorry, something went wrong. Please try again later."
+)
+
+var ErrUnknownCommand = errors.New("unknown command")
+
+// Session is the subset of *discordgo.Session used by the router.
+type Session interface {
+	ApplicationCommands(
+		appID, guildID string, options ...discordgo.RequestOption,
+	) ([]*discordgo.ApplicationCommand, error)
+	ApplicationCommandBulkOverwrite(
+		appID, guildID string, commands []*discordgo.ApplicationCommand, options ...discordgo.RequestOption,
+	) ([]*discordgo.ApplicationCommand, error)
+	InteractionRespond(
+		interaction *discordgo.Interaction, resp *discordgo.InteractionResponse, options ...discordgo.RequestOption,
+	) error
+	InteractionResponseEdit(
+		interaction *discordgo.Interaction, edit *discordgo.WebhookEdit, options ...discordgo.RequestOption,
+	) (*discordgo.Message, error)
+}
+
+type Params struct {
+	fx.In
+
+	Config   config.Config
+	Bots     *discord.Bots
+	Commands []Command `group:"commands"`
+}
+
+// Router keeps the slash commands registered on one Discord application in
+// sync with the ones declared in Go and dispatches interactions to their
+// handlers on behalf of the character the bot routes the channel to.
+type Router struct {
+	session  Session
+	guildID  string
+	commands map[string]Command
+	route    func(channelID string) string
+	appID    func() string
+}
+
+// NewRouters creates a router for every enabled bot, since each bot is a
+// separate application with commands of its own.
+func NewRouters(params Params) []*Router {
+	bots := params.Bots.Enabled()
+	routers := make([]*Router, 0, len(bots))
+
+	for _, bot := range bots {
+		session := bot.Session()
+		router := newRouter(session, params.Config.Discord.GuildID, params.Commands, bot.Route)
+		router.appID = func() string { return session.State.User.ID }
+
+		session.AddHandler(func(_ *discordgo.Session, event *discordgo.InteractionCreate) {
+			router.Handle(logging.WithRequestID(context.Background(), event.ID), event.Interaction)
+		})
+
+		routers = append(routers, router)
+	}
+
+	return routers
+}
+
+func newRouter(session Session, guildID string, commands []Command, route func(channelID string) string) *Router {
+	router := &Router{
+		session:  session,
+		guildID:  guildID,
+		commands: make(map[string]Command, len(commands)),
+		route:    route,
+		appID:    nil,
+	}
+	for _, command := range commands {
+		router.commands[command.Name] = command
+	}
+
+	return router
+}
+
+func RegisterLifecycle(lc fx.Lifecycle, routers []*Router) {
+	for _, router := range routers {
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				return router.Sync(router.appID())
+			},
+			OnStop: nil,
+		})
+	}
+}
+
+// Sync registers the declared commands for appID, skipping the request when
+// Discord already has identical definitions.
+func (r *Router) Sync(appID string) error {
+	registered, err := r.session.ApplicationCommands(appID, r.guildID)
+	if err != nil {
+		return fmt.Errorf("list application commands: %w", err)
+	}
+
+	desired := make([]*discordgo.ApplicationCommand, 0, len(r.commands))
+	for _, command := range r.commands {
+		desired = append(desired, command.definition())
+	}
+
+	if equalDefinitions(registered, desired) {
+		slog.Info("application commands up to date", "count", len(desired))
+
+		return nil
+	}
+
+	_, err = r.session.ApplicationCommandBulkOverwrite(appID, r.guildID, desired)
+	if err != nil {
+		return fmt.Errorf("overwrite application commands: %w", err)
+	}
+
+	slog.Info("application commands registered", "count", len(desired))
+
+	return nil
+}
+
+func (r *Router) Handle(ctx context.Context, interaction *discordgo.Interaction) {
+	if interaction.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
+	data := interaction.ApplicationCommandData()
+
+	command, ok := r.commands[data.Name]
+	if !ok {
+		slog.WarnContext(ctx, "interaction for unknown command", "command", data.Name, "error", ErrUnknownCommand)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, handlerTimeout)
+	defer cancel()
+
+	if command.Deferred {
+		r.handleDeferred(ctx, command, interaction, r.newRequest(interaction, data))
+
+		return
+	}
+
+	content, err := command.Handler(ctx, r.newRequest(interaction, data))
+	if err != nil {
+		slog.ErrorContext(ctx, "command failed", "command", command.Name, "error", err)
+
+		content = failureMessage
+	}
+
+	err = r.session.InteractionRespond(interaction, response(
+		discordgo.InteractionResponseChannelMessageWithSource, content, command.Ephemeral,
+	))
+	if err != nil {
+		slog.ErrorContext(ctx, "respond to interaction failed", "command", command.Name, "error", err)
+	}
+}
+
+func (r *Router) handleDeferred(ctx context.Context, command Command, interaction *discordgo.Interaction, req Request) {
+	err := r.session.InteractionRespond(interaction, response(
+		discordgo.InteractionResponseDeferredChannelMessageWithSource, "", command.Ephemeral,
+	))
+	if err != nil {
+		slog.ErrorContext(ctx, "defer interaction failed", "command", command.Name, "error", err)
+
+		return
+	}
+
+	content, err := command.Handler(ctx, req)
+	if err != nil {
+		slog.ErrorContext(ctx, "command failed", "command", command.Name, "error", err)
+
+		content = failureMessage
+	}
+
+	edit := new(discordgo.WebhookEdit)
+	edit.Content = &content
+
+	_, err = r.session.InteractionResponseEdit(interaction, edit)
+	if err != nil {
+		slog.ErrorContext(ctx, "edit interaction response failed", "command", command.Name, "error", err)
+	}
+}
+
+func (r *Router) newRequest(
+	interaction *discordgo.Interaction,
+	data discordgo.ApplicationCommandInteractionData,
+) Request {
+	user := interaction.User
+	if interaction.Member != nil && interaction.Member.User != nil {
+		user = interaction.Member.User
+	}
+
+	req := Request{
+		CharacterID: r.route(interaction.ChannelID),
+		GuildID:     interaction.GuildID,
+		ChannelID:   interaction.ChannelID,
+		UserID:      "",
+		UserName:    "",
+		Options:     newOptions(data.Options),
+	}
+
+	if user != nil {
+		req.UserID = user.ID
+		req.UserName = user.DisplayName()
+	}
+
+	return req
+}
+
+func response(kind discordgo.InteractionResponseType, content string, ephemeral bool) *discordgo.InteractionResponse {
+	data := new(discordgo.InteractionResponseData)
+	data.Content = content
+
+	if ephemeral {
+		data.Flags = discordgo.MessageFlagsEphemeral
+	}
+
+	resp := new(discordgo.InteractionResponse)
+	resp.Type = kind
+	resp.Data = data
+
+	return resp
+}
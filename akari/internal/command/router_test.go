@@ -0,0 +1,216 @@
+package command
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	testAppID       = "app"
+	testGuildID     = "guild"
+	testCharacterID = "akari"
+)
+
+func testRoute(string) string {
+	return testCharacterID
+}
+
+type fakeSession struct {
+	mu         sync.Mutex
+	registered []*discordgo.ApplicationCommand
+	overwrites int
+	calls      []string
+}
+
+func (s *fakeSession) ApplicationCommands(
+	string, string, ...discordgo.RequestOption,
+) ([]*discordgo.ApplicationCommand, error) {
+	return s.registered, nil
+}
+
+func (s *fakeSession) ApplicationCommandBulkOverwrite(
+	_ string, _ string, commands []*discordgo.ApplicationCommand, _ ...discordgo.RequestOption,
+) ([]*discordgo.ApplicationCommand, error) {
+	s.overwrites++
+	s.registered = commands
+
+	return commands, nil
+}
+
+func (s *fakeSession) InteractionRespond(
+	_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, "respond:"+resp.Data.Content)
+
+	return nil
+}
+
+func (s *fakeSession) InteractionResponseEdit(
+	_ *discordgo.Interaction, edit *discordgo.WebhookEdit, _ ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, "edit:"+*edit.Content)
+
+	return new(discordgo.Message), nil
+}
+
+func echoCommand(deferred bool) Command {
+	return Command{
+		Name:        "echo",
+		Description: "Echo text",
+		Options: []Option{
+			{Name: "text", Description: "Text to echo", Type: OptionString, Required: true},
+		},
+		Deferred:  deferred,
+		Ephemeral: false,
+		Handler: func(_ context.Context, req Request) (string, error) {
+			text, _ := req.Options.String("text")
+
+			return text, nil
+		},
+	}
+}
+
+func echoInteraction() *discordgo.Interaction {
+	option := new(discordgo.ApplicationCommandInteractionDataOption)
+	option.Name = "text"
+	option.Type = discordgo.ApplicationCommandOptionString
+	option.Value = "hello"
+
+	interaction := new(discordgo.Interaction)
+	interaction.Type = discordgo.InteractionApplicationCommand
+	interaction.Data = discordgo.ApplicationCommandInteractionData{
+		ID:          "",
+		Name:        "echo",
+		CommandType: discordgo.ChatApplicationCommand,
+		Resolved:    nil,
+		Options:     []*discordgo.ApplicationCommandInteractionDataOption{option},
+		TargetID:    "",
+	}
+	interaction.User = &discordgo.User{ID: "user", Username: "alice"}
+
+	return interaction
+}
+
+func TestRouterSync(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		registered []*discordgo.ApplicationCommand
+		want       int
+	}{
+		{name: "registers new commands", registered: nil, want: 1},
+		{
+			name:       "skips identical commands",
+			registered: []*discordgo.ApplicationCommand{echoCommand(false).definition()},
+			want:       0,
+		},
+		{
+			name: "overwrites changed commands",
+			registered: []*discordgo.ApplicationCommand{
+				{Name: "echo", Description: "Old description", Options: nil},
+			},
+			want: 1,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			session := &fakeSession{mu: sync.Mutex{}, registered: testCase.registered, overwrites: 0, calls: nil}
+			router := newRouter(session, testGuildID, []Command{echoCommand(false)}, testRoute)
+
+			err := router.Sync(testAppID)
+			if err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+
+			if session.overwrites != testCase.want {
+				t.Fatalf("overwrites = %d, want %d", session.overwrites, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRouterHandle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		deferred bool
+		want     []string
+	}{
+		{name: "responds immediately", deferred: false, want: []string{"respond:hello"}},
+		{name: "defers then edits", deferred: true, want: []string{"respond:", "edit:hello"}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			session := &fakeSession{mu: sync.Mutex{}, registered: nil, overwrites: 0, calls: nil}
+			router := newRouter(session, testGuildID, []Command{echoCommand(testCase.deferred)}, testRoute)
+
+			router.Handle(t.Context(), echoInteraction())
+
+			if !slices.Equal(session.calls, testCase.want) {
+				t.Fatalf("session calls = %q, want %q", session.calls, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRouterRequestCharacter(t *testing.T) {
+	t.Parallel()
+
+	var got Request
+
+	command := echoCommand(false)
+	command.Handler = func(_ context.Context, req Request) (string, error) {
+		got = req
+
+		return "", nil
+	}
+
+	router := newRouter(new(fakeSession), testGuildID, []Command{command}, func(channelID string) string {
+		return "luna:" + channelID
+	})
+
+	interaction := echoInteraction()
+	interaction.ChannelID = "night"
+
+	router.Handle(t.Context(), interaction)
+
+	if got.CharacterID != "luna:night" {
+		t.Fatalf("Request.CharacterID = %q, want the routed character", got.CharacterID)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	options := newOptions(echoInteraction().ApplicationCommandData().Options)
+
+	if got, ok := options.String("text"); !ok || got != "hello" {
+		t.Fatalf("String() = %q, %v", got, ok)
+	}
+
+	if _, ok := options.Integer("text"); ok {
+		t.Fatal("Integer() ok = true for a string option")
+	}
+
+	if _, ok := options.String("missing"); ok {
+		t.Fatal("String() ok = true for a missing option")
+	}
+}
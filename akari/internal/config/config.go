@@ -4,19 +4,44 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 const (
-	envTest       = "test"
-	envProduction = "production"
+	envDevelopment = "development"
+	envLocal       = "local"
+	envTest        = "test"
+	envProduction  = "production"
 )
 
 type Config struct {
+	// Env is the deployment environment: development (or local, as the
+	// Makefile sets it), test or production.
+	Env      string
 	Addr     string
 	Database Database
+	Discord  Discord
+	LLM      LLM
+	// Attachments limits the files of Discord messages shown to the model.
+	Attachments Attachments
+	Kiseki      Kiseki
+	Character   Character
+	// Characters are hosted alongside Character, as listed in the
+	// AKARI_CONFIG file.
+	Characters []Character
+	RateLimit  RateLimit
+	Auth       Auth
+	Log        Log
+	Tracing    Tracing
+	Secrets    Secrets
+	Runtime    Runtime
+	// File is the AKARI_CONFIG file read beneath the environment, if any.
+	File string
 }
 
 type Database struct {
@@ -28,25 +53,235 @@ type Database struct {
 	SSLMode  string
 }
 
+type Discord struct {
+	Token   string
+	GuildID string
+}
+
+type LLM struct {
+	ProjectID string
+	Location  string
+	ModelName string
+}
+
+// Attachments limits the files downloaded from Discord messages: only files
+// of MIMETypes, at most MaxCount per message and MaxBytes each, fetched
+// within Timeout. A MaxCount of zero ignores attachments.
+type Attachments struct {
+	MIMETypes []string
+	MaxBytes  int
+	MaxCount  int
+	Timeout   time.Duration
+}
+
+type Kiseki struct {
+	URL     string
+	Timeout time.Duration
+}
+
+// Character is a hosted character. One listed in the AKARI_CONFIG file may
+// log in as a Discord bot of its own, with the token held by the secret
+// named TokenSecret, and may have its own RateLimit; otherwise it shares the
+// DISCORD_TOKEN bot and the RATE_LIMIT_* limits. Tools lists the tools the
+// character's model may call.
+type Character struct {
+	ID            string
+	Name          string
+	SleepSchedule string
+	Timezone      string
+	Tools         []string
+	TokenSecret   string
+	Token         string
+	RateLimit     *RateLimit
+}
+
+// RateLimit holds token-bucket limits for LLM-backed replies. A bucket with
+// PerMinute set to zero is unlimited.
+type RateLimit struct {
+	User    Bucket
+	Channel Bucket
+	Global  Bucket
+}
+
+type Bucket struct {
+	PerMinute int
+	Burst     int
+}
+
+// Auth configures bearer authentication for the Connect API. APIKeys is a
+// comma-separated list of name:role:key entries. JWTs are accepted when
+// JWKSFile is set and must be issued by JWTIssuer.
+type Auth struct {
+	APIKeys     string
+	JWTIssuer   string
+	JWTAudience string
+	JWKSFile    string
+}
+
+// Log configures the process logger. Level is a slog level name such as
+// "debug" or "warn" and Format is "text" or "json".
+type Log struct {
+	Level     string
+	Format    string
+	AddSource bool
+}
+
+// Tracing configures OpenTelemetry trace export. Exporter is "none", "otlp"
+// (OTLP over HTTP to Endpoint) or "console" (JSON to File, or stdout when
+// File is empty). SampleRatio applies to traces started by akari.
+type Tracing struct {
+	Exporter    string
+	Endpoint    string
+	File        string
+	SampleRatio float64
+	ServiceName string
+}
+
+// Secrets configures where rotating secrets are fetched from. Provider is
+// "none" (use the values read at startup), "file" (one file per secret in
+// Dir) or "gcp" (Google Secret Manager in ProjectID, or a compatible API at
+// Endpoint). Secrets are fetched again every RefreshInterval.
+type Secrets struct {
+	Provider        string
+	Dir             string
+	ProjectID       string
+	Endpoint        string
+	RefreshInterval time.Duration
+}
+
+// Runtime locates the optional JSON file of runtime settings, which is
+// layered over the environment and re-read while akari runs.
+type Runtime struct {
+	File string
+}
+
+// Load reads the configuration from the environment and validates it,
+// reporting every problem at once.
 func Load() (Config, error) {
+	cfg, file, err := read()
+
+	// A value that failed to parse is zero, so validating it again would only
+	// repeat the problem.
+	unparsed := FieldErrors(err)
+	problems := unparsed
+
+	for _, problem := range FieldErrors(cfg.Validate()) {
+		if !slices.ContainsFunc(unparsed, func(parse *FieldError) bool { return parse.Key == problem.Key }) {
+			problem.Location = resolveLocation(file, problem)
+			problems = append(problems, problem)
+		}
+	}
+
+	if len(problems) > 0 {
+		return Config{}, joinFieldErrors(problems)
+	}
+
+	return cfg, nil
+}
+
+// Read reads the configuration without validating it. Each setting comes
+// from its environment variable, or else from the AKARI_CONFIG file, or else
+// its default. Values that fail to parse are left zero and reported together.
+func Read() (Config, error) {
+	cfg, _, err := read()
+
+	return cfg, err
+}
+
+func read() (Config, configFile, error) {
 	_ = godotenv.Load(envFile())
 
-	port, err := strconv.Atoi(getenv("POSTGRES_PORT", "5432"))
-	if err != nil {
-		return Config{}, fmt.Errorf("parse POSTGRES_PORT: %w", err)
+	var env reader
+
+	path := os.Getenv(fileKey)
+	if path != "" {
+		env.file, env.errs = readFile(path)
 	}
 
-	return Config{
-		Addr: getenv("AKARI_ADDR", ":8080"),
+	cfg := Config{
+		Env:  env.get("ENV", envDevelopment),
+		Addr: env.get("AKARI_ADDR", ":8080"),
 		Database: Database{
-			Host:     getenv("POSTGRES_HOST", "localhost"),
-			Port:     port,
-			User:     getenv("POSTGRES_USER", "postgres"),
-			Password: getenv("POSTGRES_PASSWORD", "postgres"),
-			Name:     getenv("POSTGRES_DB", "akari"),
-			SSLMode:  getenv("POSTGRES_SSLMODE", "disable"),
+			Host:     env.get("POSTGRES_HOST", "localhost"),
+			Port:     env.int("POSTGRES_PORT", "5432"),
+			User:     env.get("POSTGRES_USER", "postgres"),
+			Password: env.secret("POSTGRES_PASSWORD", "postgres"),
+			Name:     env.get("POSTGRES_DB", "akari"),
+			SSLMode:  env.get("POSTGRES_SSLMODE", "disable"),
+		},
+		Discord: Discord{
+			Token:   env.secret("DISCORD_TOKEN", ""),
+			GuildID: env.get("DISCORD_GUILD_ID", ""),
+		},
+		LLM: LLM{
+			ProjectID: env.get("LLM_PROJECT_ID", ""),
+			Location:  env.get("LLM_LOCATION", "us-central1"),
+			ModelName: env.get("LLM_MODEL_NAME", "gemini-2.5-flash"),
+		},
+		Attachments: Attachments{
+			MIMETypes: list(env.get("ATTACHMENTS_MIME_TYPES", "image/png,image/jpeg,image/webp")),
+			MaxBytes:  env.int("ATTACHMENTS_MAX_BYTES", "5242880"),
+			MaxCount:  env.int("ATTACHMENTS_MAX_COUNT", "4"),
+			Timeout:   env.duration("ATTACHMENTS_TIMEOUT", "10s"),
+		},
+		Kiseki: Kiseki{
+			URL:     env.get("KISEKI_URL", ""),
+			Timeout: env.duration("KISEKI_TIMEOUT", "5s"),
+		},
+		Character: Character{
+			ID:            env.get("CHARACTER_ID", ""),
+			Name:          env.get("CHARACTER_NAME", "Akari"),
+			SleepSchedule: env.get("CHARACTER_SLEEP_SCHEDULE", ""),
+			Timezone:      env.get("CHARACTER_TIMEZONE", "UTC"),
+			Tools:         list(env.get("CHARACTER_TOOLS", "")),
+			TokenSecret:   "",
+			Token:         "",
+			RateLimit:     nil,
+		},
+		Characters: nil,
+		RateLimit: RateLimit{
+			User:    env.bucket("RATE_LIMIT_USER", "6", "3"),
+			Channel: env.bucket("RATE_LIMIT_CHANNEL", "20", "10"),
+			Global:  env.bucket("RATE_LIMIT_GLOBAL", "60", "20"),
 		},
-	}, nil
+		Auth: Auth{
+			APIKeys:     env.secret("AUTH_API_KEYS", ""),
+			JWTIssuer:   env.get("AUTH_JWT_ISSUER", ""),
+			JWTAudience: env.get("AUTH_JWT_AUDIENCE", ""),
+			JWKSFile:    env.get("AUTH_JWKS_FILE", ""),
+		},
+		Log: Log{
+			Level:     env.get("LOG_LEVEL", "info"),
+			Format:    env.get("LOG_FORMAT", "text"),
+			AddSource: env.bool("LOG_SOURCE", "false"),
+		},
+		Tracing: Tracing{
+			Exporter:    env.get("OTEL_TRACES_EXPORTER", "none"),
+			Endpoint:    env.get("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+			File:        env.get("OTEL_TRACES_FILE", ""),
+			SampleRatio: env.float("OTEL_TRACES_SAMPLER_ARG", "1"),
+			ServiceName: env.get("OTEL_SERVICE_NAME", "akari"),
+		},
+		Secrets: Secrets{
+			Provider:        env.get("SECRETS_PROVIDER", "none"),
+			Dir:             env.get("SECRETS_DIR", ""),
+			ProjectID:       env.get("SECRETS_PROJECT_ID", ""),
+			Endpoint:        env.get("SECRETS_ENDPOINT", "https://secretmanager.googleapis.com"),
+			RefreshInterval: env.duration("SECRETS_REFRESH_INTERVAL", "5m"),
+		},
+		Runtime: Runtime{
+			File: env.get("RUNTIME_CONFIG_FILE", ""),
+		},
+		File: path,
+	}
+
+	cfg.Characters = env.characters(cfg.RateLimit)
+
+	if len(env.errs) > 0 {
+		return cfg, env.file, joinFieldErrors(env.errs)
+	}
+
+	return cfg, env.file, nil
 }
 
 func (d Database) DSN() string {
@@ -72,6 +307,118 @@ func (d Database) URL() string {
 	)
 }
 
+// reader parses typed environment variables, falling back to the config
+// file, and collects every failure instead of stopping at the first.
+type reader struct {
+	file configFile
+	errs []*FieldError
+}
+
+func (r *reader) get(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	if value, ok := r.file.values[key]; ok {
+		return value.value
+	}
+
+	return fallback
+}
+
+func (r *reader) int(key string, fallback string) int {
+	value, err := strconv.Atoi(r.get(key, fallback))
+	if err != nil {
+		r.fail(key, err)
+	}
+
+	return value
+}
+
+func (r *reader) bool(key string, fallback string) bool {
+	value, err := strconv.ParseBool(r.get(key, fallback))
+	if err != nil {
+		r.fail(key, err)
+	}
+
+	return value
+}
+
+func (r *reader) float(key string, fallback string) float64 {
+	value, err := strconv.ParseFloat(r.get(key, fallback), 64)
+	if err != nil {
+		r.fail(key, err)
+	}
+
+	return value
+}
+
+func (r *reader) duration(key string, fallback string) time.Duration {
+	value, err := time.ParseDuration(r.get(key, fallback))
+	if err != nil {
+		r.fail(key, err)
+	}
+
+	return value
+}
+
+// secret reads key from the file named by key_FILE when that is set, as
+// Docker and Kubernetes secrets are mounted, and from key otherwise.
+func (r *reader) secret(key string, fallback string) string {
+	path := os.Getenv(key + "_FILE")
+	if path == "" {
+		return getenv(key, fallback)
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- the path is operator configuration.
+	if err != nil {
+		r.fail(key+"_FILE", err)
+
+		return ""
+	}
+
+	return strings.TrimRight(string(data), "\r\n")
+}
+
+func (r *reader) bucket(prefix string, perMinute string, burst string) Bucket {
+	return Bucket{
+		PerMinute: r.int(prefix+"_PER_MINUTE", perMinute),
+		Burst:     r.int(prefix+"_BURST", burst),
+	}
+}
+
+func (r *reader) fail(key string, err error) {
+	r.errs = append(r.errs, &FieldError{
+		Key:      key,
+		Location: locate(r.file, key),
+		Err:      fmt.Errorf("%w: %w", ErrInvalid, err),
+	})
+}
+
+// resolveLocation places a validation problem in the config file. Problems
+// with listed characters already name their setting.
+func resolveLocation(file configFile, problem *FieldError) string {
+	if problem.Location == "" {
+		return locate(file, problem.Key)
+	}
+
+	if value, ok := file.values[problem.Location]; ok {
+		return value.location
+	}
+
+	return problem.Location
+}
+
+// locate returns where in the config file the value of key came from, or ""
+// if it came from the environment or a default.
+func locate(file configFile, key string) string {
+	if os.Getenv(key) != "" {
+		return ""
+	}
+
+	return file.values[key].location
+}
+
 func envFile() string {
 	if os.Getenv("ENV") == envTest {
 		return ".env.test"
@@ -84,6 +431,19 @@ func envFile() string {
 	return ".env"
 }
 
+// list splits a comma-separated setting, dropping empty entries.
+func list(value string) []string {
+	var entries []string
+
+	for entry := range strings.SplitSeq(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
 func getenv(key string, fallback string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
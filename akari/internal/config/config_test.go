@@ -2,12 +2,18 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 const (
 	testAddr        = ":8080"
 	testDatabase    = "akari"
+	testDevelopment = "development"
 	testFallback    = "fallback"
 	testHost        = "localhost"
 	testPassword    = "secret"
@@ -18,6 +24,12 @@ const (
 	testUser        = "postgres"
 	testValue       = "value"
 	testEnvironment = "test"
+	testLocation    = "us-central1"
+	testModelName   = "gemini-2.5-flash"
+	testCharacter   = "Akari"
+	testTimeout     = 5 * time.Second
+
+	testSecretsEndpoint = "https://secretmanager.googleapis.com"
 )
 
 func TestDatabaseURL(t *testing.T) {
@@ -98,6 +110,7 @@ func TestLoad(t *testing.T) {
 			env:     nil,
 			wantErr: false,
 			want: Config{
+				Env:  testDevelopment,
 				Addr: testAddr,
 				Database: Database{
 					Host:     testHost,
@@ -107,21 +120,103 @@ func TestLoad(t *testing.T) {
 					Name:     testDatabase,
 					SSLMode:  testSSLMode,
 				},
+				Discord: Discord{Token: "", GuildID: ""},
+				LLM: LLM{
+					ProjectID: "",
+					Location:  testLocation,
+					ModelName: testModelName,
+				},
+				Attachments: Attachments{
+					MIMETypes: []string{"image/png", "image/jpeg", "image/webp"},
+					MaxBytes:  5 << 20,
+					MaxCount:  4,
+					Timeout:   10 * time.Second,
+				},
+				Kiseki: Kiseki{URL: "", Timeout: testTimeout},
+				Character: Character{
+					ID:            "",
+					Name:          testCharacter,
+					SleepSchedule: "",
+					Timezone:      "UTC",
+					Tools:         nil,
+					TokenSecret:   "",
+					Token:         "",
+					RateLimit:     nil,
+				},
+				Characters: nil,
+				RateLimit: RateLimit{
+					User:    Bucket{PerMinute: 6, Burst: 3},
+					Channel: Bucket{PerMinute: 20, Burst: 10},
+					Global:  Bucket{PerMinute: 60, Burst: 20},
+				},
+				Auth: Auth{APIKeys: "", JWTIssuer: "", JWTAudience: "", JWKSFile: ""},
+				Log:  Log{Level: "info", Format: "text", AddSource: false},
+				Tracing: Tracing{
+					Exporter:    "none",
+					Endpoint:    "",
+					File:        "",
+					SampleRatio: 1,
+					ServiceName: "akari",
+				},
+				Secrets: Secrets{
+					Provider:        "none",
+					Dir:             "",
+					ProjectID:       "",
+					Endpoint:        testSecretsEndpoint,
+					RefreshInterval: 5 * time.Minute,
+				},
+				Runtime: Runtime{File: ""},
+				File:    "",
 			},
 		},
 		{
 			name:    "loads environment overrides",
 			wantErr: false,
 			env: map[string]string{
-				"AKARI_ADDR":        ":9090",
-				"POSTGRES_HOST":     "db",
-				testPortEnv:         "15432",
-				"POSTGRES_USER":     testDatabase,
-				"POSTGRES_PASSWORD": "password",
-				"POSTGRES_DB":       "akari_dev",
-				"POSTGRES_SSLMODE":  "require",
+				"AKARI_ADDR":                   ":9090",
+				"POSTGRES_HOST":                "db",
+				testPortEnv:                    "15432",
+				"POSTGRES_USER":                testDatabase,
+				"POSTGRES_PASSWORD":            "password",
+				"POSTGRES_DB":                  "akari_dev",
+				"POSTGRES_SSLMODE":             "require",
+				"DISCORD_TOKEN":                "token",
+				"DISCORD_GUILD_ID":             "123456789012345678",
+				"LLM_PROJECT_ID":               "kizuna-org",
+				"LLM_LOCATION":                 "asia-northeast1",
+				"LLM_MODEL_NAME":               "gemini-2.5-pro",
+				"ATTACHMENTS_MIME_TYPES":       "image/png, image/gif",
+				"ATTACHMENTS_MAX_BYTES":        "1048576",
+				"ATTACHMENTS_MAX_COUNT":        "0",
+				"ATTACHMENTS_TIMEOUT":          "3s",
+				"KISEKI_URL":                   "http://kiseki:8080",
+				"KISEKI_TIMEOUT":               "2s",
+				"CHARACTER_ID":                 "0193b1c6-6f5e-7a51-9a3c-3f0d1c2b4e5f",
+				"CHARACTER_NAME":               "Hikari",
+				"CHARACTER_SLEEP_SCHEDULE":     "0 3 * * *",
+				"CHARACTER_TIMEZONE":           "Asia/Tokyo",
+				"CHARACTER_TOOLS":              "current_time, recall_memory",
+				"RATE_LIMIT_USER_PER_MINUTE":   "2",
+				"RATE_LIMIT_USER_BURST":        "1",
+				"RATE_LIMIT_GLOBAL_PER_MINUTE": "0",
+				"AUTH_API_KEYS":                "ops:admin:secret",
+				"AUTH_JWT_ISSUER":              "https://auth.example.com",
+				"AUTH_JWT_AUDIENCE":            "akari",
+				"AUTH_JWKS_FILE":               "/app/secrets/jwks.json",
+				"LOG_LEVEL":                    "debug",
+				"LOG_FORMAT":                   "json",
+				"LOG_SOURCE":                   "true",
+				"OTEL_TRACES_EXPORTER":         "otlp",
+				"OTEL_EXPORTER_OTLP_ENDPOINT":  "http://otel-collector:4318",
+				"OTEL_TRACES_SAMPLER_ARG":      "0.25",
+				"OTEL_SERVICE_NAME":            "akari-dev",
+				"SECRETS_PROVIDER":             "file",
+				"SECRETS_DIR":                  "/run/secrets",
+				"SECRETS_REFRESH_INTERVAL":     "1m",
+				"RUNTIME_CONFIG_FILE":          "/app/config/runtime.json",
 			},
 			want: Config{
+				Env:  testDevelopment,
 				Addr: ":9090",
 				Database: Database{
 					Host:     "db",
@@ -131,6 +226,58 @@ func TestLoad(t *testing.T) {
 					Name:     "akari_dev",
 					SSLMode:  "require",
 				},
+				Discord: Discord{Token: "token", GuildID: "123456789012345678"},
+				LLM: LLM{
+					ProjectID: "kizuna-org",
+					Location:  "asia-northeast1",
+					ModelName: "gemini-2.5-pro",
+				},
+				Attachments: Attachments{
+					MIMETypes: []string{"image/png", "image/gif"},
+					MaxBytes:  1 << 20,
+					MaxCount:  0,
+					Timeout:   3 * time.Second,
+				},
+				Kiseki: Kiseki{URL: "http://kiseki:8080", Timeout: 2 * time.Second},
+				Character: Character{
+					ID:            "0193b1c6-6f5e-7a51-9a3c-3f0d1c2b4e5f",
+					Name:          "Hikari",
+					SleepSchedule: "0 3 * * *",
+					Timezone:      "Asia/Tokyo",
+					Tools:         []string{"current_time", "recall_memory"},
+					TokenSecret:   "",
+					Token:         "",
+					RateLimit:     nil,
+				},
+				Characters: nil,
+				RateLimit: RateLimit{
+					User:    Bucket{PerMinute: 2, Burst: 1},
+					Channel: Bucket{PerMinute: 20, Burst: 10},
+					Global:  Bucket{PerMinute: 0, Burst: 20},
+				},
+				Auth: Auth{
+					APIKeys:     "ops:admin:secret",
+					JWTIssuer:   "https://auth.example.com",
+					JWTAudience: "akari",
+					JWKSFile:    "/app/secrets/jwks.json",
+				},
+				Log: Log{Level: "debug", Format: "json", AddSource: true},
+				Tracing: Tracing{
+					Exporter:    "otlp",
+					Endpoint:    "http://otel-collector:4318",
+					File:        "",
+					SampleRatio: 0.25,
+					ServiceName: "akari-dev",
+				},
+				Secrets: Secrets{
+					Provider:        "file",
+					Dir:             "/run/secrets",
+					ProjectID:       "",
+					Endpoint:        testSecretsEndpoint,
+					RefreshInterval: time.Minute,
+				},
+				Runtime: Runtime{File: "/app/config/runtime.json"},
+				File:    "",
 			},
 		},
 		{
@@ -138,17 +285,47 @@ func TestLoad(t *testing.T) {
 			env: map[string]string{
 				testPortEnv: "invalid",
 			},
-			want: Config{
-				Addr: "",
-				Database: Database{
-					Host:     "",
-					Port:     0,
-					User:     "",
-					Password: "",
-					Name:     "",
-					SSLMode:  "",
-				},
+			want:    Config{},
+			wantErr: true,
+		},
+		{
+			name: "rejects invalid rate limit",
+			env: map[string]string{
+				"RATE_LIMIT_CHANNEL_BURST": "many",
+			},
+			want:    Config{},
+			wantErr: true,
+		},
+		{
+			name: "rejects invalid log source",
+			env: map[string]string{
+				"LOG_SOURCE": "sometimes",
 			},
+			want:    Config{},
+			wantErr: true,
+		},
+		{
+			name: "rejects invalid sample ratio",
+			env: map[string]string{
+				"OTEL_TRACES_SAMPLER_ARG": "half",
+			},
+			want:    Config{},
+			wantErr: true,
+		},
+		{
+			name: "rejects invalid attachment size",
+			env: map[string]string{
+				"ATTACHMENTS_MAX_BYTES": "5MB",
+			},
+			want:    Config{},
+			wantErr: true,
+		},
+		{
+			name: "rejects invalid kiseki timeout",
+			env: map[string]string{
+				"KISEKI_TIMEOUT": "soon",
+			},
+			want:    Config{},
 			wantErr: true,
 		},
 	}
@@ -174,13 +351,422 @@ func TestLoad(t *testing.T) {
 				t.Fatalf("Load() error = %v", err)
 			}
 
-			if got != testCase.want {
+			if !reflect.DeepEqual(got, testCase.want) {
 				t.Fatalf("Load() = %#v, want %#v", got, testCase.want)
 			}
 		})
 	}
 }
 
+func TestLoadReportsAllProblems(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		wantKeys []string
+	}{
+		{
+			name: "aggregates parse and validation errors",
+			env: map[string]string{
+				"AKARI_ADDR":       "8080",
+				testPortEnv:        "invalid",
+				"POSTGRES_SSLMODE": "sometimes",
+				"LOG_FORMAT":       "xml",
+			},
+			wantKeys: []string{testPortEnv, "AKARI_ADDR", "POSTGRES_SSLMODE", "LOG_FORMAT"},
+		},
+		{
+			name: "requires deployment fields in production",
+			env: map[string]string{
+				"ENV":            testProduction,
+				"DISCORD_TOKEN":  "token",
+				"LLM_PROJECT_ID": "kizuna-org",
+			},
+			wantKeys: []string{"KISEKI_URL", "CHARACTER_ID"},
+		},
+		{
+			name: "forbids a discord token in test",
+			env: map[string]string{
+				"ENV":           testEnvironment,
+				"DISCORD_TOKEN": "token",
+			},
+			wantKeys: []string{"DISCORD_TOKEN"},
+		},
+		{
+			name: "reports unreadable secret files",
+			env: map[string]string{
+				"DISCORD_TOKEN_FILE": "/nonexistent/discord-token",
+				"SECRETS_PROVIDER":   "gcp",
+			},
+			wantKeys: []string{"DISCORD_TOKEN_FILE", "SECRETS_PROJECT_ID"},
+		},
+		{
+			name: "checks dependent fields",
+			env: map[string]string{
+				"AUTH_API_KEYS":            "ops:root:secret,ci::",
+				"AUTH_JWKS_FILE":           "/app/secrets/jwks.json",
+				"ATTACHMENTS_MIME_TYPES":   "image/png,png",
+				"ATTACHMENTS_MAX_COUNT":    "-1",
+				"CHARACTER_SLEEP_SCHEDULE": "at night",
+				"OTEL_TRACES_SAMPLER_ARG":  "2",
+			},
+			wantKeys: []string{
+				"ATTACHMENTS_MIME_TYPES",
+				"ATTACHMENTS_MAX_COUNT",
+				"CHARACTER_SLEEP_SCHEDULE",
+				"AUTH_API_KEYS",
+				"AUTH_API_KEYS",
+				"AUTH_JWT_ISSUER",
+				"OTEL_TRACES_SAMPLER_ARG",
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			clearConfigEnv(t)
+
+			for key, value := range testCase.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := Load()
+
+			var keys []string
+			for _, fieldErr := range FieldErrors(err) {
+				keys = append(keys, fieldErr.Key)
+			}
+
+			if !slices.Equal(keys, testCase.wantKeys) {
+				t.Fatalf("Load() problems = %v, want %v\n%v", keys, testCase.wantKeys, err)
+			}
+		})
+	}
+}
+
+func TestLoadSecretFiles(t *testing.T) {
+	clearConfigEnv(t)
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"password": testPassword + "\n",
+		"token":    "token",
+	} {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600)
+		if err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	t.Setenv("POSTGRES_PASSWORD", "ignored")
+	t.Setenv("POSTGRES_PASSWORD_FILE", filepath.Join(dir, "password"))
+	t.Setenv("DISCORD_TOKEN_FILE", filepath.Join(dir, "token"))
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Database.Password != testPassword || cfg.Discord.Token != "token" {
+		t.Fatalf("Load() secrets = %q, %q, want %q, %q", cfg.Database.Password, cfg.Discord.Token, testPassword, "token")
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "yaml",
+			file: "akari.yaml",
+			content: `database:
+  host: db
+  port: 15432
+discord:
+  guild_id: 123456789012345678
+kiseki:
+  timeout: 2s
+rate_limit:
+  user: {per_minute: 2, burst: 1}
+log:
+  source: true
+tracing:
+  sample_ratio: 0.25
+`,
+		},
+		{
+			name: "toml",
+			file: "akari.toml",
+			content: `[database]
+host = "db"
+port = 15432
+
+[discord]
+guild_id = "123456789012345678"
+
+[kiseki]
+timeout = "2s"
+
+[rate_limit.user]
+per_minute = 2
+burst = 1
+
+[log]
+source = true
+
+[tracing]
+sample_ratio = 0.25
+`,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			clearConfigEnv(t)
+
+			path := filepath.Join(t.TempDir(), testCase.file)
+
+			err := os.WriteFile(path, []byte(testCase.content), 0o600)
+			if err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			t.Setenv("AKARI_CONFIG", path)
+			t.Setenv("POSTGRES_HOST", "override")
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			if cfg.File != path || cfg.Database.Host != "override" || cfg.Database.Port != 15432 {
+				t.Errorf("Load() = %q, %q, %d, want the file with POSTGRES_HOST overriding it",
+					cfg.File, cfg.Database.Host, cfg.Database.Port)
+			}
+
+			if cfg.Discord.GuildID != "123456789012345678" || cfg.Kiseki.Timeout != 2*time.Second {
+				t.Errorf("Load() guild, timeout = %q, %v", cfg.Discord.GuildID, cfg.Kiseki.Timeout)
+			}
+
+			if cfg.RateLimit.User != (Bucket{PerMinute: 2, Burst: 1}) || cfg.RateLimit.Channel.PerMinute != 20 {
+				t.Errorf("Load() rate limits = %+v, want the user bucket from the file", cfg.RateLimit)
+			}
+
+			if !cfg.Log.AddSource || cfg.Tracing.SampleRatio != 0.25 || cfg.Log.Level != "info" {
+				t.Errorf("Load() log, tracing = %+v, %+v", cfg.Log, cfg.Tracing)
+			}
+		})
+	}
+}
+
+func TestLoadHostedCharacters(t *testing.T) {
+	clearConfigEnv(t)
+
+	path := filepath.Join(t.TempDir(), "akari.yaml")
+
+	err := os.WriteFile(path, []byte(`character:
+  id: akari
+characters:
+  - id: luna
+    name: Luna
+    tools: current_time
+    discord_token_secret: LUNA_DISCORD_TOKEN
+  - id: sol
+    name: Sol
+    timezone: Asia/Tokyo
+    rate_limit:
+      user: {per_minute: 2}
+`), 0o600)
+	if err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	t.Setenv("AKARI_CONFIG", path)
+	t.Setenv("LUNA_DISCORD_TOKEN", "luna-token")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := []Character{
+		{
+			ID:            "luna",
+			Name:          "Luna",
+			SleepSchedule: "",
+			Timezone:      "UTC",
+			Tools:         []string{"current_time"},
+			TokenSecret:   "LUNA_DISCORD_TOKEN",
+			Token:         "luna-token",
+			RateLimit:     nil,
+		},
+		{
+			ID:            "sol",
+			Name:          "Sol",
+			SleepSchedule: "",
+			Timezone:      "Asia/Tokyo",
+			Tools:         nil,
+			TokenSecret:   "",
+			Token:         "",
+			RateLimit: &RateLimit{
+				User:    Bucket{PerMinute: 2, Burst: 3},
+				Channel: Bucket{PerMinute: 20, Burst: 10},
+				Global:  Bucket{PerMinute: 60, Burst: 20},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(cfg.Characters, want) {
+		t.Fatalf("Load() characters = %+v, want %+v", cfg.Characters, want)
+	}
+}
+
+func TestLoadConfigFileProblems(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []string
+	}{
+		{
+			name: "yaml",
+			file: "akari.yaml",
+			content: `database:
+  port: many
+  sslmode: sometimes
+character:
+  nmae: Akari
+log: debug
+`,
+			want: []string{
+				`POSTGRES_PORT (database.port at PATH:2): invalid value: "many" is not an integer`,
+				`AKARI_CONFIG (PATH:5): unknown key character.nmae`,
+				`AKARI_CONFIG (log at PATH:6): invalid value: want a mapping`,
+				`POSTGRES_SSLMODE (database.sslmode at PATH:3): invalid value: "sometimes" is not one of`,
+			},
+		},
+		{
+			name: "toml",
+			file: "akari.toml",
+			content: `[database]
+port = "many"
+sslmode = "sometimes"
+
+[character]
+nmae = "Akari"
+`,
+			want: []string{
+				`AKARI_CONFIG (PATH): unknown key character.nmae`,
+				`POSTGRES_PORT (database.port at PATH): invalid value: "many" is not an integer`,
+				`POSTGRES_SSLMODE (database.sslmode at PATH): invalid value: "sometimes" is not one of`,
+			},
+		},
+		{
+			name: "characters",
+			file: "akari.yaml",
+			content: `character:
+  id: akari
+characters:
+  - id: akari
+    name: Again
+  - id: luna
+    timezone: Moon/Base
+    discord_token_secret: luna-token
+    rate_limit:
+      global: {burst: -1}
+`,
+			want: []string{
+				`AKARI_CONFIG (characters[0].id at PATH:4): invalid value: "akari" is hosted twice`,
+				`AKARI_CONFIG (characters[1].name): required`,
+				`AKARI_CONFIG (characters[1].timezone at PATH:7): invalid value`,
+				`AKARI_CONFIG (characters[1].discord_token_secret at PATH:8): invalid value`,
+				`AKARI_CONFIG (characters[1].rate_limit.global.burst at PATH:10): invalid value: -1 is negative`,
+			},
+		},
+		{
+			name:    "syntax",
+			file:    "akari.yaml",
+			content: "database: [\n",
+			want:    []string{`AKARI_CONFIG (PATH): invalid value: yaml: line 1`},
+		},
+		{
+			name:    "format",
+			file:    "akari.json",
+			content: "{}",
+			want:    []string{`AKARI_CONFIG (PATH): unknown config file format`},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			clearConfigEnv(t)
+
+			path := filepath.Join(t.TempDir(), testCase.file)
+
+			err := os.WriteFile(path, []byte(testCase.content), 0o600)
+			if err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			t.Setenv("AKARI_CONFIG", path)
+
+			_, err = Load()
+
+			problems := FieldErrors(err)
+			if len(problems) != len(testCase.want) {
+				t.Fatalf("Load() problems = %v, want %d", err, len(testCase.want))
+			}
+
+			for index, want := range testCase.want {
+				want = strings.ReplaceAll(want, "PATH", path)
+				if !strings.HasPrefix(problems[index].Error(), want) {
+					t.Errorf("problem %d = %q, want prefix %q", index, problems[index], want)
+				}
+			}
+		})
+	}
+}
+
+func TestPrint(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("POSTGRES_PASSWORD", testPassword)
+	t.Setenv("DISCORD_TOKEN", "token")
+	t.Setenv("AUTH_API_KEYS", "ops:admin:secret, bot:user:hunter2")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var out strings.Builder
+
+	err = Print(&out, cfg)
+	if err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+
+	for _, line := range []string{
+		"ENV=development",
+		"POSTGRES_PORT=5432",
+		"POSTGRES_PASSWORD=[REDACTED]",
+		"DISCORD_TOKEN=[REDACTED]",
+		"AUTH_API_KEYS=ops:admin:[REDACTED],bot:user:[REDACTED]",
+		"KISEKI_TIMEOUT=5s",
+		"OTEL_TRACES_SAMPLER_ARG=1",
+	} {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("Print() output is missing %q:\n%s", line, out.String())
+		}
+	}
+
+	for _, secret := range []string{testPassword, "token", "hunter2"} {
+		if strings.Contains(out.String(), "="+secret) || strings.Contains(out.String(), ":"+secret) {
+			t.Errorf("Print() output leaks %q:\n%s", secret, out.String())
+		}
+	}
+}
+
 func TestEnvFile(t *testing.T) {
 	tests := []struct {
 		name string
@@ -238,6 +824,50 @@ func clearConfigEnv(t *testing.T) {
 		"POSTGRES_PASSWORD",
 		"POSTGRES_DB",
 		"POSTGRES_SSLMODE",
+		"DISCORD_TOKEN",
+		"DISCORD_GUILD_ID",
+		"LLM_PROJECT_ID",
+		"LLM_LOCATION",
+		"LLM_MODEL_NAME",
+		"ATTACHMENTS_MIME_TYPES",
+		"ATTACHMENTS_MAX_BYTES",
+		"ATTACHMENTS_MAX_COUNT",
+		"ATTACHMENTS_TIMEOUT",
+		"KISEKI_URL",
+		"KISEKI_TIMEOUT",
+		"CHARACTER_ID",
+		"CHARACTER_NAME",
+		"CHARACTER_SLEEP_SCHEDULE",
+		"CHARACTER_TIMEZONE",
+		"CHARACTER_TOOLS",
+		"RATE_LIMIT_USER_PER_MINUTE",
+		"RATE_LIMIT_USER_BURST",
+		"RATE_LIMIT_CHANNEL_PER_MINUTE",
+		"RATE_LIMIT_CHANNEL_BURST",
+		"RATE_LIMIT_GLOBAL_PER_MINUTE",
+		"RATE_LIMIT_GLOBAL_BURST",
+		"AUTH_API_KEYS",
+		"AUTH_JWT_ISSUER",
+		"AUTH_JWT_AUDIENCE",
+		"LOG_LEVEL",
+		"LOG_FORMAT",
+		"LOG_SOURCE",
+		"OTEL_TRACES_EXPORTER",
+		"OTEL_EXPORTER_OTLP_ENDPOINT",
+		"OTEL_TRACES_FILE",
+		"OTEL_TRACES_SAMPLER_ARG",
+		"OTEL_SERVICE_NAME",
+		"AUTH_JWKS_FILE",
+		"SECRETS_PROVIDER",
+		"SECRETS_DIR",
+		"SECRETS_PROJECT_ID",
+		"SECRETS_ENDPOINT",
+		"SECRETS_REFRESH_INTERVAL",
+		"POSTGRES_PASSWORD_FILE",
+		"DISCORD_TOKEN_FILE",
+		"AUTH_API_KEYS_FILE",
+		"RUNTIME_CONFIG_FILE",
+		"AKARI_CONFIG",
 	}
 	for _, key := range keys {
 		t.Setenv(key, "")
@@ -0,0 +1,528 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"go.yaml.in/yaml/v3"
+)
+
+const fileKey = "AKARI_CONFIG"
+
+var (
+	ErrUnknownKey    = errors.New("unknown key")
+	ErrUnknownFormat = errors.New("unknown config file format, want .yaml, .yml or .toml")
+)
+
+// fileConfig is the schema of the AKARI_CONFIG file. Each setting names the
+// environment variable it stands in for, which takes precedence over the
+// file; tables prefix the variables of their settings. Secrets are left out
+// so the file can be committed: set them in the environment or a _FILE.
+type fileConfig struct {
+	Env         *string         `env:"ENV"         file:"env"`
+	Addr        *string         `env:"AKARI_ADDR"  file:"addr"`
+	Database    fileDatabase    `env:"POSTGRES_"   file:"database"`
+	Discord     fileDiscord     `env:"DISCORD_"    file:"discord"`
+	LLM         fileLLM         `env:"LLM_"        file:"llm"`
+	Attachments fileAttachments `env:"ATTACHMENTS_" file:"attachments"`
+	Kiseki      fileKiseki      `env:"KISEKI_"     file:"kiseki"`
+	Character   fileCharacter   `env:"CHARACTER_"  file:"character"`
+	RateLimit   fileRateLimit   `env:"RATE_LIMIT_" file:"rate_limit"`
+	Auth        fileAuth        `env:"AUTH_"       file:"auth"`
+	Log         fileLog         `env:"LOG_"        file:"log"`
+	Tracing     fileTracing     `env:"OTEL_"       file:"tracing"`
+	Secrets     fileSecrets     `env:"SECRETS_"    file:"secrets"`
+	Runtime     fileRuntime     `env:"RUNTIME_"    file:"runtime"`
+
+	Characters []fileHostedCharacter `file:"characters"`
+}
+
+type fileDatabase struct {
+	Host    *string `env:"HOST"    file:"host"`
+	Port    *int    `env:"PORT"    file:"port"`
+	User    *string `env:"USER"    file:"user"`
+	Name    *string `env:"DB"      file:"name"`
+	SSLMode *string `env:"SSLMODE" file:"sslmode"`
+}
+
+type fileDiscord struct {
+	GuildID *string `env:"GUILD_ID" file:"guild_id"`
+}
+
+type fileLLM struct {
+	ProjectID *string `env:"PROJECT_ID" file:"project_id"`
+	Location  *string `env:"LOCATION"   file:"location"`
+	ModelName *string `env:"MODEL_NAME" file:"model_name"`
+}
+
+type fileAttachments struct {
+	MIMETypes *string        `env:"MIME_TYPES" file:"mime_types"`
+	MaxBytes  *int           `env:"MAX_BYTES"  file:"max_bytes"`
+	MaxCount  *int           `env:"MAX_COUNT"  file:"max_count"`
+	Timeout   *time.Duration `env:"TIMEOUT"    file:"timeout"`
+}
+
+type fileKiseki struct {
+	URL     *string        `env:"URL"     file:"url"`
+	Timeout *time.Duration `env:"TIMEOUT" file:"timeout"`
+}
+
+type fileCharacter struct {
+	ID            *string `env:"ID"             file:"id"`
+	Name          *string `env:"NAME"           file:"name"`
+	SleepSchedule *string `env:"SLEEP_SCHEDULE" file:"sleep_schedule"`
+	Timezone      *string `env:"TIMEZONE"       file:"timezone"`
+	Tools         *string `env:"TOOLS"          file:"tools"`
+}
+
+type fileRateLimit struct {
+	User    fileBucket `env:"USER_"    file:"user"`
+	Channel fileBucket `env:"CHANNEL_" file:"channel"`
+	Global  fileBucket `env:"GLOBAL_"  file:"global"`
+}
+
+type fileBucket struct {
+	PerMinute *int `env:"PER_MINUTE" file:"per_minute"`
+	Burst     *int `env:"BURST"      file:"burst"`
+}
+
+type fileAuth struct {
+	JWTIssuer   *string `env:"JWT_ISSUER"   file:"jwt_issuer"`
+	JWTAudience *string `env:"JWT_AUDIENCE" file:"jwt_audience"`
+	JWKSFile    *string `env:"JWKS_FILE"    file:"jwks_file"`
+}
+
+type fileLog struct {
+	Level     *string `env:"LEVEL"  file:"level"`
+	Format    *string `env:"FORMAT" file:"format"`
+	AddSource *bool   `env:"SOURCE" file:"source"`
+}
+
+type fileTracing struct {
+	Exporter    *string  `env:"TRACES_EXPORTER"        file:"exporter"`
+	Endpoint    *string  `env:"EXPORTER_OTLP_ENDPOINT" file:"endpoint"`
+	File        *string  `env:"TRACES_FILE"            file:"file"`
+	SampleRatio *float64 `env:"TRACES_SAMPLER_ARG"     file:"sample_ratio"`
+	ServiceName *string  `env:"SERVICE_NAME"           file:"service_name"`
+}
+
+type fileSecrets struct {
+	Provider        *string        `env:"PROVIDER"         file:"provider"`
+	Dir             *string        `env:"DIR"              file:"dir"`
+	ProjectID       *string        `env:"PROJECT_ID"       file:"project_id"`
+	Endpoint        *string        `env:"ENDPOINT"         file:"endpoint"`
+	RefreshInterval *time.Duration `env:"REFRESH_INTERVAL" file:"refresh_interval"`
+}
+
+type fileRuntime struct {
+	File *string `env:"CONFIG_FILE" file:"file"`
+}
+
+// fileHostedCharacter is an entry of the characters list. Its settings have
+// no environment variables; rate limits it leaves out are the shared ones.
+type fileHostedCharacter struct {
+	ID            *string       `file:"id"`
+	Name          *string       `file:"name"`
+	SleepSchedule *string       `file:"sleep_schedule"`
+	Timezone      *string       `file:"timezone"`
+	Tools         *string       `file:"tools"`
+	TokenSecret   *string       `file:"discord_token_secret"`
+	RateLimit     fileRateLimit `file:"rate_limit"`
+}
+
+func (r fileRateLimit) resolve(defaults RateLimit) *RateLimit {
+	if !r.User.set() && !r.Channel.set() && !r.Global.set() {
+		return nil
+	}
+
+	return &RateLimit{
+		User:    r.User.resolve(defaults.User),
+		Channel: r.Channel.resolve(defaults.Channel),
+		Global:  r.Global.resolve(defaults.Global),
+	}
+}
+
+func (b fileBucket) set() bool {
+	return b.PerMinute != nil || b.Burst != nil
+}
+
+func (b fileBucket) resolve(defaults Bucket) Bucket {
+	return Bucket{PerMinute: valueOr(b.PerMinute, defaults.PerMinute), Burst: valueOr(b.Burst, defaults.Burst)}
+}
+
+// configFile is what was read from the config file: settings keyed by their
+// environment variable, settings of listed characters keyed by name, and
+// the listed characters themselves.
+type configFile struct {
+	values     map[string]fileValue
+	characters []fileHostedCharacter
+}
+
+// fileValue is a setting read from the config file, as its environment
+// variable would spell it, and where in the file it was found.
+type fileValue struct {
+	value    string
+	location string
+}
+
+// readFile reads the config file at path, a YAML or TOML document chosen by
+// its extension. Every problem is reported with its location; settings that
+// decoded cleanly are still used.
+func readFile(path string) (configFile, []*FieldError) {
+	data, err := os.ReadFile(path) // #nosec G304 -- the path is operator configuration.
+	if err != nil {
+		return configFile{}, []*FieldError{fileError(fileKey, "", fmt.Errorf("%w: %w", ErrInvalid, err))}
+	}
+
+	var (
+		decoded fileConfig
+		lines   map[string]int
+		errs    []*FieldError
+	)
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		lines, errs = decodeYAML(path, data, &decoded)
+	case ".toml":
+		errs = decodeTOML(path, data, &decoded)
+	default:
+		return configFile{}, []*FieldError{fileError(fileKey, path, ErrUnknownFormat)}
+	}
+
+	values := map[string]fileValue{}
+	record := func(byName bool) func(string, string, reflect.Value) {
+		return func(name string, key string, field reflect.Value) {
+			if field.IsNil() {
+				return
+			}
+
+			if byName {
+				key = name
+			}
+
+			values[key] = fileValue{
+				value:    fmt.Sprint(field.Elem().Interface()),
+				location: location(path, lines[name], name),
+			}
+		}
+	}
+
+	visitSchema(reflect.ValueOf(&decoded).Elem(), "", "", record(false))
+
+	for index := range decoded.Characters {
+		prefix := fmt.Sprintf("characters[%d].", index)
+		visitSchema(reflect.ValueOf(&decoded.Characters[index]).Elem(), prefix, "", record(true))
+	}
+
+	return configFile{values: values, characters: decoded.Characters}, errs
+}
+
+// characters builds the characters listed in the config file. Each one's
+// bot token is read like any other secret, from the environment variable
+// (or _FILE) its discord_token_secret names.
+func (r *reader) characters(defaults RateLimit) []Character {
+	if len(r.file.characters) == 0 {
+		return nil
+	}
+
+	characters := make([]Character, 0, len(r.file.characters))
+
+	for _, entry := range r.file.characters {
+		character := Character{
+			ID:            valueOr(entry.ID, ""),
+			Name:          valueOr(entry.Name, ""),
+			SleepSchedule: valueOr(entry.SleepSchedule, ""),
+			Timezone:      valueOr(entry.Timezone, "UTC"),
+			Tools:         list(valueOr(entry.Tools, "")),
+			TokenSecret:   valueOr(entry.TokenSecret, ""),
+			Token:         "",
+			RateLimit:     entry.RateLimit.resolve(defaults),
+		}
+
+		if character.TokenSecret != "" {
+			character.Token = r.secret(character.TokenSecret, "")
+		}
+
+		characters = append(characters, character)
+	}
+
+	return characters
+}
+
+// visitSchema calls visit with the dotted file name and the environment
+// variable of every setting in schema, leaving out lists.
+func visitSchema(schema reflect.Value, prefix string, envPrefix string, visit func(string, string, reflect.Value)) {
+	for index := range schema.NumField() {
+		field := schema.Type().Field(index)
+		name := prefix + field.Tag.Get("file")
+		key := envPrefix + field.Tag.Get("env")
+
+		switch field.Type.Kind() {
+		case reflect.Struct:
+			visitSchema(schema.Field(index), name+".", key, visit)
+		case reflect.Slice:
+		default:
+			visit(name, key, schema.Field(index))
+		}
+	}
+}
+
+// schemaField finds the setting or table called name in schema.
+func schemaField(schema reflect.Value, name string) (reflect.Value, string, bool) {
+	for index := range schema.NumField() {
+		field := schema.Type().Field(index)
+		if field.Tag.Get("file") == name {
+			return schema.Field(index), field.Tag.Get("env"), true
+		}
+	}
+
+	return reflect.Value{}, "", false
+}
+
+type yamlDecoder struct {
+	path  string
+	lines map[string]int
+	errs  []*FieldError
+	// listed is set while decoding list entries, whose settings have no
+	// environment variable.
+	listed bool
+}
+
+func decodeYAML(path string, data []byte, decoded *fileConfig) (map[string]int, []*FieldError) {
+	var document yaml.Node
+
+	err := yaml.Unmarshal(data, &document)
+	if err != nil {
+		return nil, []*FieldError{fileError(fileKey, path, fmt.Errorf("%w: %w", ErrInvalid, err))}
+	}
+
+	decoder := yamlDecoder{path: path, lines: map[string]int{}, errs: nil, listed: false}
+	if len(document.Content) > 0 {
+		decoder.table(document.Content[0], reflect.ValueOf(decoded).Elem(), "", "")
+	}
+
+	return decoder.lines, decoder.errs
+}
+
+func (d *yamlDecoder) table(node *yaml.Node, schema reflect.Value, name string, envPrefix string) {
+	if node.Kind != yaml.MappingNode {
+		d.fail(fileKey, node.Line, name, fmt.Errorf("%w: want a mapping", ErrInvalid))
+
+		return
+	}
+
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		keyNode, valueNode := node.Content[index], node.Content[index+1]
+		childName := joinName(name, keyNode.Value)
+
+		field, env, ok := schemaField(schema, keyNode.Value)
+		if !ok {
+			d.fail(fileKey, keyNode.Line, "", fmt.Errorf("%w %s", ErrUnknownKey, childName))
+
+			continue
+		}
+
+		d.lines[childName] = keyNode.Line
+
+		switch field.Kind() {
+		case reflect.Struct:
+			d.table(valueNode, field, childName, envPrefix+env)
+		case reflect.Slice:
+			d.list(valueNode, field, childName)
+		default:
+			err := valueNode.Decode(field.Addr().Interface())
+			if err != nil || valueNode.Kind != yaml.ScalarNode {
+				field.SetZero()
+				d.fail(settingKey(envPrefix+env, d.listed), valueNode.Line, childName, invalidSetting(valueNode.Value, field))
+			}
+		}
+	}
+}
+
+func (d *yamlDecoder) list(node *yaml.Node, field reflect.Value, name string) {
+	if node.Kind != yaml.SequenceNode {
+		d.fail(fileKey, node.Line, name, fmt.Errorf("%w: want a list", ErrInvalid))
+
+		return
+	}
+
+	d.listed = true
+	defer func() { d.listed = false }()
+
+	for index, item := range node.Content {
+		entryName := fmt.Sprintf("%s[%d]", name, index)
+		d.lines[entryName] = item.Line
+
+		entry := reflect.New(field.Type().Elem()).Elem()
+		d.table(item, entry, entryName, "")
+		field.Set(reflect.Append(field, entry))
+	}
+}
+
+func (d *yamlDecoder) fail(key string, line int, name string, err error) {
+	d.errs = append(d.errs, fileError(key, location(d.path, line, name), err))
+}
+
+type tomlDecoder struct {
+	path     string
+	metadata toml.MetaData
+	errs     []*FieldError
+	listed   bool
+}
+
+// decodeTOML decodes one table at a time so that, as with YAML, every
+// problem is reported rather than only the first. TOML positions are not
+// exposed per key, so problems are located by name only.
+func decodeTOML(path string, data []byte, decoded *fileConfig) []*FieldError {
+	var document map[string]toml.Primitive
+
+	metadata, err := toml.Decode(string(data), &document)
+	if err != nil {
+		return []*FieldError{fileError(fileKey, path, fmt.Errorf("%w: %w", ErrInvalid, err))}
+	}
+
+	decoder := tomlDecoder{path: path, metadata: metadata, errs: nil, listed: false}
+	decoder.table(document, reflect.ValueOf(decoded).Elem(), "", "")
+
+	return decoder.errs
+}
+
+func (d *tomlDecoder) table(document map[string]toml.Primitive, schema reflect.Value, name string, envPrefix string) {
+	names := make([]string, 0, len(document))
+	for key := range document {
+		names = append(names, key)
+	}
+
+	slices.Sort(names)
+
+	for _, key := range names {
+		childName := joinName(name, key)
+
+		field, env, ok := schemaField(schema, key)
+		if !ok {
+			d.fail(fileKey, "", fmt.Errorf("%w %s", ErrUnknownKey, childName))
+
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.Struct:
+			var child map[string]toml.Primitive
+
+			err := d.metadata.PrimitiveDecode(document[key], &child)
+			if err != nil {
+				d.fail(fileKey, childName, fmt.Errorf("%w: want a table", ErrInvalid))
+
+				continue
+			}
+
+			d.table(child, field, childName, envPrefix+env)
+		case reflect.Slice:
+			d.list(document[key], field, childName)
+		default:
+			err := d.metadata.PrimitiveDecode(document[key], field.Addr().Interface())
+			if err != nil {
+				var raw any
+
+				_ = d.metadata.PrimitiveDecode(document[key], &raw)
+
+				field.SetZero()
+				d.fail(settingKey(envPrefix+env, d.listed), childName, invalidSetting(fmt.Sprint(raw), field))
+			}
+		}
+	}
+}
+
+func (d *tomlDecoder) list(value toml.Primitive, field reflect.Value, name string) {
+	var entries []map[string]toml.Primitive
+
+	err := d.metadata.PrimitiveDecode(value, &entries)
+	if err != nil {
+		d.fail(fileKey, name, fmt.Errorf("%w: want an array of tables", ErrInvalid))
+
+		return
+	}
+
+	d.listed = true
+	defer func() { d.listed = false }()
+
+	for index, item := range entries {
+		entry := reflect.New(field.Type().Elem()).Elem()
+		d.table(item, entry, fmt.Sprintf("%s[%d]", name, index), "")
+		field.Set(reflect.Append(field, entry))
+	}
+}
+
+func (d *tomlDecoder) fail(key string, name string, err error) {
+	d.errs = append(d.errs, fileError(key, location(d.path, 0, name), err))
+}
+
+// invalidSetting describes a value that does not fit its setting's type.
+func invalidSetting(value string, field reflect.Value) error {
+	want := "a string"
+
+	switch elem := field.Type().Elem(); {
+	case elem == reflect.TypeFor[time.Duration]():
+		want = "a duration such as 30s"
+	case elem.Kind() == reflect.Int:
+		want = "an integer"
+	case elem.Kind() == reflect.Float64:
+		want = "a number"
+	case elem.Kind() == reflect.Bool:
+		want = "true or false"
+	}
+
+	return fmt.Errorf("%w: %q is not %s", ErrInvalid, value, want)
+}
+
+// settingKey is the environment variable a setting is reported under. Listed
+// settings have none, so they are reported under AKARI_CONFIG.
+func settingKey(key string, listed bool) string {
+	if listed {
+		return fileKey
+	}
+
+	return key
+}
+
+func valueOr[T any](value *T, fallback T) T {
+	if value == nil {
+		return fallback
+	}
+
+	return *value
+}
+
+func fileError(key string, where string, err error) *FieldError {
+	return &FieldError{Key: key, Location: where, Err: err}
+}
+
+// location describes where a setting is in the config file, as
+// "name at path:line", or less when the line or name is not known.
+func location(path string, line int, name string) string {
+	where := path
+	if line > 0 {
+		where = fmt.Sprintf("%s:%d", path, line)
+	}
+
+	if name == "" {
+		return where
+	}
+
+	return name + " at " + where
+}
+
+func joinName(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
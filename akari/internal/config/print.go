@@ -0,0 +1,152 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Redacted replaces secret values in Print output.
+const Redacted = "[REDACTED]"
+
+// Print writes the effective configuration as KEY=value lines, one per
+// environment variable and then one per setting of each character listed in
+// the config file, with passwords, tokens and API keys redacted.
+func Print(w io.Writer, cfg Config) error {
+	for _, setting := range settings(cfg) {
+		_, err := fmt.Fprintf(w, "%s=%s\n", setting.key, setting.value)
+		if err != nil {
+			return fmt.Errorf("write config: %w", err)
+		}
+	}
+
+	return nil
+}
+
+type setting struct {
+	key   string
+	value string
+}
+
+func settings(cfg Config) []setting {
+	settings := []setting{
+		{key: "ENV", value: cfg.Env},
+		{key: "AKARI_CONFIG", value: cfg.File},
+		{key: "AKARI_ADDR", value: cfg.Addr},
+		{key: "POSTGRES_HOST", value: cfg.Database.Host},
+		{key: "POSTGRES_PORT", value: strconv.Itoa(cfg.Database.Port)},
+		{key: "POSTGRES_USER", value: cfg.Database.User},
+		{key: "POSTGRES_PASSWORD", value: redact(cfg.Database.Password)},
+		{key: "POSTGRES_DB", value: cfg.Database.Name},
+		{key: "POSTGRES_SSLMODE", value: cfg.Database.SSLMode},
+		{key: "DISCORD_TOKEN", value: redact(cfg.Discord.Token)},
+		{key: "DISCORD_GUILD_ID", value: cfg.Discord.GuildID},
+		{key: "LLM_PROJECT_ID", value: cfg.LLM.ProjectID},
+		{key: "LLM_LOCATION", value: cfg.LLM.Location},
+		{key: "LLM_MODEL_NAME", value: cfg.LLM.ModelName},
+		{key: "ATTACHMENTS_MIME_TYPES", value: strings.Join(cfg.Attachments.MIMETypes, ",")},
+		{key: "ATTACHMENTS_MAX_BYTES", value: strconv.Itoa(cfg.Attachments.MaxBytes)},
+		{key: "ATTACHMENTS_MAX_COUNT", value: strconv.Itoa(cfg.Attachments.MaxCount)},
+		{key: "ATTACHMENTS_TIMEOUT", value: cfg.Attachments.Timeout.String()},
+		{key: "KISEKI_URL", value: cfg.Kiseki.URL},
+		{key: "KISEKI_TIMEOUT", value: cfg.Kiseki.Timeout.String()},
+		{key: "CHARACTER_ID", value: cfg.Character.ID},
+		{key: "CHARACTER_NAME", value: cfg.Character.Name},
+		{key: "CHARACTER_SLEEP_SCHEDULE", value: cfg.Character.SleepSchedule},
+		{key: "CHARACTER_TIMEZONE", value: cfg.Character.Timezone},
+		{key: "CHARACTER_TOOLS", value: strings.Join(cfg.Character.Tools, ",")},
+		{key: "RATE_LIMIT_USER_PER_MINUTE", value: strconv.Itoa(cfg.RateLimit.User.PerMinute)},
+		{key: "RATE_LIMIT_USER_BURST", value: strconv.Itoa(cfg.RateLimit.User.Burst)},
+		{key: "RATE_LIMIT_CHANNEL_PER_MINUTE", value: strconv.Itoa(cfg.RateLimit.Channel.PerMinute)},
+		{key: "RATE_LIMIT_CHANNEL_BURST", value: strconv.Itoa(cfg.RateLimit.Channel.Burst)},
+		{key: "RATE_LIMIT_GLOBAL_PER_MINUTE", value: strconv.Itoa(cfg.RateLimit.Global.PerMinute)},
+		{key: "RATE_LIMIT_GLOBAL_BURST", value: strconv.Itoa(cfg.RateLimit.Global.Burst)},
+		{key: "AUTH_API_KEYS", value: redactAPIKeys(cfg.Auth.APIKeys)},
+		{key: "AUTH_JWT_ISSUER", value: cfg.Auth.JWTIssuer},
+		{key: "AUTH_JWT_AUDIENCE", value: cfg.Auth.JWTAudience},
+		{key: "AUTH_JWKS_FILE", value: cfg.Auth.JWKSFile},
+		{key: "LOG_LEVEL", value: cfg.Log.Level},
+		{key: "LOG_FORMAT", value: cfg.Log.Format},
+		{key: "LOG_SOURCE", value: strconv.FormatBool(cfg.Log.AddSource)},
+		{key: "OTEL_TRACES_EXPORTER", value: cfg.Tracing.Exporter},
+		{key: "OTEL_EXPORTER_OTLP_ENDPOINT", value: cfg.Tracing.Endpoint},
+		{key: "OTEL_TRACES_FILE", value: cfg.Tracing.File},
+		{key: "OTEL_TRACES_SAMPLER_ARG", value: strconv.FormatFloat(cfg.Tracing.SampleRatio, 'g', -1, 64)},
+		{key: "OTEL_SERVICE_NAME", value: cfg.Tracing.ServiceName},
+		{key: "SECRETS_PROVIDER", value: cfg.Secrets.Provider},
+		{key: "SECRETS_DIR", value: cfg.Secrets.Dir},
+		{key: "SECRETS_PROJECT_ID", value: cfg.Secrets.ProjectID},
+		{key: "SECRETS_ENDPOINT", value: cfg.Secrets.Endpoint},
+		{key: "SECRETS_REFRESH_INTERVAL", value: cfg.Secrets.RefreshInterval.String()},
+		{key: "RUNTIME_CONFIG_FILE", value: cfg.Runtime.File},
+	}
+
+	for index, character := range cfg.Characters {
+		settings = append(settings, characterSettings(fmt.Sprintf("characters[%d].", index), character)...)
+	}
+
+	return settings
+}
+
+func characterSettings(prefix string, character Character) []setting {
+	settings := []setting{
+		{key: prefix + "id", value: character.ID},
+		{key: prefix + "name", value: character.Name},
+		{key: prefix + "sleep_schedule", value: character.SleepSchedule},
+		{key: prefix + "timezone", value: character.Timezone},
+		{key: prefix + "tools", value: strings.Join(character.Tools, ",")},
+		{key: prefix + "discord_token_secret", value: character.TokenSecret},
+	}
+
+	if character.TokenSecret != "" {
+		settings = append(settings, setting{key: character.TokenSecret, value: redact(character.Token)})
+	}
+
+	if character.RateLimit == nil {
+		return settings
+	}
+
+	for _, scope := range []struct {
+		name   string
+		bucket Bucket
+	}{
+		{name: "user", bucket: character.RateLimit.User},
+		{name: "channel", bucket: character.RateLimit.Channel},
+		{name: "global", bucket: character.RateLimit.Global},
+	} {
+		settings = append(settings,
+			setting{key: prefix + "rate_limit." + scope.name + ".per_minute", value: strconv.Itoa(scope.bucket.PerMinute)},
+			setting{key: prefix + "rate_limit." + scope.name + ".burst", value: strconv.Itoa(scope.bucket.Burst)},
+		)
+	}
+
+	return settings
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	return Redacted
+}
+
+// redactAPIKeys keeps the name and role of each name:role:key entry so the
+// output still shows who has access.
+func redactAPIKeys(value string) string {
+	var entries []string
+
+	for entry := range strings.SplitSeq(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, rest, _ := strings.Cut(entry, ":")
+		role, _, _ := strings.Cut(rest, ":")
+		entries = append(entries, name+":"+role+":"+Redacted)
+	}
+
+	return strings.Join(entries, ",")
+}
@@ -0,0 +1,388 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+const maxPort = 65535
+
+var (
+	ErrInvalid    = errors.New("invalid value")
+	ErrRequired   = errors.New("required")
+	ErrNotAllowed = errors.New("not allowed")
+)
+
+var (
+	environments = []string{envDevelopment, envLocal, envTest, envProduction}
+	sslModes     = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	logFormats   = []string{"text", "json"}
+	exporters    = []string{"none", "otlp", "console"}
+	apiKeyRoles  = []string{"user", "admin"}
+	providers    = []string{"none", "file", "gcp"}
+
+	secretName = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+)
+
+// FieldError is a problem with a single environment variable. Location is
+// set when the value came from the AKARI_CONFIG file.
+type FieldError struct {
+	Key      string
+	Location string
+	Err      error
+}
+
+func (e *FieldError) Error() string {
+	if e.Location != "" {
+		return e.Key + " (" + e.Location + "): " + e.Err.Error()
+	}
+
+	return e.Key + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldErrors returns the field errors joined into err, in order.
+func FieldErrors(err error) []*FieldError {
+	if err == nil {
+		return nil
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		var fieldErr *FieldError
+		if errors.As(err, &fieldErr) {
+			return []*FieldError{fieldErr}
+		}
+
+		return nil
+	}
+
+	var fieldErrs []*FieldError
+	for _, inner := range joined.Unwrap() {
+		fieldErrs = append(fieldErrs, FieldErrors(inner)...)
+	}
+
+	return fieldErrs
+}
+
+func joinFieldErrors(fieldErrs []*FieldError) error {
+	errs := make([]error, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		errs = append(errs, fieldErr)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate checks every setting and returns all problems joined together, so
+// a misconfigured deployment fails at startup with the complete list. Fields
+// required in production, and forbidden in test, depend on Env.
+func (c Config) Validate() error {
+	var check validator
+
+	check.oneOf("ENV", c.Env, environments)
+	check.addr("AKARI_ADDR", c.Addr)
+	check.port("POSTGRES_PORT", c.Database.Port)
+	check.oneOf("POSTGRES_SSLMODE", c.Database.SSLMode, sslModes)
+
+	check.attachments(c.Attachments)
+
+	if c.Kiseki.URL != "" {
+		check.url("KISEKI_URL", c.Kiseki.URL)
+	}
+
+	check.positive("KISEKI_TIMEOUT", c.Kiseki.Timeout)
+	check.schedule("CHARACTER_TIMEZONE", "CHARACTER_SLEEP_SCHEDULE", c.Character)
+	check.characters(c)
+	check.rateLimit("RATE_LIMIT_%s_PER_MINUTE", "RATE_LIMIT_%s_BURST", c.RateLimit)
+	check.apiKeys("AUTH_API_KEYS", c.Auth.APIKeys)
+
+	if c.Auth.JWKSFile != "" && c.Auth.JWTIssuer == "" {
+		check.fail("AUTH_JWT_ISSUER", fmt.Errorf("%w with AUTH_JWKS_FILE", ErrRequired))
+	}
+
+	check.logLevel("LOG_LEVEL", c.Log.Level)
+	check.oneOf("LOG_FORMAT", c.Log.Format, logFormats)
+	check.oneOf("OTEL_TRACES_EXPORTER", c.Tracing.Exporter, exporters)
+
+	if c.Tracing.Endpoint != "" {
+		check.url("OTEL_EXPORTER_OTLP_ENDPOINT", c.Tracing.Endpoint)
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		check.fail("OTEL_TRACES_SAMPLER_ARG",
+			fmt.Errorf("%w: %v is not between 0 and 1", ErrInvalid, c.Tracing.SampleRatio))
+	}
+
+	check.secrets(c.Secrets)
+	check.environment(c)
+
+	if len(check.errs) > 0 {
+		return joinFieldErrors(check.errs)
+	}
+
+	return nil
+}
+
+type validator struct {
+	errs []*FieldError
+	// entry prefixes the names of the settings of a listed character, which
+	// have no environment variable and are reported under AKARI_CONFIG.
+	entry string
+}
+
+func (v *validator) fail(key string, err error) {
+	if v.entry != "" {
+		v.errs = append(v.errs, &FieldError{Key: fileKey, Location: v.entry + key, Err: err})
+
+		return
+	}
+
+	v.errs = append(v.errs, &FieldError{Key: key, Location: "", Err: err})
+}
+
+func (v *validator) oneOf(key string, value string, allowed []string) {
+	if !slices.Contains(allowed, value) {
+		v.fail(key, fmt.Errorf("%w: %q is not one of %s", ErrInvalid, value, strings.Join(allowed, ", ")))
+	}
+}
+
+func (v *validator) addr(key string, value string) {
+	_, port, err := net.SplitHostPort(value)
+	if err != nil {
+		v.fail(key, fmt.Errorf("%w: %w", ErrInvalid, err))
+
+		return
+	}
+
+	number, err := strconv.Atoi(port)
+	if err != nil || number < 0 || number > maxPort {
+		v.fail(key, fmt.Errorf("%w: port %q is not between 0 and %d", ErrInvalid, port, maxPort))
+	}
+}
+
+func (v *validator) port(key string, value int) {
+	if value < 1 || value > maxPort {
+		v.fail(key, fmt.Errorf("%w: %d is not between 1 and %d", ErrInvalid, value, maxPort))
+	}
+}
+
+func (v *validator) url(key string, value string) {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		v.fail(key, fmt.Errorf("%w: %w", ErrInvalid, err))
+
+		return
+	}
+
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		v.fail(key, fmt.Errorf("%w: %q is not an http or https URL", ErrInvalid, value))
+	}
+}
+
+func (v *validator) positive(key string, value time.Duration) {
+	if value <= 0 {
+		v.fail(key, fmt.Errorf("%w: %s is not positive", ErrInvalid, value))
+	}
+}
+
+func (v *validator) attachments(attachments Attachments) {
+	for _, mimeType := range attachments.MIMETypes {
+		mediaType, _, err := mime.ParseMediaType(mimeType)
+		if err != nil || mediaType != mimeType || !strings.Contains(mimeType, "/") {
+			v.fail("ATTACHMENTS_MIME_TYPES", fmt.Errorf("%w: %q is not a MIME type", ErrInvalid, mimeType))
+		}
+	}
+
+	if attachments.MaxBytes <= 0 {
+		v.fail("ATTACHMENTS_MAX_BYTES", fmt.Errorf("%w: %d is not positive", ErrInvalid, attachments.MaxBytes))
+	}
+
+	if attachments.MaxCount < 0 {
+		v.fail("ATTACHMENTS_MAX_COUNT", fmt.Errorf("%w: %d is negative", ErrInvalid, attachments.MaxCount))
+	}
+
+	v.positive("ATTACHMENTS_TIMEOUT", attachments.Timeout)
+}
+
+func (v *validator) schedule(timezoneKey string, scheduleKey string, character Character) {
+	location, err := time.LoadLocation(character.Timezone)
+	if err != nil {
+		v.fail(timezoneKey, fmt.Errorf("%w: %w", ErrInvalid, err))
+
+		return
+	}
+
+	if character.SleepSchedule == "" {
+		return
+	}
+
+	_, err = cron.ParseStandard("CRON_TZ=" + location.String() + " " + character.SleepSchedule)
+	if err != nil {
+		v.fail(scheduleKey, fmt.Errorf("%w: %w", ErrInvalid, err))
+	}
+}
+
+// characters checks the characters listed in the config file.
+func (v *validator) characters(c Config) {
+	hosted := map[string]bool{c.Character.ID: true}
+
+	for index, character := range c.Characters {
+		entry := validator{errs: nil, entry: fmt.Sprintf("characters[%d].", index)}
+
+		switch {
+		case character.ID == "":
+			entry.fail("id", ErrRequired)
+		case hosted[character.ID]:
+			entry.fail("id", fmt.Errorf("%w: %q is hosted twice", ErrInvalid, character.ID))
+		}
+
+		hosted[character.ID] = true
+
+		if strings.TrimSpace(character.Name) == "" {
+			entry.fail("name", ErrRequired)
+		}
+
+		entry.schedule("timezone", "sleep_schedule", character)
+
+		if character.TokenSecret != "" && !secretName.MatchString(character.TokenSecret) {
+			entry.fail("discord_token_secret",
+				fmt.Errorf("%w: %q is not an environment variable name", ErrInvalid, character.TokenSecret))
+		}
+
+		if character.RateLimit != nil {
+			entry.rateLimit("rate_limit.%s.per_minute", "rate_limit.%s.burst", *character.RateLimit)
+		}
+
+		v.errs = append(v.errs, entry.errs...)
+	}
+}
+
+// rateLimit checks every bucket, naming each setting by formatting the keys
+// with the bucket's scope, which environment variables spell in upper case.
+func (v *validator) rateLimit(perMinuteKey string, burstKey string, limits RateLimit) {
+	for _, scope := range []struct {
+		name   string
+		bucket Bucket
+	}{
+		{name: "user", bucket: limits.User},
+		{name: "channel", bucket: limits.Channel},
+		{name: "global", bucket: limits.Global},
+	} {
+		name := scope.name
+		if v.entry == "" {
+			name = strings.ToUpper(name)
+		}
+
+		if scope.bucket.PerMinute < 0 {
+			v.fail(fmt.Sprintf(perMinuteKey, name), fmt.Errorf("%w: %d is negative", ErrInvalid, scope.bucket.PerMinute))
+		}
+
+		if scope.bucket.Burst < 0 {
+			v.fail(fmt.Sprintf(burstKey, name), fmt.Errorf("%w: %d is negative", ErrInvalid, scope.bucket.Burst))
+		}
+	}
+}
+
+// apiKeys checks the name:role:key shape the auth interceptor parses.
+func (v *validator) apiKeys(key string, value string) {
+	for entry := range strings.SplitSeq(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, rest, _ := strings.Cut(entry, ":")
+		role, secret, _ := strings.Cut(rest, ":")
+
+		switch {
+		case name == "" || secret == "":
+			v.fail(key, fmt.Errorf("%w: entry %q is not name:role:key", ErrInvalid, name))
+		case !slices.Contains(apiKeyRoles, role):
+			v.fail(key, fmt.Errorf("%w: entry %q has unknown role %q", ErrInvalid, name, role))
+		}
+	}
+}
+
+func (v *validator) secrets(secrets Secrets) {
+	v.oneOf("SECRETS_PROVIDER", secrets.Provider, providers)
+
+	switch secrets.Provider {
+	case "file":
+		if secrets.Dir == "" {
+			v.fail("SECRETS_DIR", fmt.Errorf("%w with SECRETS_PROVIDER=file", ErrRequired))
+		}
+	case "gcp":
+		if secrets.ProjectID == "" {
+			v.fail("SECRETS_PROJECT_ID", fmt.Errorf("%w with SECRETS_PROVIDER=gcp", ErrRequired))
+		}
+
+		v.url("SECRETS_ENDPOINT", secrets.Endpoint)
+	}
+
+	v.positive("SECRETS_REFRESH_INTERVAL", secrets.RefreshInterval)
+}
+
+func (v *validator) logLevel(key string, value string) {
+	var level slog.Level
+
+	err := level.UnmarshalText([]byte(value))
+	if err != nil {
+		v.fail(key, fmt.Errorf("%w: %w", ErrInvalid, err))
+	}
+}
+
+// environment checks the fields a deployment needs: production must reach
+// Discord, the model and kiseki as a configured character, and test must
+// never connect to a real Discord gateway.
+func (v *validator) environment(c Config) {
+	switch c.Env {
+	case envProduction:
+		// A secret provider may supply the token instead.
+		if c.Discord.Token == "" && c.Secrets.Provider == "none" {
+			v.fail("DISCORD_TOKEN", fmt.Errorf("%w in %s", ErrRequired, envProduction))
+		}
+
+		required := []struct {
+			key   string
+			value string
+		}{
+			{key: "LLM_PROJECT_ID", value: c.LLM.ProjectID},
+			{key: "KISEKI_URL", value: c.Kiseki.URL},
+			{key: "CHARACTER_ID", value: c.Character.ID},
+		}
+		for _, field := range required {
+			if field.value == "" {
+				v.fail(field.key, fmt.Errorf("%w in %s", ErrRequired, envProduction))
+			}
+		}
+	case envTest:
+		if c.Discord.Token != "" {
+			v.fail("DISCORD_TOKEN", fmt.Errorf("%w in %s", ErrNotAllowed, envTest))
+		}
+	}
+
+	for _, character := range c.Characters {
+		switch {
+		case character.TokenSecret == "":
+		case c.Env == envProduction && character.Token == "" && c.Secrets.Provider == "none":
+			v.fail(character.TokenSecret, fmt.Errorf("%w in %s", ErrRequired, envProduction))
+		case c.Env == envTest && character.Token != "":
+			v.fail(character.TokenSecret, fmt.Errorf("%w in %s", ErrNotAllowed, envTest))
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package conversation
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxRecordSize bounds a single JSON Lines record when reading an archive.
+const maxRecordSize = 4 << 20
+
+// record is the JSON Lines representation of a turn in an archive.
+type record struct {
+	ID          string       `json:"id"`
+	CharacterID string       `json:"character_id"`
+	GuildID     string       `json:"guild_id,omitempty"`
+	ChannelID   string       `json:"channel_id"`
+	AuthorID    string       `json:"author_id"`
+	AuthorName  string       `json:"author_name"`
+	Message     string       `json:"message"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+	Reply       string       `json:"reply"`
+	Memories    []string     `json:"memories"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// Encode writes turn as one JSON Lines record.
+func Encode(w io.Writer, turn Turn) error {
+	memories := turn.Memories
+	if memories == nil {
+		memories = []string{}
+	}
+
+	err := json.NewEncoder(w).Encode(record{
+		ID:          turn.ID.String(),
+		CharacterID: turn.CharacterID,
+		GuildID:     turn.GuildID,
+		ChannelID:   turn.ChannelID,
+		AuthorID:    turn.AuthorID,
+		AuthorName:  turn.AuthorName,
+		Message:     turn.Message,
+		Attachments: turn.Attachments,
+		Reply:       turn.Reply,
+		Memories:    memories,
+		CreatedAt:   turn.CreatedAt.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("encode turn %s: %w", turn.ID, err)
+	}
+
+	return nil
+}
+
+// Decoder reads the turns of a JSON Lines archive. Blank lines are skipped.
+type Decoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxRecordSize)
+
+	return &Decoder{scanner: scanner, line: 0}
+}
+
+// Next returns the next turn, or io.EOF at the end of the archive.
+func (d *Decoder) Next() (Turn, error) {
+	for d.scanner.Scan() {
+		d.line++
+
+		if len(d.scanner.Bytes()) == 0 {
+			continue
+		}
+
+		turn, err := decode(d.scanner.Bytes())
+		if err != nil {
+			return Turn{}, fmt.Errorf("line %d: %w", d.line, err)
+		}
+
+		return turn, nil
+	}
+
+	err := d.scanner.Err()
+	if errors.Is(err, bufio.ErrTooLong) {
+		return Turn{}, fmt.Errorf("line %d: %w: longer than %d bytes", d.line+1, ErrInvalidRecord, maxRecordSize)
+	}
+
+	if err != nil {
+		return Turn{}, fmt.Errorf("read archive line %d: %w", d.line+1, err)
+	}
+
+	return Turn{}, io.EOF
+}
+
+func decode(line []byte) (Turn, error) {
+	var entry record
+
+	err := json.Unmarshal(line, &entry)
+	if err != nil {
+		return Turn{}, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+	}
+
+	id, err := uuid.Parse(entry.ID)
+	if err != nil {
+		return Turn{}, fmt.Errorf("%w: id: %w", ErrInvalidRecord, err)
+	}
+
+	if entry.ChannelID == "" || entry.AuthorID == "" || entry.CreatedAt.IsZero() {
+		return Turn{}, fmt.Errorf("%w: channel_id, author_id and created_at are required", ErrInvalidRecord)
+	}
+
+	return Turn{
+		ID:          id,
+		CharacterID: entry.CharacterID,
+		GuildID:     entry.GuildID,
+		ChannelID:   entry.ChannelID,
+		AuthorID:    entry.AuthorID,
+		AuthorName:  entry.AuthorName,
+		Message:     entry.Message,
+		Attachments: entry.Attachments,
+		Reply:       entry.Reply,
+		Memories:    entry.Memories,
+		CreatedAt:   entry.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package conversation
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+const testRecord = `{"id":"0199a1b2-0000-7000-8000-000000000001","character_id":"akari","channel_id":"general",` +
+	`"author_id":"alice","author_name":"Alice","message":"hello","reply":"hi!","memories":["Alice likes tea."],` +
+	`"created_at":"2026-10-01T12:00:00Z"}`
+
+func TestDecoder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		archive   string
+		wantTurns int
+		wantErr   error
+	}{
+		{name: "empty", archive: "", wantTurns: 0, wantErr: nil},
+		{name: "records and blank lines", archive: testRecord + "\n\n" + testRecord, wantTurns: 2, wantErr: nil},
+		{name: "not json", archive: "hello\n", wantTurns: 0, wantErr: ErrInvalidRecord},
+		{name: "bad id", archive: `{"id":"1","channel_id":"c","author_id":"a"}`, wantTurns: 0, wantErr: ErrInvalidRecord},
+		{
+			name:      "missing author",
+			archive:   strings.Replace(testRecord, `"author_id":"alice"`, `"author_id":""`, 1),
+			wantTurns: 0,
+			wantErr:   ErrInvalidRecord,
+		},
+		{
+			name:      "record too long",
+			archive:   strings.Repeat("x", maxRecordSize+1),
+			wantTurns: 0,
+			wantErr:   ErrInvalidRecord,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			decoder := NewDecoder(strings.NewReader(testCase.archive))
+			turns := 0
+
+			for {
+				turn, err := decoder.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+
+				if err != nil {
+					if !errors.Is(err, testCase.wantErr) {
+						t.Fatalf("Next() error = %v, want %v", err, testCase.wantErr)
+					}
+
+					return
+				}
+
+				if turn.AuthorID != "alice" || len(turn.Memories) != 1 {
+					t.Fatalf("Next() = %+v", turn)
+				}
+
+				turns++
+			}
+
+			if testCase.wantErr != nil || turns != testCase.wantTurns {
+				t.Fatalf("decoded %d turns, want %d and error %v", turns, testCase.wantTurns, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreImport(t *testing.T) {
+	t.Parallel()
+
+	turn, err := NewDecoder(strings.NewReader(testRecord)).Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+
+	store := NewMemoryStore()
+
+	for _, want := range []int{1, 0} {
+		imported, err := store.Import(t.Context(), []Turn{turn, turn})
+		if err != nil {
+			t.Fatalf("Import() error = %v", err)
+		}
+
+		if imported != want {
+			t.Fatalf("Import() = %d, want %d", imported, want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidRecord = errors.New("invalid conversation record")
+
+// Turn is one exchange between a user and a character. Attachments describe
+// the files attached to the message, and Memories holds the kiseki memories
+// recalled to write the reply.
+type Turn struct {
+	ID          uuid.UUID
+	CharacterID string
+	GuildID     string
+	ChannelID   string
+	AuthorID    string
+	AuthorName  string
+	Message     string
+	Attachments []Attachment
+	Reply       string
+	Memories    []string
+	CreatedAt   time.Time
+}
+
+// Attachment describes a file attached to a message. The file itself is
+// not kept.
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int    `json:"size"`
+	URL         string `json:"url"`
+}
+
+// Filter selects the turns of a user, a channel or both. CharacterID
+// narrows the selection further when set.
+type Filter struct {
+	UserID      string
+	ChannelID   string
+	CharacterID string
+}
+
+func (f Filter) matches(turn Turn) bool {
+	return (f.UserID == "" || turn.AuthorID == f.UserID) &&
+		(f.ChannelID == "" || turn.ChannelID == f.ChannelID) &&
+		(f.CharacterID == "" || turn.CharacterID == f.CharacterID)
+}
+
+// Store keeps the conversation history.
+type Store interface {
+	// Record appends a turn, assigning its ID and time when unset.
+	Record(ctx context.Context, turn Turn) error
+	// Each calls fn for every turn matching filter, oldest first.
+	Each(ctx context.Context, filter Filter, fn func(Turn) error) error
+	// Import stores the turns whose IDs are not stored yet and returns how
+	// many were added, so importing an archive twice is harmless.
+	Import(ctx context.Context, turns []Turn) (int, error)
+}
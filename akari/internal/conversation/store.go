@@ -0,0 +1,282 @@
+package conversation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kizuna-org/akari/ent/schema"
+	"github.com/kizuna-org/akari/gen/ent"
+	"github.com/kizuna-org/akari/gen/ent/predicate"
+	entturn "github.com/kizuna-org/akari/gen/ent/turn"
+)
+
+// pageSize is how many turns Each reads from Postgres at a time.
+const pageSize = 500
+
+func NewStore(client *ent.Client) Store {
+	return NewEntStore(client)
+}
+
+// EntStore persists turns in Postgres.
+type EntStore struct {
+	client *ent.Client
+}
+
+func NewEntStore(client *ent.Client) *EntStore {
+	return &EntStore{client: client}
+}
+
+func (s *EntStore) Record(ctx context.Context, turn Turn) error {
+	turn = withDefaults(turn)
+
+	err := s.create(s.client, turn).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("record turn %s: %w", turn.ID, err)
+	}
+
+	return nil
+}
+
+func (s *EntStore) Each(ctx context.Context, filter Filter, fn func(Turn) error) error {
+	var predicates []predicate.Turn
+	if filter.UserID != "" {
+		predicates = append(predicates, entturn.AuthorID(filter.UserID))
+	}
+
+	if filter.ChannelID != "" {
+		predicates = append(predicates, entturn.ChannelID(filter.ChannelID))
+	}
+
+	if filter.CharacterID != "" {
+		predicates = append(predicates, entturn.CharacterID(filter.CharacterID))
+	}
+
+	for offset := 0; ; offset += pageSize {
+		rows, err := s.client.Turn.Query().
+			Where(predicates...).
+			Order(ent.Asc(entturn.FieldCreatedAt), ent.Asc(entturn.FieldID)).
+			Offset(offset).
+			Limit(pageSize).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("list turns: %w", err)
+		}
+
+		for _, row := range rows {
+			err = fn(fromEnt(row))
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < pageSize {
+			return nil
+		}
+	}
+}
+
+func (s *EntStore) Import(ctx context.Context, turns []Turn) (int, error) {
+	if len(turns) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(turns))
+	for _, turn := range turns {
+		ids = append(ids, turn.ID)
+	}
+
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin import: %w", err)
+	}
+
+	existing, err := tx.Turn.Query().Where(entturn.IDIn(ids...)).IDs(ctx)
+	if err != nil {
+		return 0, rollback(tx, fmt.Errorf("list existing turns: %w", err))
+	}
+
+	builders := make([]*ent.TurnCreate, 0, len(turns))
+	for _, turn := range missing(turns, existing) {
+		builders = append(builders, s.create(tx.Client(), turn))
+	}
+
+	err = tx.Turn.CreateBulk(builders...).Exec(ctx)
+	if err != nil {
+		return 0, rollback(tx, fmt.Errorf("import turns: %w", err))
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, fmt.Errorf("commit import: %w", err)
+	}
+
+	return len(builders), nil
+}
+
+func (s *EntStore) create(client *ent.Client, turn Turn) *ent.TurnCreate {
+	return client.Turn.Create().
+		SetID(turn.ID).
+		SetCharacterID(turn.CharacterID).
+		SetGuildID(turn.GuildID).
+		SetChannelID(turn.ChannelID).
+		SetAuthorID(turn.AuthorID).
+		SetAuthorName(turn.AuthorName).
+		SetMessage(turn.Message).
+		SetAttachments(toSchema(turn.Attachments)).
+		SetReply(turn.Reply).
+		SetMemories(turn.Memories).
+		SetCreatedAt(turn.CreatedAt)
+}
+
+func rollback(tx *ent.Tx, err error) error {
+	rollbackErr := tx.Rollback()
+	if rollbackErr != nil {
+		return fmt.Errorf("%w (rollback: %w)", err, rollbackErr)
+	}
+
+	return err
+}
+
+func fromEnt(row *ent.Turn) Turn {
+	return Turn{
+		ID:          row.ID,
+		CharacterID: row.CharacterID,
+		GuildID:     row.GuildID,
+		ChannelID:   row.ChannelID,
+		AuthorID:    row.AuthorID,
+		AuthorName:  row.AuthorName,
+		Message:     row.Message,
+		Attachments: fromSchema(row.Attachments),
+		Reply:       row.Reply,
+		Memories:    row.Memories,
+		CreatedAt:   row.CreatedAt,
+	}
+}
+
+func toSchema(attachments []Attachment) []schema.Attachment {
+	rows := make([]schema.Attachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		rows = append(rows, schema.Attachment(attachment))
+	}
+
+	return rows
+}
+
+func fromSchema(rows []schema.Attachment) []Attachment {
+	attachments := make([]Attachment, 0, len(rows))
+	for _, row := range rows {
+		attachments = append(attachments, Attachment(row))
+	}
+
+	return attachments
+}
+
+func withDefaults(turn Turn) Turn {
+	if turn.ID == uuid.Nil {
+		turn.ID = uuid.Must(uuid.NewV7())
+	}
+
+	if turn.CreatedAt.IsZero() {
+		turn.CreatedAt = time.Now()
+	}
+
+	if turn.Attachments == nil {
+		turn.Attachments = []Attachment{}
+	}
+
+	if turn.Memories == nil {
+		turn.Memories = []string{}
+	}
+
+	return turn
+}
+
+// missing returns the turns whose IDs are not in existing, dropping
+// duplicates within turns too.
+func missing(turns []Turn, existing []uuid.UUID) []Turn {
+	seen := make(map[uuid.UUID]struct{}, len(turns)+len(existing))
+	for _, id := range existing {
+		seen[id] = struct{}{}
+	}
+
+	result := make([]Turn, 0, len(turns))
+
+	for _, turn := range turns {
+		if _, ok := seen[turn.ID]; ok {
+			continue
+		}
+
+		seen[turn.ID] = struct{}{}
+
+		result = append(result, withDefaults(turn))
+	}
+
+	return result
+}
+
+// MemoryStore keeps turns in memory, for tests and tools that run without
+// a database.
+type MemoryStore struct {
+	mu    sync.Mutex
+	turns []Turn
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{mu: sync.Mutex{}, turns: nil}
+}
+
+func (s *MemoryStore) Record(_ context.Context, turn Turn) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.turns = append(s.turns, withDefaults(turn))
+
+	return nil
+}
+
+func (s *MemoryStore) Each(_ context.Context, filter Filter, fn func(Turn) error) error {
+	s.mu.Lock()
+	turns := slices.Clone(s.turns)
+	s.mu.Unlock()
+
+	slices.SortStableFunc(turns, func(a, b Turn) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	for _, turn := range turns {
+		if !filter.matches(turn) {
+			continue
+		}
+
+		err := fn(turn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) Import(_ context.Context, turns []Turn) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing := make([]uuid.UUID, 0, len(s.turns))
+	for _, turn := range s.turns {
+		existing = append(existing, turn.ID)
+	}
+
+	added := missing(turns, existing)
+	s.turns = append(s.turns, added...)
+
+	return len(added), nil
+}
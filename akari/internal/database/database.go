@@ -2,23 +2,66 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"log/slog"
 
 	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
 	"github.com/kizuna-org/akari/gen/ent"
 	"github.com/kizuna-org/akari/internal/config"
-	_ "github.com/lib/pq"
+	"github.com/kizuna-org/akari/internal/metrics"
+	"github.com/kizuna-org/akari/internal/secret"
+	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"go.uber.org/fx"
 )
 
-func NewClient(cfg config.Config) (*ent.Client, error) {
-	client, err := ent.Open(dialect.Postgres, cfg.Database.DSN())
+// NewDB opens the connection pool. Each connection is opened with the
+// current database password, so a rotated password applies to new
+// connections without reopening the pool.
+func NewDB(cfg config.Config, secrets *secret.Store) *sql.DB {
+	return sql.OpenDB(connector{cfg: cfg.Database, secrets: secrets})
+}
+
+type connector struct {
+	cfg     config.Database
+	secrets *secret.Store
+}
+
+func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
+	cfg := c.cfg
+	cfg.Password = c.secrets.Get(secret.DatabasePassword)
+
+	pqConnector, err := pq.NewConnector(cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("parse database DSN: %w", err)
+	}
+
+	conn, err := pqConnector.Connect(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("open ent client: %w", err)
+		return nil, fmt.Errorf("connect to database: %w", err)
 	}
 
-	return client, nil
+	return conn, nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return pq.Driver{}
+}
+
+func NewClient(db *sql.DB) *ent.Client {
+	return ent.NewClient(ent.Driver(newTracedDriver(entsql.OpenDB(dialect.Postgres, db))))
+}
+
+// RegisterMetrics exports the connection pool statistics of db, labeled
+// db_name="akari".
+func RegisterMetrics(registry *prometheus.Registry, db *sql.DB) error {
+	_, err := metrics.Register(registry, collectors.NewDBStatsCollector(db, "akari"))
+
+	return err
 }
 
 func RegisterLifecycle(lc fx.Lifecycle, client *ent.Client) {
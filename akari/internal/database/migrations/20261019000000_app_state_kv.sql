@@ -0,0 +1,10 @@
+ALTER TABLE "app_states"
+  ADD COLUMN "key" character varying NULL,
+  ADD COLUMN "value" text NULL,
+  ADD COLUMN "updated_at" timestamptz NULL;
+UPDATE "app_states" SET "key" = 'legacy:' || "id", "value" = '', "updated_at" = now();
+ALTER TABLE "app_states"
+  ALTER COLUMN "key" SET NOT NULL,
+  ALTER COLUMN "value" SET NOT NULL,
+  ALTER COLUMN "updated_at" SET NOT NULL;
+CREATE UNIQUE INDEX "app_states_key_key" ON "app_states" ("key");
@@ -0,0 +1,10 @@
+CREATE TABLE "characters" (
+  "id" character varying NOT NULL,
+  "name" character varying NOT NULL,
+  "persona" text NOT NULL DEFAULT '',
+  "prompt_template" text NOT NULL DEFAULT '',
+  "channel_ids" jsonb NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
@@ -0,0 +1,15 @@
+CREATE TABLE "turns" (
+  "id" uuid NOT NULL,
+  "character_id" character varying NOT NULL,
+  "guild_id" character varying NOT NULL DEFAULT '',
+  "channel_id" character varying NOT NULL,
+  "author_id" character varying NOT NULL,
+  "author_name" character varying NOT NULL,
+  "message" text NOT NULL,
+  "reply" text NOT NULL,
+  "memories" jsonb NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "turn_author_id_created_at" ON "turns" ("author_id", "created_at");
+CREATE INDEX "turn_channel_id_created_at" ON "turns" ("channel_id", "created_at");
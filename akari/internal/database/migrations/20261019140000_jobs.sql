@@ -0,0 +1,15 @@
+CREATE TABLE "jobs" (
+  "id" uuid NOT NULL,
+  "kind" character varying NOT NULL,
+  "payload" text NOT NULL,
+  "status" character varying NOT NULL DEFAULT 'pending',
+  "run_at" timestamptz NOT NULL,
+  "attempts" bigint NOT NULL DEFAULT 0,
+  "last_error" text NOT NULL DEFAULT '',
+  "lease_owner" character varying NOT NULL DEFAULT '',
+  "lease_expires_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "job_status_run_at" ON "jobs" ("status", "run_at");
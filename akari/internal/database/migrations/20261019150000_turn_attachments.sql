@@ -0,0 +1,2 @@
+ALTER TABLE "turns" ADD COLUMN "attachments" jsonb NOT NULL DEFAULT '[]';
+ALTER TABLE "turns" ALTER COLUMN "attachments" DROP DEFAULT;
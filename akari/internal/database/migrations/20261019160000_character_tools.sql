@@ -0,0 +1,2 @@
+ALTER TABLE "characters" ADD COLUMN "tools" jsonb NOT NULL DEFAULT '[]';
+ALTER TABLE "characters" ALTER COLUMN "tools" DROP DEFAULT;
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"entgo.io/ent/dialect"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/kizuna-org/akari/internal/database"
+
+// tracedDriver records a span for every query ent runs, so slow replies can
+// be attributed to the database.
+type tracedDriver struct {
+	dialect.Driver
+
+	tracer trace.Tracer
+}
+
+func newTracedDriver(driver dialect.Driver) *tracedDriver {
+	return &tracedDriver{Driver: driver, tracer: otel.Tracer(tracerName)}
+}
+
+func (d *tracedDriver) Exec(ctx context.Context, query string, args, v any) error {
+	return traceQuery(ctx, d.tracer, "exec", query, func(ctx context.Context) error {
+		return d.Driver.Exec(ctx, query, args, v)
+	})
+}
+
+func (d *tracedDriver) Query(ctx context.Context, query string, args, v any) error {
+	return traceQuery(ctx, d.tracer, "query", query, func(ctx context.Context) error {
+		return d.Driver.Query(ctx, query, args, v)
+	})
+}
+
+func (d *tracedDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	return &tracedTx{Tx: tx, tracer: d.tracer}, nil
+}
+
+// BeginTx lets ent.Client.BeginTx work through the wrapper.
+func (d *tracedDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	beginner, ok := d.Driver.(interface {
+		BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return d.Tx(ctx)
+	}
+
+	tx, err := beginner.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	return &tracedTx{Tx: tx, tracer: d.tracer}, nil
+}
+
+type tracedTx struct {
+	dialect.Tx
+
+	tracer trace.Tracer
+}
+
+func (t *tracedTx) Exec(ctx context.Context, query string, args, v any) error {
+	return traceQuery(ctx, t.tracer, "exec", query, func(ctx context.Context) error {
+		return t.Tx.Exec(ctx, query, args, v)
+	})
+}
+
+func (t *tracedTx) Query(ctx context.Context, query string, args, v any) error {
+	return traceQuery(ctx, t.tracer, "query", query, func(ctx context.Context) error {
+		return t.Tx.Query(ctx, query, args, v)
+	})
+}
+
+func traceQuery(
+	ctx context.Context,
+	tracer trace.Tracer,
+	operation string,
+	query string,
+	run func(context.Context) error,
+) error {
+	ctx, span := tracer.Start(ctx, "ent."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", dialect.Postgres),
+			attribute.String("db.statement", query),
+		),
+	)
+	defer span.End()
+
+	err := run(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
@@ -0,0 +1,448 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kizuna-org/akari/internal/attachment"
+	"github.com/kizuna-org/akari/internal/character"
+	"github.com/kizuna-org/akari/internal/chat"
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/discord/rest"
+	"github.com/kizuna-org/akari/internal/logging"
+	"github.com/kizuna-org/akari/internal/metrics"
+	"github.com/kizuna-org/akari/internal/secret"
+	"github.com/kizuna-org/akari/internal/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+)
+
+const (
+	intents = discordgo.IntentsGuildMessages |
+		discordgo.IntentsDirectMessages |
+		discordgo.IntentsMessageContent
+
+	statusOnline   = "online"
+	statusIdle     = "idle"
+	sleepingStatus = "Sleeping..."
+
+	botPrefix  = "Bot "
+	tracerName = "github.com/kizuna-org/akari/internal/discord"
+
+	// restTimeout leaves a REST request the time it may wait for rate limits
+	// on top of the time it takes.
+	restTimeout = rest.MaxWait + 20*time.Second
+)
+
+// newSession creates a gateway session with the current value of the token
+// secret. A rotated token is used from the next request and reconnect.
+func newSession(secrets *secret.Store, tokenSecret string) (*discordgo.Session, error) {
+	session, err := discordgo.New(botPrefix + secrets.Get(tokenSecret))
+	if err != nil {
+		return nil, fmt.Errorf("create discord session: %w", err)
+	}
+
+	secrets.Subscribe(tokenSecret, func(token string) {
+		session.Lock()
+		defer session.Unlock()
+
+		session.Token = botPrefix + token
+	})
+
+	session.Identify.Intents = intents
+	session.Client = new(http.Client)
+	session.Client.Timeout = restTimeout
+	session.Client.Transport = rest.NewTransport(tracing.NewTransport(http.DefaultTransport))
+	// The transport retries 429s within its own budget; a 429 it gives up on
+	// is returned rather than slept on.
+	session.ShouldRetryOnRateLimit = false
+
+	return session, nil
+}
+
+var (
+	ErrGatewayDisconnected = errors.New("discord gateway is not connected")
+	ErrBotDisabled         = errors.New("discord bot token is not set")
+)
+
+// Bot is one Discord application. A dedicated bot replies as its own
+// character; the shared bot, logged in with DISCORD_TOKEN, replies as the
+// character its registry routes each channel to.
+type Bot struct {
+	session     *discordgo.Session
+	secrets     *secret.Store
+	tokenSecret string
+	route       func(channelID string) string
+	responder   *chat.Responder
+	connected   atomic.Bool
+	events      *prometheus.CounterVec
+	replies     *prometheus.CounterVec
+}
+
+// Bots are every Discord application this instance logs in as.
+type Bots struct {
+	shared    *Bot
+	dedicated map[string]*Bot
+	primary   string
+}
+
+func NewBots(
+	cfg config.Config,
+	secrets *secret.Store,
+	responder *chat.Responder,
+	characters *character.Registry,
+	registry *prometheus.Registry,
+) (*Bots, error) {
+	events, err := metrics.Register(registry, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "discord",
+		Name:      "events_total",
+		Help:      "Discord gateway events received, by event.",
+	}, []string{"event"}))
+	if err != nil {
+		return nil, err
+	}
+
+	replies, err := metrics.Register(registry, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "discord",
+		Name:      "replies_total",
+		Help:      "Replies to Discord messages, by outcome (sent, skipped or failed).",
+	}, []string{"outcome"}))
+	if err != nil {
+		return nil, err
+	}
+
+	bots := &Bots{shared: nil, dedicated: map[string]*Bot{}, primary: cfg.Character.ID}
+
+	for _, hosted := range cfg.Characters {
+		if hosted.TokenSecret == "" {
+			continue
+		}
+
+		id := hosted.ID
+
+		bots.dedicated[id], err = newBot(secrets, hosted.TokenSecret, func(string) string { return id },
+			responder, events, replies)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	shares := func(id string) bool {
+		_, ok := bots.dedicated[id]
+
+		return !ok
+	}
+
+	bots.shared, err = newBot(secrets, secret.DiscordToken, func(channelID string) string {
+		return characters.Route(channelID, shares).ID
+	}, responder, events, replies)
+	if err != nil {
+		return nil, err
+	}
+
+	return bots, nil
+}
+
+func newBot(
+	secrets *secret.Store,
+	tokenSecret string,
+	route func(channelID string) string,
+	responder *chat.Responder,
+	events *prometheus.CounterVec,
+	replies *prometheus.CounterVec,
+) (*Bot, error) {
+	session, err := newSession(secrets, tokenSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	bot := &Bot{
+		session:     session,
+		secrets:     secrets,
+		tokenSecret: tokenSecret,
+		route:       route,
+		responder:   responder,
+		connected:   atomic.Bool{},
+		events:      events,
+		replies:     replies,
+	}
+	session.AddHandler(bot.onMessageCreate)
+	session.AddHandler(func(*discordgo.Session, *discordgo.Ready) { bot.setConnected("ready", true) })
+	session.AddHandler(func(*discordgo.Session, *discordgo.Resumed) { bot.setConnected("resumed", true) })
+	session.AddHandler(func(*discordgo.Session, *discordgo.Disconnect) { bot.setConnected("disconnect", false) })
+
+	return bot, nil
+}
+
+// All returns the shared bot followed by the dedicated bots by character ID.
+func (b *Bots) All() []*Bot {
+	all := []*Bot{b.shared}
+	for _, id := range slices.Sorted(maps.Keys(b.dedicated)) {
+		all = append(all, b.dedicated[id])
+	}
+
+	return all
+}
+
+// Enabled returns the bots whose token is set.
+func (b *Bots) Enabled() []*Bot {
+	var enabled []*Bot
+
+	for _, bot := range b.All() {
+		if bot.Enabled() {
+			enabled = append(enabled, bot)
+		}
+	}
+
+	return enabled
+}
+
+// Of returns the bot that presents a character: its dedicated bot, or the
+// shared bot for the primary character. Other characters on the shared bot
+// have no presence of their own.
+func (b *Bots) Of(characterID string) (*Bot, bool) {
+	if bot, ok := b.dedicated[characterID]; ok {
+		return bot, true
+	}
+
+	if characterID == b.primary {
+		return b.shared, true
+	}
+
+	return nil, false
+}
+
+// Send posts a message as a character on its own initiative, through its
+// dedicated bot or else the shared bot.
+func (b *Bots) Send(characterID string, channelID string, content string) error {
+	bot, ok := b.dedicated[characterID]
+	if !ok {
+		bot = b.shared
+	}
+
+	if !bot.Enabled() {
+		return fmt.Errorf("%w: %s", ErrBotDisabled, bot.tokenSecret)
+	}
+
+	return sendMessage(bot.session, channelID, content)
+}
+
+// Ready reports whether every enabled bot's gateway session is established.
+func (b *Bots) Ready(ctx context.Context) error {
+	var errs []error
+
+	for _, bot := range b.Enabled() {
+		err := bot.Ready(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", bot.tokenSecret, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (b *Bot) setConnected(event string, connected bool) {
+	b.events.WithLabelValues(event).Inc()
+	b.connected.Store(connected)
+}
+
+// Enabled reports whether the bot's token is set.
+func (b *Bot) Enabled() bool {
+	return b.secrets.Get(b.tokenSecret) != ""
+}
+
+// Session returns the bot's gateway session.
+func (b *Bot) Session() *discordgo.Session {
+	return b.session
+}
+
+// Route returns the ID of the character the bot replies as in a channel.
+func (b *Bot) Route(channelID string) string {
+	return b.route(channelID)
+}
+
+// Ready reports whether the gateway session is established, so messages are
+// being received.
+func (b *Bot) Ready(context.Context) error {
+	if !b.connected.Load() {
+		return ErrGatewayDisconnected
+	}
+
+	return nil
+}
+
+func RegisterLifecycle(lc fx.Lifecycle, bots *Bots) {
+	for _, bot := range bots.All() {
+		if !bot.Enabled() {
+			slog.Info("discord token is not set, discord bot disabled", "token_secret", bot.tokenSecret)
+
+			continue
+		}
+
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				err := bot.session.Open()
+				if err != nil {
+					return fmt.Errorf("open discord session: %w", err)
+				}
+
+				slog.Info("discord connected", "token_secret", bot.tokenSecret)
+
+				return nil
+			},
+			OnStop: func(context.Context) error {
+				err := bot.session.Close()
+				if err != nil {
+					return fmt.Errorf("close discord session: %w", err)
+				}
+
+				slog.Info("discord disconnected", "token_secret", bot.tokenSecret)
+
+				return nil
+			},
+		})
+	}
+}
+
+// Sleep shows the bot as sleeping while its memories are consolidated.
+func (b *Bot) Sleep() error {
+	activity := new(discordgo.Activity)
+	activity.Name = sleepingStatus
+	activity.State = sleepingStatus
+	activity.Type = discordgo.ActivityTypeCustom
+
+	return b.updateStatus(statusIdle, []*discordgo.Activity{activity})
+}
+
+func (b *Bot) Wake() error {
+	return b.updateStatus(statusOnline, nil)
+}
+
+func (b *Bot) updateStatus(status string, activities []*discordgo.Activity) error {
+	err := b.session.UpdateStatusComplex(discordgo.UpdateStatusData{
+		IdleSince:  nil,
+		Activities: activities,
+		AFK:        false,
+		Status:     status,
+	})
+	if errors.Is(err, discordgo.ErrWSNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("update discord status: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Bot) onMessageCreate(session *discordgo.Session, event *discordgo.MessageCreate) {
+	b.events.WithLabelValues("message_create").Inc()
+
+	if event.Author == nil || event.Author.Bot || !addressed(session.State.User, event.Message) {
+		return
+	}
+
+	ctx, span := otel.Tracer(tracerName).Start(
+		logging.WithRequestID(context.Background(), event.ID),
+		"discord.MessageCreate",
+		trace.WithAttributes(attribute.String("discord.channel_id", event.ChannelID)),
+	)
+	defer span.End()
+
+	characterID := b.Route(event.ChannelID)
+	span.SetAttributes(attribute.String("akari.character_id", characterID))
+
+	stream := newReplyStream(session, event.ChannelID, event.Reference())
+	defer stream.stopTyping()
+
+	reply, err := b.responder.ReplyStream(ctx, chat.Message{
+		CharacterID: characterID,
+		GuildID:     event.GuildID,
+		ChannelID:   event.ChannelID,
+		AuthorID:    event.Author.ID,
+		AuthorName:  event.Author.DisplayName(),
+		Content:     stripMention(session.State.User, event.Content),
+		Attachments: files(event.Attachments),
+	}, stream)
+	if err != nil {
+		b.replies.WithLabelValues("failed").Inc()
+		slog.ErrorContext(ctx, "reply failed", "character_id", characterID, "channel_id", event.ChannelID, "error", err)
+
+		return
+	}
+
+	sent, err := stream.Finish(reply)
+	if err != nil {
+		b.replies.WithLabelValues("failed").Inc()
+		slog.ErrorContext(ctx, "send reply failed", "channel_id", event.ChannelID, "error", err)
+
+		return
+	}
+
+	if !sent {
+		b.replies.WithLabelValues("skipped").Inc()
+
+		return
+	}
+
+	b.replies.WithLabelValues("sent").Inc()
+}
+
+// addressed reports whether msg is a direct message or mentions the bot.
+func addressed(self *discordgo.User, msg *discordgo.Message) bool {
+	if msg.GuildID == "" {
+		return true
+	}
+
+	if self == nil {
+		return false
+	}
+
+	for _, user := range msg.Mentions {
+		if user.ID == self.ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func files(attachments []*discordgo.MessageAttachment) []attachment.File {
+	files := make([]attachment.File, 0, len(attachments))
+	for _, file := range attachments {
+		files = append(files, attachment.File{
+			Filename:    file.Filename,
+			ContentType: file.ContentType,
+			Size:        file.Size,
+			URL:         file.URL,
+		})
+	}
+
+	return files
+}
+
+func stripMention(self *discordgo.User, content string) string {
+	if self == nil {
+		return strings.TrimSpace(content)
+	}
+
+	content = strings.ReplaceAll(content, "<@"+self.ID+">", "")
+	content = strings.ReplaceAll(content, "<@!"+self.ID+">", "")
+
+	return strings.TrimSpace(content)
+}
@@ -0,0 +1,63 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const testBotID = "1000"
+
+func TestAddressed(t *testing.T) {
+	t.Parallel()
+
+	self := &discordgo.User{ID: testBotID}
+
+	tests := []struct {
+		name string
+		msg  *discordgo.Message
+		want bool
+	}{
+		{name: "direct message", msg: &discordgo.Message{GuildID: ""}, want: true},
+		{
+			name: "mentioned in guild",
+			msg:  &discordgo.Message{GuildID: "1", Mentions: []*discordgo.User{{ID: testBotID}}},
+			want: true,
+		},
+		{name: "not mentioned in guild", msg: &discordgo.Message{GuildID: "1"}, want: false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := addressed(self, testCase.msg); got != testCase.want {
+				t.Fatalf("addressed() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestStripMention(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "mention", content: "<@1000> hello", want: "hello"},
+		{name: "nickname mention", content: "hi <@!1000>", want: "hi"},
+		{name: "no mention", content: " hello ", want: "hello"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := stripMention(&discordgo.User{ID: testBotID}, testCase.content); got != testCase.want {
+				t.Fatalf("stripMention() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package rest
+
+import "strings"
+
+// majorParts splits a path into its resource, its ID and the rest.
+const majorParts = 3
+
+// majorResources are the path parameters Discord keeps separate buckets for.
+var majorResources = map[string]bool{
+	"channels": true,
+	"guilds":   true,
+	"webhooks": true,
+}
+
+// route returns the rate-limit route of a request: its method and path with
+// every parameter except major ones replaced by a placeholder. Requests with
+// the same route share a bucket. Interaction tokens are replaced too, as every
+// interaction has its own and would otherwise add a route.
+func route(method string, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) >= 2 && segments[0] == "api" {
+		segments = segments[2:]
+	}
+
+	for i, segment := range segments {
+		if i == 0 {
+			continue
+		}
+
+		previous := segments[i-1]
+
+		switch {
+		case majorResources[previous] && i == 1:
+		case previous == "reactions":
+			segments[i] = "{emoji}"
+		case i == 2 && segments[0] == "webhooks":
+		case i == 2 && segments[0] == "interactions":
+			segments[i] = "{token}"
+		case isSnowflake(segment):
+			segments[i] = "{id}"
+		}
+	}
+
+	return method + " /" + strings.Join(segments, "/")
+}
+
+func isSnowflake(segment string) bool {
+	if segment == "" {
+		return false
+	}
+
+	for _, r := range segment {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// major returns the major parameter of a route, such as "channels/123".
+func major(key string) string {
+	_, path, _ := strings.Cut(key, " ")
+
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", majorParts)
+	if len(segments) >= 2 && majorResources[segments[0]] {
+		return segments[0] + "/" + segments[1]
+	}
+
+	return ""
+}
@@ -0,0 +1,405 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxWait bounds the time a request spends queued behind its bucket and
+	// waiting for rate limits, over all of its retries. An HTTP client
+	// timeout must leave room for it on top of the requests themselves, or
+	// the client cancels a queued request before it is reported as a
+	// BackpressureError.
+	MaxWait = 10 * time.Second
+
+	defaultMaxRetries = 3
+	defaultMaxQueue   = 64
+
+	// sweepInterval is how often buckets that have been idle past their
+	// reset are forgotten.
+	sweepInterval = time.Minute
+
+	headerBucket     = "X-Ratelimit-Bucket"
+	headerRemaining  = "X-Ratelimit-Remaining"
+	headerReset      = "X-Ratelimit-Reset"
+	headerResetAfter = "X-Ratelimit-Reset-After"
+	headerGlobal     = "X-Ratelimit-Global"
+	headerRetryAfter = "Retry-After"
+	maxErrorBody     = 4096
+)
+
+var ErrRateLimited = errors.New("discord rate limited")
+
+// BackpressureError is returned instead of waiting when a request would have
+// to wait longer than the transport allows, or when too many requests are
+// already queued on its bucket.
+type BackpressureError struct {
+	Route      string
+	RetryAfter time.Duration
+}
+
+func (e *BackpressureError) Error() string {
+	return fmt.Sprintf("%s: %s, retry after %s", ErrRateLimited, e.Route, e.RetryAfter)
+}
+
+func (e *BackpressureError) Unwrap() error {
+	return ErrRateLimited
+}
+
+type bucket struct {
+	lock      chan struct{}
+	waiting   int
+	remaining int
+	reset     time.Time
+}
+
+// Transport schedules Discord REST requests by rate-limit bucket. Requests on
+// a bucket are sent one at a time, wait for the bucket to reset once it is
+// exhausted and are retried after a 429 for as long as Discord asks.
+//
+// discordgo keeps rate-limit buckets of its own, which it fills from the
+// response headers and sleeps on before a request reaches the transport,
+// beyond MaxWait. The transport consumes those headers so that discordgo's
+// buckets never run out and the transport alone decides how long to wait.
+type Transport struct {
+	base       http.RoundTripper
+	maxWait    time.Duration
+	maxRetries int
+	maxQueue   int
+	now        func() time.Time
+
+	mu          sync.Mutex
+	hashes      map[string]string
+	buckets     map[string]*bucket
+	globalReset time.Time
+	swept       time.Time
+}
+
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{
+		base:        base,
+		maxWait:     MaxWait,
+		maxRetries:  defaultMaxRetries,
+		maxQueue:    defaultMaxQueue,
+		now:         time.Now,
+		mu:          sync.Mutex{},
+		hashes:      make(map[string]string),
+		buckets:     make(map[string]*bucket),
+		globalReset: time.Time{},
+		swept:       time.Time{},
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := route(req.Method, req.URL.Path)
+
+	deadline := t.now().Add(t.maxWait)
+
+	current, err := t.acquire(req.Context(), key, deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	defer t.release(current)
+
+	for attempt := 0; ; attempt++ {
+		err := t.wait(req.Context(), key, current, deadline)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.send(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		retryAfter := t.update(key, current, resp)
+		consume(resp.Header)
+
+		if resp.StatusCode != http.StatusTooManyRequests || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+
+		_ = resp.Body.Close()
+
+		if attempt >= t.maxRetries || retryAfter > deadline.Sub(t.now()) {
+			return nil, &BackpressureError{Route: key, RetryAfter: retryAfter}
+		}
+	}
+}
+
+// acquire queues on the bucket of key and locks it. When Discord reports
+// while the request is queued that key shares its bucket hash with another
+// route, the request moves to that route's bucket, so that one lock guards
+// each hash.
+func (t *Transport) acquire(ctx context.Context, key string, deadline time.Time) (*bucket, error) {
+	for {
+		current, err := t.enqueue(key)
+		if err != nil {
+			return nil, err
+		}
+
+		err = t.lock(ctx, key, current, deadline)
+		if err != nil {
+			t.dequeue(current)
+
+			return nil, err
+		}
+
+		t.mu.Lock()
+		moved := t.buckets[t.id(key)] != current
+		t.mu.Unlock()
+
+		if !moved {
+			return current, nil
+		}
+
+		t.release(current)
+	}
+}
+
+func (t *Transport) release(current *bucket) {
+	<-current.lock
+	t.dequeue(current)
+}
+
+// lock waits for the requests queued before this one on the bucket until
+// the deadline.
+func (t *Transport) lock(ctx context.Context, key string, current *bucket, deadline time.Time) error {
+	timer := time.NewTimer(deadline.Sub(t.now()))
+	defer timer.Stop()
+
+	select {
+	case current.lock <- struct{}{}:
+		return nil
+	case <-timer.C:
+		t.mu.Lock()
+		retryAfter := max(current.reset.Sub(t.now()), 0)
+		t.mu.Unlock()
+
+		return &BackpressureError{Route: key, RetryAfter: retryAfter}
+	case <-ctx.Done():
+		return fmt.Errorf("wait for discord bucket: %w", ctx.Err())
+	}
+}
+
+// id returns the key of the bucket of a route in buckets: the route until
+// Discord reports its bucket hash, then the hash and major parameter. The
+// caller holds mu.
+func (t *Transport) id(key string) string {
+	if hash, ok := t.hashes[key]; ok {
+		return hash + " " + major(key)
+	}
+
+	return key
+}
+
+func (t *Transport) enqueue(key string) (*bucket, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if now := t.now(); now.Sub(t.swept) >= sweepInterval {
+		t.sweep(now)
+	}
+
+	id := t.id(key)
+
+	current, ok := t.buckets[id]
+	if !ok {
+		current = &bucket{lock: make(chan struct{}, 1), waiting: 0, remaining: -1, reset: time.Time{}}
+		t.buckets[id] = current
+	}
+
+	if current.waiting >= t.maxQueue {
+		return nil, &BackpressureError{Route: key, RetryAfter: max(current.reset.Sub(t.now()), 0)}
+	}
+
+	current.waiting++
+
+	return current, nil
+}
+
+func (t *Transport) dequeue(current *bucket) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current.waiting--
+}
+
+// sweep forgets the buckets no request is queued on whose limits have
+// reset, and the hashes of routes whose bucket it forgot, so that routes
+// with per-use parameters such as webhook tokens do not pile up. A forgotten
+// route starts over with a fresh bucket. The caller holds mu.
+func (t *Transport) sweep(now time.Time) {
+	t.swept = now
+
+	for id, idle := range t.buckets {
+		if idle.waiting == 0 && !idle.reset.After(now) {
+			delete(t.buckets, id)
+		}
+	}
+
+	for key, hash := range t.hashes {
+		if _, ok := t.buckets[hash+" "+major(key)]; !ok {
+			delete(t.hashes, key)
+		}
+	}
+}
+
+// wait blocks until the bucket and the global limit allow a request, unless
+// that is past the deadline.
+func (t *Transport) wait(ctx context.Context, key string, current *bucket, deadline time.Time) error {
+	t.mu.Lock()
+	now := t.now()
+	delay := max(t.globalReset.Sub(now), 0)
+
+	if current.remaining == 0 {
+		delay = max(delay, current.reset.Sub(now))
+	}
+	t.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	if delay > deadline.Sub(now) {
+		return &BackpressureError{Route: key, RetryAfter: delay}
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("wait for discord rate limit: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (t *Transport) send(req *http.Request, attempt int) (*http.Response, error) {
+	if attempt == 0 || req.Body == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	retry := req.Clone(req.Context())
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("replay request body: %w", err)
+	}
+
+	retry.Body = body
+
+	return t.base.RoundTrip(retry)
+}
+
+// update records the rate-limit headers of resp and returns how long a 429
+// asked us to wait.
+func (t *Transport) update(key string, current *bucket, resp *http.Response) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+
+	if remaining, err := strconv.Atoi(resp.Header.Get(headerRemaining)); err == nil {
+		current.remaining = remaining
+	}
+
+	if resetAfter, ok := parseSeconds(resp.Header.Get(headerResetAfter)); ok {
+		current.reset = now.Add(resetAfter)
+	}
+
+	var retryAfter time.Duration
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		var global bool
+
+		retryAfter, global = parseTooManyRequests(resp)
+		if global {
+			t.globalReset = now.Add(retryAfter)
+		} else {
+			current.remaining = 0
+			current.reset = now.Add(retryAfter)
+		}
+	}
+
+	if hash := resp.Header.Get(headerBucket); hash != "" {
+		t.share(key, hash, current)
+	}
+
+	return retryAfter
+}
+
+// share files key under the bucket hash Discord reported for it, so that
+// every route mapped to the same hash queues on the same bucket. When
+// another route already holds a bucket for the hash, that bucket takes the
+// state of current and requests queued on current move to it.
+func (t *Transport) share(key string, hash string, current *bucket) {
+	if t.buckets[key] == current {
+		delete(t.buckets, key)
+	}
+
+	t.hashes[key] = hash
+	id := t.id(key)
+
+	shared, ok := t.buckets[id]
+	if !ok {
+		t.buckets[id] = current
+
+		return
+	}
+
+	if shared != current {
+		shared.remaining = current.remaining
+		shared.reset = current.reset
+	}
+}
+
+// consume removes the rate-limit headers the transport has acted on from a
+// response, leaving discordgo's limiter nothing to wait on.
+func consume(header http.Header) {
+	for _, name := range []string{headerBucket, headerRemaining, headerResetAfter, headerReset, headerGlobal} {
+		header.Del(name)
+	}
+}
+
+func parseTooManyRequests(resp *http.Response) (time.Duration, bool) {
+	var body struct {
+		RetryAfter float64 `json:"retry_after"`
+		Global     bool    `json:"global"`
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err == nil {
+		_ = json.Unmarshal(payload, &body)
+	}
+
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(payload))
+
+	retryAfter := time.Duration(body.RetryAfter * float64(time.Second))
+	if retryAfter == 0 {
+		retryAfter, _ = parseSeconds(resp.Header.Get(headerRetryAfter))
+	}
+
+	return retryAfter, body.Global || resp.Header.Get(headerGlobal) == "true"
+}
+
+func parseSeconds(value string) (time.Duration, bool) {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return time.Duration(seconds * float64(time.Second)), true
+}
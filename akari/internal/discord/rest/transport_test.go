@@ -0,0 +1,315 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testMessagesPath = "/api/v9/channels/123/messages"
+
+func TestRoute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   string
+	}{
+		{
+			name:   "keeps the major channel id",
+			method: http.MethodPost,
+			path:   testMessagesPath,
+			want:   "POST /channels/123/messages",
+		},
+		{
+			name:   "replaces minor ids",
+			method: http.MethodDelete,
+			path:   "/api/v9/channels/123/messages/456",
+			want:   "DELETE /channels/123/messages/{id}",
+		},
+		{
+			name:   "replaces reaction emoji",
+			method: http.MethodPut,
+			path:   "/api/v9/channels/123/messages/456/reactions/%F0%9F%91%8D/@me",
+			want:   "PUT /channels/123/messages/{id}/reactions/{emoji}/@me",
+		},
+		{
+			name:   "keeps webhook id and token",
+			method: http.MethodPatch,
+			path:   "/api/v9/webhooks/789/token/messages/@original",
+			want:   "PATCH /webhooks/789/token/messages/@original",
+		},
+		{
+			name:   "replaces interaction token",
+			method: http.MethodPost,
+			path:   "/api/v9/interactions/456/aW50ZXJhY3Rpb24/callback",
+			want:   "POST /interactions/{id}/{token}/callback",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := route(testCase.method, testCase.path); got != testCase.want {
+				t.Fatalf("route() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func newTestServer(t *testing.T, handler func(w http.ResponseWriter, attempt int32)) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		handler(w, requests.Add(1))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func post(t *testing.T, client *http.Client, url string) (*http.Response, error) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, url, strings.NewReader(`{"content":"hi"}`))
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	return client.Do(req)
+}
+
+func TestTransportRetriesTooManyRequests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		global bool
+	}{
+		{name: "bucket limit", global: false},
+		{name: "global limit", global: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, requests := newTestServer(t, func(w http.ResponseWriter, attempt int32) {
+				w.Header().Set(headerBucket, "abcd")
+
+				if attempt == 1 {
+					if testCase.global {
+						w.Header().Set(headerGlobal, "true")
+					}
+
+					w.WriteHeader(http.StatusTooManyRequests)
+					_, _ = w.Write([]byte(`{"message":"You are being rate limited.","retry_after":0.05}`))
+
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+			})
+
+			client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+			start := time.Now()
+
+			resp, err := post(t, client, server.URL+testMessagesPath)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK || requests.Load() != 2 {
+				t.Fatalf("status = %d after %d requests, want 200 after 2", resp.StatusCode, requests.Load())
+			}
+
+			if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+				t.Fatalf("retried after %s, want at least retry_after", elapsed)
+			}
+		})
+	}
+}
+
+func TestTransportWaitsForBucketReset(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newTestServer(t, func(w http.ResponseWriter, _ int32) {
+		w.Header().Set(headerBucket, "abcd")
+		w.Header().Set(headerRemaining, "0")
+		w.Header().Set(headerResetAfter, "0.1")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+	start := time.Now()
+
+	for range 2 {
+		resp, err := post(t, client, server.URL+testMessagesPath)
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("second request sent after %s, want after bucket reset", elapsed)
+	}
+}
+
+func TestTransportConsumesHeaders(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newTestServer(t, func(w http.ResponseWriter, _ int32) {
+		w.Header().Set(headerBucket, "abcd")
+		w.Header().Set(headerRemaining, "0")
+		w.Header().Set(headerResetAfter, "0.1")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+	resp, err := post(t, client, server.URL+testMessagesPath)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if got := resp.Header.Get(headerRemaining); got != "" {
+		t.Fatalf("%s = %q, want it consumed", headerRemaining, got)
+	}
+}
+
+func TestTransportWaitBudget(t *testing.T) {
+	t.Parallel()
+
+	server, requests := newTestServer(t, func(w http.ResponseWriter, _ int32) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"message":"You are being rate limited.","retry_after":0.3}`))
+	})
+
+	transport := NewTransport(http.DefaultTransport)
+	transport.maxWait = 500 * time.Millisecond
+	client := &http.Client{Transport: transport}
+
+	// Each wait fits in maxWait, but the second does not fit in what is left
+	// of it after the first.
+	_, err := post(t, client, server.URL+testMessagesPath)
+
+	var backpressure *BackpressureError
+	if !errors.As(err, &backpressure) {
+		t.Fatalf("Do() error = %v, want backpressure", err)
+	}
+
+	if requests.Load() != 2 {
+		t.Fatalf("requests = %d, want 2", requests.Load())
+	}
+}
+
+func TestTransportBackpressure(t *testing.T) {
+	t.Parallel()
+
+	server, requests := newTestServer(t, func(w http.ResponseWriter, _ int32) {
+		w.Header().Set(headerRetryAfter, "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	transport := NewTransport(http.DefaultTransport)
+	transport.maxWait = time.Second
+	client := &http.Client{Transport: transport}
+
+	for range 2 {
+		_, err := post(t, client, server.URL+testMessagesPath)
+
+		var backpressure *BackpressureError
+		if !errors.As(err, &backpressure) || !errors.Is(err, ErrRateLimited) {
+			t.Fatalf("Do() error = %v, want backpressure", err)
+		}
+	}
+
+	if requests.Load() != 1 {
+		t.Fatalf("requests = %d, want 1", requests.Load())
+	}
+}
+
+func TestTransportSharesBucketByHash(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newTestServer(t, func(w http.ResponseWriter, _ int32) {
+		w.Header().Set(headerBucket, "abcd")
+		w.Header().Set(headerRemaining, "4")
+		w.Header().Set(headerResetAfter, "1")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	transport := NewTransport(http.DefaultTransport)
+	client := &http.Client{Transport: transport}
+
+	for _, path := range []string{testMessagesPath, testMessagesPath + "/456"} {
+		resp, err := post(t, client, server.URL+path)
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	if len(transport.buckets) != 1 || transport.buckets["abcd channels/123"] == nil {
+		t.Fatalf("buckets = %v, want one for hash abcd", transport.buckets)
+	}
+}
+
+func TestTransportSweepsIdleBuckets(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newTestServer(t, func(w http.ResponseWriter, _ int32) {
+		w.Header().Set(headerBucket, "abcd")
+		w.Header().Set(headerRemaining, "4")
+		w.Header().Set(headerResetAfter, "1")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	transport := NewTransport(http.DefaultTransport)
+	transport.now = func() time.Time { return now }
+	client := &http.Client{Transport: transport}
+
+	for _, webhook := range []string{"1/first-token", "2/second-token", "3/third-token"} {
+		resp, err := post(t, client, server.URL+"/api/v9/webhooks/"+webhook)
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	if len(transport.buckets) != 3 || len(transport.hashes) != 3 {
+		t.Fatalf("%d buckets and %d hashes, want 3 each", len(transport.buckets), len(transport.hashes))
+	}
+
+	now = now.Add(sweepInterval)
+
+	resp, err := post(t, client, server.URL+testMessagesPath)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if len(transport.buckets) != 1 || len(transport.hashes) != 1 {
+		t.Fatalf("%d buckets and %d hashes after sweep, want only the new route's", len(transport.buckets),
+			len(transport.hashes))
+	}
+}
@@ -0,0 +1,280 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// messageLimit is the most characters Discord accepts in a message.
+	messageLimit = 2000
+	// editInterval throttles progressive edits, which share the channel's
+	// message rate limit with every other reply.
+	editInterval = 1500 * time.Millisecond
+	// typingInterval renews the typing indicator, which Discord shows for
+	// ten seconds.
+	typingInterval = 8 * time.Second
+
+	codeFence = "```"
+)
+
+// messenger is the part of a Discord session a reply stream uses.
+type messenger interface {
+	ChannelTyping(channelID string, options ...discordgo.RequestOption) error
+	ChannelMessageSend(channelID string, content string, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageSendReply(
+		channelID string,
+		content string,
+		reference *discordgo.MessageReference,
+		options ...discordgo.RequestOption,
+	) (*discordgo.Message, error)
+	ChannelMessageEdit(
+		channelID string,
+		messageID string,
+		content string,
+		options ...discordgo.RequestOption,
+	) (*discordgo.Message, error)
+	ChannelMessageDelete(channelID string, messageID string, options ...discordgo.RequestOption) error
+}
+
+// replyStream renders a reply as it is generated: a typing indicator until
+// the first text, then a reply edited as the text grows, followed by more
+// messages once it outgrows one. It implements chat.Progress.
+type replyStream struct {
+	session   messenger
+	channelID string
+	reference *discordgo.MessageReference
+	now       func() time.Time
+
+	typing   chan struct{}
+	stopOnce sync.Once
+
+	// messages are the IDs of the messages sent so far and contents what
+	// each currently says.
+	messages []string
+	contents []string
+	rendered time.Time
+}
+
+func newReplyStream(session messenger, channelID string, reference *discordgo.MessageReference) *replyStream {
+	return &replyStream{
+		session:   session,
+		channelID: channelID,
+		reference: reference,
+		now:       time.Now,
+		typing:    make(chan struct{}),
+		stopOnce:  sync.Once{},
+		messages:  nil,
+		contents:  nil,
+		rendered:  time.Time{},
+	}
+}
+
+// Generating shows the typing indicator until the first text arrives.
+func (s *replyStream) Generating() {
+	go func() {
+		ticker := time.NewTicker(typingInterval)
+		defer ticker.Stop()
+
+		for {
+			// The indicator is cosmetic, so a failure is not worth reporting.
+			_ = s.session.ChannelTyping(s.channelID)
+
+			select {
+			case <-s.typing:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Partial renders the reply so far, at most once per editInterval. Failed
+// requests are not reported; Finish renders whatever they left out.
+func (s *replyStream) Partial(reply string) {
+	s.stopTyping()
+
+	if len(s.messages) > 0 && s.now().Sub(s.rendered) < editInterval {
+		return
+	}
+
+	_ = s.render(reply)
+}
+
+// Finish renders the whole reply and reports whether any message shows it.
+func (s *replyStream) Finish(reply string) (bool, error) {
+	s.stopTyping()
+
+	err := s.render(reply)
+
+	return len(s.messages) > 0, err
+}
+
+func (s *replyStream) stopTyping() {
+	s.stopOnce.Do(func() { close(s.typing) })
+}
+
+// render edits the messages whose chunk of the reply changed, sends the
+// chunks that have no message yet, the first as a reply, and deletes the
+// messages left over from a longer reply.
+func (s *replyStream) render(reply string) error {
+	s.rendered = s.now()
+
+	chunks := split(reply, messageLimit)
+
+	for index, chunk := range chunks {
+		if index < len(s.messages) {
+			if s.contents[index] == chunk {
+				continue
+			}
+
+			_, err := s.session.ChannelMessageEdit(s.channelID, s.messages[index], chunk)
+			if err != nil {
+				return fmt.Errorf("edit discord reply: %w", err)
+			}
+
+			s.contents[index] = chunk
+
+			continue
+		}
+
+		message, err := s.send(index, chunk)
+		if err != nil {
+			return err
+		}
+
+		s.messages = append(s.messages, message.ID)
+		s.contents = append(s.contents, chunk)
+	}
+
+	for len(s.messages) > len(chunks) {
+		last := len(s.messages) - 1
+
+		err := s.session.ChannelMessageDelete(s.channelID, s.messages[last])
+		if err != nil {
+			return fmt.Errorf("delete discord reply: %w", err)
+		}
+
+		s.messages = s.messages[:last]
+		s.contents = s.contents[:last]
+	}
+
+	return nil
+}
+
+func (s *replyStream) send(index int, chunk string) (*discordgo.Message, error) {
+	var (
+		message *discordgo.Message
+		err     error
+	)
+
+	if index == 0 {
+		message, err = s.session.ChannelMessageSendReply(s.channelID, chunk, s.reference)
+	} else {
+		message, err = s.session.ChannelMessageSend(s.channelID, chunk)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("send discord reply: %w", err)
+	}
+
+	return message, nil
+}
+
+// sendMessage sends content that is not a reply, such as a reminder, split
+// into as many messages as it takes.
+func sendMessage(session messenger, channelID string, content string) error {
+	for _, chunk := range split(content, messageLimit) {
+		_, err := session.ChannelMessageSend(channelID, chunk)
+		if err != nil {
+			return fmt.Errorf("send discord message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// split cuts text into chunks of at most limit characters, preferably at a
+// line break and otherwise at a space. A code block that spans chunks is
+// closed at the end of one and reopened, with its language, in the next.
+// Chunks only depend on the text before them, so a growing text keeps the
+// chunks it already filled.
+func split(text string, limit int) []string {
+	var (
+		chunks []string
+		open   string
+	)
+
+	closing := "\n" + codeFence
+	runes := []rune(text)
+
+	for len(runes) > 0 {
+		head := ""
+		if open != "" {
+			head = open + "\n"
+		}
+
+		headLength := len([]rune(head))
+		if headLength+len(runes) <= limit {
+			chunks = append(chunks, head+string(runes))
+
+			break
+		}
+
+		window := runes[:limit-headLength-len(closing)]
+		cut, skip := breakPoint(window)
+		chunk := string(runes[:cut])
+		runes = runes[cut+skip:]
+
+		open = fenceAfter(open, chunk)
+		if len([]rune(open)) > limit/4 {
+			// Reopening with a long info string would crowd out the text.
+			open = codeFence
+		}
+
+		if open != "" {
+			chunk += closing
+		}
+
+		chunks = append(chunks, head+chunk)
+	}
+
+	return chunks
+}
+
+// breakPoint returns where to cut window and how many characters to drop
+// there: the last line break, else the last space, else the whole window.
+func breakPoint(window []rune) (int, int) {
+	for _, separator := range []rune{'\n', ' '} {
+		for index := len(window) - 1; index > 0; index-- {
+			if window[index] == separator {
+				return index, 1
+			}
+		}
+	}
+
+	return len(window), 0
+}
+
+// fenceAfter returns the opening line of the code block still open after
+// chunk, given the one open before it, or "" if none is.
+func fenceAfter(open string, chunk string) string {
+	for line := range strings.SplitSeq(chunk, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, codeFence) {
+			continue
+		}
+
+		if open == "" {
+			open = line
+		} else {
+			open = ""
+		}
+	}
+
+	return open
+}
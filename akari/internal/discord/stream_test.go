@@ -0,0 +1,217 @@
+package discord
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const testChannelID = "channel"
+
+type fakeMessenger struct {
+	mu      sync.Mutex
+	calls   []string
+	content map[string]string
+}
+
+func newFakeMessenger() *fakeMessenger {
+	return &fakeMessenger{mu: sync.Mutex{}, calls: nil, content: map[string]string{}}
+}
+
+func (f *fakeMessenger) ChannelTyping(string, ...discordgo.RequestOption) error {
+	return nil
+}
+
+func (f *fakeMessenger) ChannelMessageSend(
+	channelID string,
+	content string,
+	_ ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	return f.send("send", channelID, content)
+}
+
+func (f *fakeMessenger) ChannelMessageSendReply(
+	channelID string,
+	content string,
+	_ *discordgo.MessageReference,
+	_ ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	return f.send("reply", channelID, content)
+}
+
+func (f *fakeMessenger) ChannelMessageEdit(
+	channelID string,
+	messageID string,
+	content string,
+	_ ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, "edit "+messageID)
+	f.content[messageID] = content
+
+	return &discordgo.Message{ID: messageID, ChannelID: channelID, Content: content}, nil
+}
+
+func (f *fakeMessenger) ChannelMessageDelete(_ string, messageID string, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, "delete "+messageID)
+	delete(f.content, messageID)
+
+	return nil
+}
+
+func (f *fakeMessenger) send(call string, channelID string, content string) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := strconv.Itoa(len(f.content))
+	f.calls = append(f.calls, call+" "+id)
+	f.content[id] = content
+
+	return &discordgo.Message{ID: id, ChannelID: channelID, Content: content}, nil
+}
+
+func TestReplyStream(t *testing.T) {
+	t.Parallel()
+
+	session := newFakeMessenger()
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	stream := newReplyStream(session, testChannelID, nil)
+	stream.now = func() time.Time { return now }
+
+	stream.Generating()
+	stream.Partial("Hello")
+	stream.Partial("Hello there") // throttled
+
+	now = now.Add(editInterval)
+	stream.Partial("Hello there, " + strings.Repeat("a", messageLimit-10))
+
+	sent, err := stream.Finish("Hello there, " + strings.Repeat("a", messageLimit-10) + " done")
+	if err != nil || !sent {
+		t.Fatalf("Finish() = %v, %v, want sent", sent, err)
+	}
+
+	want := []string{"reply 0", "edit 0", "send 1", "edit 1"}
+	if !slices.Equal(session.calls, want) {
+		t.Fatalf("calls = %v, want %v", session.calls, want)
+	}
+
+	if session.content["0"] != "Hello there," || session.content["1"] != strings.Repeat("a", messageLimit-10)+" done" {
+		t.Fatalf("content = %q, want the reply split after the comma", session.content)
+	}
+}
+
+func TestReplyStreamShrinks(t *testing.T) {
+	t.Parallel()
+
+	session := newFakeMessenger()
+
+	stream := newReplyStream(session, testChannelID, nil)
+	stream.Partial("Let me check, " + strings.Repeat("a", messageLimit))
+
+	sent, err := stream.Finish("Done.")
+	if err != nil || !sent {
+		t.Fatalf("Finish() = %v, %v, want sent", sent, err)
+	}
+
+	want := []string{"reply 0", "send 1", "edit 0", "delete 1"}
+	if !slices.Equal(session.calls, want) {
+		t.Fatalf("calls = %v, want %v", session.calls, want)
+	}
+
+	if len(session.content) != 1 || session.content["0"] != "Done." {
+		t.Fatalf("content = %q, want only the final reply", session.content)
+	}
+
+	if !slices.Equal(stream.messages, []string{"0"}) || !slices.Equal(stream.contents, []string{"Done."}) {
+		t.Fatalf("stream = %v %q, want one message", stream.messages, stream.contents)
+	}
+}
+
+func TestReplyStreamSkipped(t *testing.T) {
+	t.Parallel()
+
+	session := newFakeMessenger()
+
+	sent, err := newReplyStream(session, testChannelID, nil).Finish("")
+	if err != nil || sent {
+		t.Fatalf("Finish() = %v, %v, want nothing sent", sent, err)
+	}
+
+	if session.calls != nil {
+		t.Fatalf("calls = %v, want none", session.calls)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{name: "fits", text: "hello world", limit: 20, want: []string{"hello world"}},
+		{name: "line break", text: "hello world\nhow are you", limit: 20, want: []string{"hello world", "how are you"}},
+		{name: "space", text: "hello world how are you", limit: 20, want: []string{"hello world how", "are you"}},
+		{name: "no break", text: strings.Repeat("a", 20), limit: 10, want: []string{"aaaaaa", "aaaaaa", "aaaaaaaa"}},
+		{name: "runes", text: strings.Repeat("あ", 12), limit: 10, want: []string{"ああああああ", "ああああああ"}},
+		{
+			name:  "code block",
+			text:  "look:\n```go\nfmt.Println(1)\nfmt.Println(2)\n```\ndone",
+			limit: 36,
+			want: []string{
+				"look:\n```go\nfmt.Println(1)\n```",
+				"```go\nfmt.Println(2)\n```\ndone",
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := split(testCase.text, testCase.limit)
+			if !slices.Equal(got, testCase.want) {
+				t.Fatalf("split() = %q, want %q", got, testCase.want)
+			}
+
+			for _, chunk := range got {
+				if length := len([]rune(chunk)); length > testCase.limit {
+					t.Fatalf("split() chunk %q has %d characters, over %d", chunk, length, testCase.limit)
+				}
+			}
+		})
+	}
+}
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+
+	session := newFakeMessenger()
+
+	err := sendMessage(session, testChannelID, strings.Repeat("a", messageLimit-10)+" and the rest")
+	if err != nil {
+		t.Fatalf("sendMessage() error = %v", err)
+	}
+
+	want := []string{"send 0", "send 1"}
+	if !slices.Equal(session.calls, want) {
+		t.Fatalf("calls = %v, want %v", session.calls, want)
+	}
+
+	if session.content["1"] != "the rest" {
+		t.Fatalf("content = %q, want the rest in a second message", session.content)
+	}
+}
@@ -0,0 +1,196 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/grpchealth"
+	"go.uber.org/fx"
+)
+
+const (
+	// Liveness is the service name probed to check that the process is up.
+	Liveness = "liveness"
+	// Readiness is the service name probed to check that every dependency is
+	// reachable. The empty service name reports the same status.
+	Readiness = "readiness"
+
+	checkInterval = 10 * time.Second
+	probeTimeout  = 3 * time.Second
+)
+
+// Check probes one dependency. While it fails the server is not ready, and
+// so are the Services listed as depending on it.
+type Check struct {
+	Name     string
+	Probe    func(ctx context.Context) error
+	Services []string
+}
+
+// Result is the outcome of a check's most recent probe.
+type Result struct {
+	Name string
+	Err  error
+}
+
+type Params struct {
+	fx.In
+
+	Checks []Check `group:"health_checks"`
+}
+
+// Checker periodically probes the dependencies and keeps the serving status
+// of each service for the health endpoints. It is the grpchealth.Checker of
+// the grpc.health.v1 service.
+type Checker struct {
+	checks   []Check
+	services []string
+
+	mu        sync.Mutex
+	evaluated bool
+	results   []Result
+}
+
+var _ grpchealth.Checker = (*Checker)(nil)
+
+func NewChecker(params Params) *Checker {
+	return newChecker(params.Checks, services)
+}
+
+func newChecker(checks []Check, services []string) *Checker {
+	return &Checker{
+		checks:    checks,
+		services:  services,
+		mu:        sync.Mutex{},
+		evaluated: false,
+		results:   nil,
+	}
+}
+
+func RegisterLifecycle(lc fx.Lifecycle, checker *Checker) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+
+				checker.Run(ctx)
+			}()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+				return fmt.Errorf("stop health checker: %w", stopCtx.Err())
+			}
+
+			return nil
+		},
+	})
+}
+
+// Run probes the dependencies immediately and then every checkInterval until
+// ctx is cancelled.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		c.Evaluate(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Evaluate probes every dependency once.
+func (c *Checker) Evaluate(ctx context.Context) {
+	results := make([]Result, 0, len(c.checks))
+
+	for _, check := range c.checks {
+		probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+		err := check.Probe(probeCtx)
+
+		cancel()
+
+		if err != nil {
+			slog.WarnContext(ctx, "health check failed", "check", check.Name, "error", err)
+		}
+
+		results = append(results, Result{Name: check.Name, Err: err})
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.evaluated = true
+	c.results = results
+}
+
+// Results returns the outcome of the most recent probes, or nil before the
+// first evaluation.
+func (c *Checker) Results() []Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.results)
+}
+
+// Check answers grpc.health.v1 Check requests with the status of the
+// requested service, and NotFound for services the server does not know.
+func (c *Checker) Check(_ context.Context, req *grpchealth.CheckRequest) (*grpchealth.CheckResponse, error) {
+	status, known := c.Status(req.Service)
+	if !known {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("%w: %s", ErrUnknownService, req.Service))
+	}
+
+	return &grpchealth.CheckResponse{Status: status}, nil
+}
+
+// Status reports the serving status of service. It returns false for
+// services the server does not know about.
+func (c *Checker) Status(service string) (grpchealth.Status, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case service == Liveness:
+		return grpchealth.StatusServing, true
+	case service == "" || service == Readiness:
+		return c.status(func(Check) bool { return true }), true
+	case slices.Contains(c.services, service):
+		return c.status(func(check Check) bool { return slices.Contains(check.Services, service) }), true
+	default:
+		return grpchealth.StatusUnknown, false
+	}
+}
+
+// status is SERVING when none of the checks selected by depends failed in
+// the latest evaluation. Callers must hold c.mu.
+func (c *Checker) status(depends func(Check) bool) grpchealth.Status {
+	if !c.evaluated {
+		return grpchealth.StatusNotServing
+	}
+
+	for i, result := range c.results {
+		if result.Err != nil && depends(c.checks[i]) {
+			return grpchealth.StatusNotServing
+		}
+	}
+
+	return grpchealth.StatusServing
+}
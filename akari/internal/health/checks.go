@@ -0,0 +1,47 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/kizuna-org/akari/gen/proto/akari/v1/akariv1connect"
+	"github.com/kizuna-org/akari/internal/discord"
+)
+
+// services lists the Connect services whose status can be checked by name.
+var services = []string{
+	akariv1connect.CharacterServiceName,
+	akariv1connect.ConversationServiceName,
+	akariv1connect.AdminServiceName,
+	akariv1connect.CharacterAdminServiceName,
+	akariv1connect.AccountServiceName,
+}
+
+// NewDatabaseCheck pings Postgres. The admin and account services persist
+// state there, so they are not serving without the database.
+func NewDatabaseCheck(db *sql.DB) Check {
+	return Check{
+		Name: "database",
+		Probe: func(ctx context.Context) error {
+			err := db.PingContext(ctx)
+			if err != nil {
+				return fmt.Errorf("ping database: %w", err)
+			}
+
+			return nil
+		},
+		Services: []string{
+			akariv1connect.ConversationServiceName,
+			akariv1connect.AdminServiceName,
+			akariv1connect.CharacterAdminServiceName,
+			akariv1connect.AccountServiceName,
+		},
+	}
+}
+
+// NewDiscordCheck requires the gateway session of every enabled bot to be
+// established. It always passes when every Discord bot is disabled.
+func NewDiscordCheck(bots *discord.Bots) Check {
+	return Check{Name: "discord", Probe: bots.Ready, Services: nil}
+}
@@ -0,0 +1,93 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/grpchealth"
+	"github.com/kizuna-org/akari/internal/server"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// ServiceName is the fully-qualified name of the standard gRPC health
+	// service.
+	ServiceName = grpchealth.HealthV1ServiceName
+
+	// legacyProcedure predates grpc.health.v1 and is kept for existing
+	// monitors. It fails while the server is not ready.
+	legacyProcedure = "/akari.v1.HealthService/Check"
+	livenessPath    = "/healthz"
+	readinessPath   = "/readyz"
+)
+
+var (
+	ErrUnknownService = errors.New("unknown service")
+	ErrNotReady       = errors.New("not ready")
+)
+
+// NewRoutes mounts grpc.health.v1.Health, served by grpchealth, along with
+// plain HTTP liveness and readiness endpoints for container orchestrators.
+// grpchealth does not support Watch, so monitors poll Check.
+func NewRoutes(checker *Checker) []server.Route {
+	path, handler := grpchealth.NewHandler(checker)
+
+	return []server.Route{
+		{Path: path, Handler: handler},
+		{Path: legacyProcedure, Handler: connect.NewUnaryHandler(legacyProcedure, legacyCheck(checker))},
+		{Path: livenessPath, Handler: http.HandlerFunc(serveLiveness)},
+		{Path: readinessPath, Handler: readinessHandler(checker)},
+	}
+}
+
+func legacyCheck(
+	checker *Checker,
+) func(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+	return func(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+		status, _ := checker.Status(Readiness)
+		if status != grpchealth.StatusServing {
+			return nil, connect.NewError(connect.CodeUnavailable, ErrNotReady)
+		}
+
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+}
+
+func serveLiveness(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// readinessHandler answers 200 when every check passed its latest probe and
+// 503 otherwise, listing each check's outcome in the body.
+func readinessHandler(checker *Checker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		status, _ := checker.Status(Readiness)
+
+		var body strings.Builder
+
+		for _, result := range checker.Results() {
+			if result.Err != nil {
+				fmt.Fprintf(&body, "%s: %v\n", result.Name, result.Err)
+			} else {
+				fmt.Fprintf(&body, "%s: ok\n", result.Name)
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+		if status != grpchealth.StatusServing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			if body.Len() == 0 {
+				body.WriteString("not checked yet\n")
+			}
+		}
+
+		_, _ = w.Write([]byte(body.String()))
+	})
+}
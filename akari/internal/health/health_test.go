@@ -0,0 +1,262 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/kizuna-org/akari/internal/server"
+)
+
+const (
+	testService  = "akari.v1.TestService"
+	otherService = "akari.v1.OtherService"
+)
+
+var errDown = errors.New("down")
+
+type probe struct {
+	err error
+}
+
+func (p *probe) check(context.Context) error {
+	return p.err
+}
+
+func newTestChecker() (*Checker, *probe, *probe) {
+	database := new(probe)
+	gateway := new(probe)
+
+	checker := newChecker([]Check{
+		{Name: "database", Probe: database.check, Services: []string{testService}},
+		{Name: "discord", Probe: gateway.check, Services: nil},
+	}, []string{testService, otherService})
+
+	return checker, database, gateway
+}
+
+func newTestServer(t *testing.T, checker *Checker) *httptest.Server {
+	t.Helper()
+
+	httpServer := httptest.NewServer(server.NewMux(NewRoutes(checker)))
+	t.Cleanup(httpServer.Close)
+
+	return httpServer
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		evaluate    bool
+		databaseErr error
+		gatewayErr  error
+		service     string
+		want        string
+		wantCode    connect.Code
+	}{
+		{
+			name:    "liveness before first evaluation",
+			service: Liveness,
+			want:    "SERVING_STATUS_SERVING",
+		},
+		{
+			name:    "readiness before first evaluation",
+			service: Readiness,
+			want:    "SERVING_STATUS_NOT_SERVING",
+		},
+		{
+			name:     "all checks pass",
+			evaluate: true,
+			service:  "",
+			want:     "SERVING_STATUS_SERVING",
+		},
+		{
+			name:       "gateway down fails readiness",
+			evaluate:   true,
+			gatewayErr: errDown,
+			service:    Readiness,
+			want:       "SERVING_STATUS_NOT_SERVING",
+		},
+		{
+			name:       "gateway down keeps services serving",
+			evaluate:   true,
+			gatewayErr: errDown,
+			service:    testService,
+			want:       "SERVING_STATUS_SERVING",
+		},
+		{
+			name:        "database down fails dependent service",
+			evaluate:    true,
+			databaseErr: errDown,
+			service:     testService,
+			want:        "SERVING_STATUS_NOT_SERVING",
+		},
+		{
+			name:        "database down keeps other service serving",
+			evaluate:    true,
+			databaseErr: errDown,
+			service:     otherService,
+			want:        "SERVING_STATUS_SERVING",
+		},
+		{
+			name:        "liveness ignores failed checks",
+			evaluate:    true,
+			databaseErr: errDown,
+			service:     Liveness,
+			want:        "SERVING_STATUS_SERVING",
+		},
+		{
+			name:     "unknown service",
+			evaluate: true,
+			service:  "akari.v1.Missing",
+			wantCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker, database, gateway := newTestChecker()
+			database.err = testCase.databaseErr
+			gateway.err = testCase.gatewayErr
+
+			if testCase.evaluate {
+				checker.Evaluate(t.Context())
+			}
+
+			got, code := check(t, newTestServer(t, checker).URL, testCase.service)
+			if code != testCase.wantCode {
+				t.Fatalf("Check() code = %v, want %v", code, testCase.wantCode)
+			}
+
+			if got != testCase.want {
+				t.Errorf("Check() status = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+// check calls grpc.health.v1.Health/Check over the Connect protocol with
+// JSON, as curl would, and returns the status or the error code.
+func check(t *testing.T, url string, service string) (string, connect.Code) {
+	t.Helper()
+
+	body := strings.NewReader(`{"service":"` + service + `"}`)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, url+"/"+ServiceName+"/Check", body)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Status string `json:"status"`
+		Code   string `json:"code"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&payload)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	var code connect.Code
+	if payload.Code != "" {
+		err = code.UnmarshalText([]byte(payload.Code))
+		if err != nil {
+			t.Fatalf("UnmarshalText(%q) error = %v", payload.Code, err)
+		}
+	}
+
+	return payload.Status, code
+}
+
+func TestHTTPEndpoints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		path       string
+		evaluate   bool
+		gatewayErr error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "liveness", path: livenessPath, wantStatus: http.StatusOK, wantBody: "ok"},
+		{
+			name:       "readiness before first evaluation",
+			path:       readinessPath,
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "not checked yet",
+		},
+		{
+			name:       "ready",
+			path:       readinessPath,
+			evaluate:   true,
+			wantStatus: http.StatusOK,
+			wantBody:   "discord: ok",
+		},
+		{
+			name:       "not ready",
+			path:       readinessPath,
+			evaluate:   true,
+			gatewayErr: errDown,
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "discord: down",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker, _, gateway := newTestChecker()
+			gateway.err = testCase.gatewayErr
+
+			if testCase.evaluate {
+				checker.Evaluate(t.Context())
+			}
+
+			httpServer := newTestServer(t, checker)
+
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, httpServer.URL+testCase.path, nil)
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v", err)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+
+			if resp.StatusCode != testCase.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, testCase.wantStatus)
+			}
+
+			if !strings.Contains(string(body), testCase.wantBody) {
+				t.Errorf("body = %q, want it to contain %q", body, testCase.wantBody)
+			}
+		})
+	}
+}
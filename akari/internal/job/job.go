@@ -0,0 +1,78 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownKind = errors.New("unknown job kind")
+	// ErrLeaseLost is returned when a job is settled by an instance whose
+	// lease expired, so another instance may be running it.
+	ErrLeaseLost = errors.New("job lease lost")
+)
+
+// Job is work to run at RunAt. Payload is the JSON the job's handler
+// decodes, and Attempts counts the runs leased so far.
+type Job struct {
+	ID        uuid.UUID
+	Kind      string
+	Payload   json.RawMessage
+	RunAt     time.Time
+	Attempts  int
+	LastError string
+}
+
+// Store keeps the scheduled jobs. A job is leased to one instance at a time
+// and settled by that instance only.
+type Store interface {
+	// Create schedules a job, assigning its ID.
+	Create(ctx context.Context, job Job) (Job, error)
+	// Lease claims up to limit pending jobs due at now whose lease is free
+	// or expired, counting an attempt for each.
+	Lease(ctx context.Context, owner string, now time.Time, ttl time.Duration, limit int) ([]Job, error)
+	// Complete marks a leased job done.
+	Complete(ctx context.Context, id uuid.UUID, owner string) error
+	// Retry releases a leased job to run again at runAt.
+	Retry(ctx context.Context, id uuid.UUID, owner string, runAt time.Time, cause string) error
+	// Fail marks a leased job failed for good.
+	Fail(ctx context.Context, id uuid.UUID, owner string, cause string) error
+}
+
+// Queue schedules jobs. It is separate from the Scheduler so that the
+// features creating jobs need not depend on the handlers running them.
+type Queue struct {
+	store Store
+}
+
+func NewQueue(store Store) *Queue {
+	return &Queue{store: store}
+}
+
+// Enqueue schedules a job of the kind to run at runAt with the payload
+// encoded as JSON.
+func (q *Queue) Enqueue(ctx context.Context, kind string, payload any, runAt time.Time) (uuid.UUID, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("encode %s job payload: %w", kind, err)
+	}
+
+	created, err := q.store.Create(ctx, Job{
+		ID:        uuid.Nil,
+		Kind:      kind,
+		Payload:   data,
+		RunAt:     runAt,
+		Attempts:  0,
+		LastError: "",
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return created.ID, nil
+}
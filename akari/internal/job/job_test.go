@@ -0,0 +1,157 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	entjob "github.com/kizuna-org/akari/gen/ent/job"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var errFlaky = errors.New("flaky")
+
+func testScheduler(t *testing.T, store Store, now *time.Time) *Scheduler {
+	t.Helper()
+
+	scheduler, err := NewScheduler(store, prometheus.NewRegistry(), []Handler{
+		{Kind: "ok", Timeout: 0, Run: func(context.Context, json.RawMessage) error { return nil }},
+		{Kind: "flaky", Timeout: 0, Run: func(context.Context, json.RawMessage) error { return errFlaky }},
+	})
+	if err != nil {
+		t.Fatalf("NewScheduler() error = %v", err)
+	}
+
+	scheduler.now = func() time.Time { return *now }
+
+	return scheduler
+}
+
+func enqueue(t *testing.T, store Store, kind string, runAt time.Time) uuid.UUID {
+	t.Helper()
+
+	id, err := NewQueue(store).Enqueue(t.Context(), kind, map[string]string{"a": "b"}, runAt)
+	if err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	return id
+}
+
+func stored(store *MemoryStore, id uuid.UUID) storedJob {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	return *store.jobs[id]
+}
+
+func TestNewScheduler(t *testing.T) {
+	t.Parallel()
+
+	handler := Handler{Kind: "ok", Timeout: 0, Run: func(context.Context, json.RawMessage) error { return nil }}
+
+	_, err := NewScheduler(NewMemoryStore(), prometheus.NewRegistry(), []Handler{handler, handler})
+	if err == nil {
+		t.Fatal("NewScheduler() with two handlers of a kind error = nil, want an error")
+	}
+}
+
+func TestSchedulerRunDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	scheduler := testScheduler(t, store, &now)
+
+	done := enqueue(t, store, "ok", now)
+	flaky := enqueue(t, store, "flaky", now.Add(-time.Minute))
+	unknown := enqueue(t, store, "unknown", now)
+	later := enqueue(t, store, "ok", now.Add(time.Hour))
+
+	scheduler.RunDue(t.Context())
+
+	tests := []struct {
+		name         string
+		id           uuid.UUID
+		wantStatus   entjob.Status
+		wantAttempts int
+		wantRunAt    time.Time
+	}{
+		{name: "done", id: done, wantStatus: entjob.StatusDone, wantAttempts: 1, wantRunAt: now},
+		{name: "retried", id: flaky, wantStatus: entjob.StatusPending, wantAttempts: 1, wantRunAt: now.Add(retryBackoff)},
+		{name: "unknown kind", id: unknown, wantStatus: entjob.StatusFailed, wantAttempts: 1, wantRunAt: now},
+		{name: "not due", id: later, wantStatus: entjob.StatusPending, wantAttempts: 0, wantRunAt: now.Add(time.Hour)},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := stored(store, testCase.id)
+			if got.status != testCase.wantStatus || got.job.Attempts != testCase.wantAttempts ||
+				!got.job.RunAt.Equal(testCase.wantRunAt) || got.leaseOwner != "" {
+				t.Fatalf("job = %+v, want %s after %d attempts to run at %s, unleased",
+					got, testCase.wantStatus, testCase.wantAttempts, testCase.wantRunAt)
+			}
+		})
+	}
+}
+
+func TestSchedulerGivesUp(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	scheduler := testScheduler(t, store, &now)
+
+	id := enqueue(t, store, "flaky", now)
+
+	for range maxAttempts {
+		scheduler.RunDue(t.Context())
+
+		now = stored(store, id).job.RunAt
+	}
+
+	got := stored(store, id)
+	if got.status != entjob.StatusFailed || got.job.Attempts != maxAttempts || got.job.LastError != errFlaky.Error() {
+		t.Fatalf("job = %+v, want failed after %d attempts with %q", got, maxAttempts, errFlaky)
+	}
+}
+
+func TestMemoryStoreLease(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	id := enqueue(t, store, "ok", now)
+
+	leased, _ := store.Lease(t.Context(), "first", now, time.Minute, batchSize)
+	if len(leased) != 1 || leased[0].ID != id {
+		t.Fatalf("Lease() = %+v, want the due job", leased)
+	}
+
+	leased, _ = store.Lease(t.Context(), "second", now, time.Minute, batchSize)
+	if len(leased) != 0 {
+		t.Fatalf("Lease() of a leased job = %+v, want none", leased)
+	}
+
+	// Once the first lease expires the job is leased again, and only the
+	// new owner may settle it.
+	leased, _ = store.Lease(t.Context(), "second", now.Add(2*time.Minute), time.Minute, batchSize)
+	if len(leased) != 1 || leased[0].Attempts != 2 {
+		t.Fatalf("Lease() after the lease expired = %+v, want the job on its second attempt", leased)
+	}
+
+	err := store.Complete(t.Context(), id, "first")
+	if !errors.Is(err, ErrLeaseLost) {
+		t.Fatalf("Complete() by the first owner error = %v, want %v", err, ErrLeaseLost)
+	}
+
+	err = store.Complete(t.Context(), id, "second")
+	if err != nil {
+		t.Fatalf("Complete() by the second owner error = %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kizuna-org/akari/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+)
+
+const (
+	pollInterval = 5 * time.Second
+	// batchSize is how many due jobs an instance leases per poll.
+	batchSize = 10
+	// defaultTimeout bounds a run of a job whose handler has no timeout of
+	// its own. It must stay well below leaseTTL, or another instance could
+	// lease a job that is still running.
+	defaultTimeout = time.Minute
+	leaseTTL       = 5 * time.Minute
+	// maxAttempts is how many times a job runs before it fails for good.
+	maxAttempts = 5
+	// retryBackoff is the delay before the first retry, doubled for each
+	// further one.
+	retryBackoff = 30 * time.Second
+
+	tracerName = "github.com/kizuna-org/akari/internal/job"
+)
+
+// Handler runs the jobs of one kind. Timeout bounds each run, a minute
+// when zero. A run that returns an error is retried with backoff.
+type Handler struct {
+	Kind    string
+	Timeout time.Duration
+	Run     func(ctx context.Context, payload json.RawMessage) error
+}
+
+// Scheduler runs due jobs with the handler of their kind. Every instance
+// polls the store, and a job is leased to one of them while it runs.
+type Scheduler struct {
+	store        Store
+	handlers     map[string]Handler
+	owner        string
+	pollInterval time.Duration
+	now          func() time.Time
+	runs         *prometheus.CounterVec
+	tracer       trace.Tracer
+}
+
+// NewScheduler checks that the handlers have distinct kinds.
+func NewScheduler(store Store, registry *prometheus.Registry, handlers []Handler) (*Scheduler, error) {
+	runs, err := metrics.Register(registry, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "job",
+		Name:      "runs_total",
+		Help:      "Scheduled job runs, by kind and outcome (done, retry or failed).",
+	}, []string{"kind", "outcome"}))
+	if err != nil {
+		return nil, err
+	}
+
+	scheduler := &Scheduler{
+		store:        store,
+		handlers:     make(map[string]Handler, len(handlers)),
+		owner:        newOwner(),
+		pollInterval: pollInterval,
+		now:          time.Now,
+		runs:         runs,
+		tracer:       otel.Tracer(tracerName),
+	}
+
+	for _, handler := range handlers {
+		if _, ok := scheduler.handlers[handler.Kind]; ok {
+			return nil, fmt.Errorf("job kind %q has two handlers", handler.Kind)
+		}
+
+		scheduler.handlers[handler.Kind] = handler
+	}
+
+	return scheduler, nil
+}
+
+// newOwner names this instance in the leases it holds.
+func newOwner() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "akari"
+	}
+
+	return host + "-" + uuid.NewString()[:8]
+}
+
+func RegisterLifecycle(lc fx.Lifecycle, scheduler *Scheduler) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+
+				scheduler.Run(ctx)
+			}()
+
+			slog.Info("job scheduler started", "owner", scheduler.owner)
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+				return fmt.Errorf("stop job scheduler: %w", stopCtx.Err())
+			}
+
+			slog.Info("job scheduler stopped", "owner", scheduler.owner)
+
+			return nil
+		},
+	})
+}
+
+// Run polls for due jobs and runs them until ctx is cancelled. A job
+// interrupted by the cancellation keeps its lease and runs again once the
+// lease expires.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.RunDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue leases the jobs due now and runs them one by one.
+func (s *Scheduler) RunDue(ctx context.Context) {
+	jobs, err := s.store.Lease(ctx, s.owner, s.now(), leaseTTL, batchSize)
+	if err != nil {
+		slog.ErrorContext(ctx, "lease due jobs failed", "error", err)
+	}
+
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.run(ctx, job)
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	ctx, span := s.tracer.Start(ctx, "job.Run", trace.WithAttributes(
+		attribute.String("job.id", job.ID.String()),
+		attribute.String("job.kind", job.Kind),
+		attribute.Int("job.attempt", job.Attempts),
+	))
+	defer span.End()
+
+	err := s.handle(ctx, job)
+	if err == nil {
+		s.settle(ctx, job, "done", s.store.Complete(ctx, job.ID, s.owner))
+
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	if errors.Is(err, ErrUnknownKind) || job.Attempts >= maxAttempts {
+		slog.ErrorContext(ctx, "job failed", "job_id", job.ID, "kind", job.Kind, "attempts", job.Attempts, "error", err)
+		s.settle(ctx, job, "failed", s.store.Fail(ctx, job.ID, s.owner, err.Error()))
+
+		return
+	}
+
+	runAt := s.now().Add(retryBackoff << (job.Attempts - 1))
+
+	slog.WarnContext(ctx, "job failed, retrying", "job_id", job.ID, "kind", job.Kind,
+		"attempts", job.Attempts, "retry_at", runAt, "error", err)
+	s.settle(ctx, job, "retry", s.store.Retry(ctx, job.ID, s.owner, runAt, err.Error()))
+}
+
+func (s *Scheduler) handle(ctx context.Context, job Job) error {
+	handler, ok := s.handlers[job.Kind]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownKind, job.Kind)
+	}
+
+	timeout := handler.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return handler.Run(ctx, job.Payload)
+}
+
+func (s *Scheduler) settle(ctx context.Context, job Job, outcome string, err error) {
+	if err != nil {
+		slog.ErrorContext(ctx, "settle job failed", "job_id", job.ID, "outcome", outcome, "error", err)
+
+		return
+	}
+
+	s.runs.WithLabelValues(job.Kind, outcome).Inc()
+}
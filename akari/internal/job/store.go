@@ -0,0 +1,237 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kizuna-org/akari/gen/ent"
+	entjob "github.com/kizuna-org/akari/gen/ent/job"
+	"github.com/kizuna-org/akari/gen/ent/predicate"
+)
+
+func NewStore(client *ent.Client) Store {
+	return NewEntStore(client)
+}
+
+// EntStore persists jobs in Postgres. A lease is claimed with an update
+// conditioned on the lease being free, so of several instances leasing the
+// same job only one updates it.
+type EntStore struct {
+	client *ent.Client
+}
+
+func NewEntStore(client *ent.Client) *EntStore {
+	return &EntStore{client: client}
+}
+
+func (s *EntStore) Create(ctx context.Context, job Job) (Job, error) {
+	row, err := s.client.Job.Create().
+		SetKind(job.Kind).
+		SetPayload(string(job.Payload)).
+		SetRunAt(job.RunAt.UTC()).
+		Save(ctx)
+	if err != nil {
+		return Job{}, fmt.Errorf("create %s job: %w", job.Kind, err)
+	}
+
+	return fromEnt(row), nil
+}
+
+func (s *EntStore) Lease(
+	ctx context.Context,
+	owner string,
+	now time.Time,
+	ttl time.Duration,
+	limit int,
+) ([]Job, error) {
+	rows, err := s.client.Job.Query().
+		Where(entjob.StatusEQ(entjob.StatusPending), entjob.RunAtLTE(now), leaseFree(now)).
+		Order(ent.Asc(entjob.FieldRunAt), ent.Asc(entjob.FieldID)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list due jobs: %w", err)
+	}
+
+	leased := make([]Job, 0, len(rows))
+
+	for _, row := range rows {
+		claimed, err := s.client.Job.Update().
+			Where(entjob.ID(row.ID), entjob.StatusEQ(entjob.StatusPending), leaseFree(now)).
+			SetLeaseOwner(owner).
+			SetLeaseExpiresAt(now.Add(ttl)).
+			AddAttempts(1).
+			Save(ctx)
+		if err != nil {
+			return leased, fmt.Errorf("lease job %s: %w", row.ID, err)
+		}
+
+		// Another instance claimed it first.
+		if claimed == 0 {
+			continue
+		}
+
+		job := fromEnt(row)
+		job.Attempts++
+		leased = append(leased, job)
+	}
+
+	return leased, nil
+}
+
+func (s *EntStore) Complete(ctx context.Context, id uuid.UUID, owner string) error {
+	return s.settle(ctx, id, owner, s.client.Job.Update().
+		SetStatus(entjob.StatusDone))
+}
+
+func (s *EntStore) Retry(ctx context.Context, id uuid.UUID, owner string, runAt time.Time, cause string) error {
+	return s.settle(ctx, id, owner, s.client.Job.Update().
+		SetRunAt(runAt.UTC()).
+		SetLastError(cause))
+}
+
+func (s *EntStore) Fail(ctx context.Context, id uuid.UUID, owner string, cause string) error {
+	return s.settle(ctx, id, owner, s.client.Job.Update().
+		SetStatus(entjob.StatusFailed).
+		SetLastError(cause))
+}
+
+// settle applies update to a job leased to owner and releases its lease.
+func (s *EntStore) settle(ctx context.Context, id uuid.UUID, owner string, update *ent.JobUpdate) error {
+	updated, err := update.
+		Where(entjob.ID(id), entjob.StatusEQ(entjob.StatusPending), entjob.LeaseOwnerEQ(owner)).
+		SetLeaseOwner("").
+		ClearLeaseExpiresAt().
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("settle job %s: %w", id, err)
+	}
+
+	if updated == 0 {
+		return fmt.Errorf("%w: %s", ErrLeaseLost, id)
+	}
+
+	return nil
+}
+
+func leaseFree(now time.Time) predicate.Job {
+	return entjob.Or(entjob.LeaseExpiresAtIsNil(), entjob.LeaseExpiresAtLT(now))
+}
+
+func fromEnt(row *ent.Job) Job {
+	return Job{
+		ID:        row.ID,
+		Kind:      row.Kind,
+		Payload:   []byte(row.Payload),
+		RunAt:     row.RunAt,
+		Attempts:  row.Attempts,
+		LastError: row.LastError,
+	}
+}
+
+// MemoryStore keeps jobs in memory, for tests and tools that run without a
+// database.
+type MemoryStore struct {
+	mu   sync.Mutex
+	jobs map[uuid.UUID]*storedJob
+}
+
+type storedJob struct {
+	job          Job
+	status       entjob.Status
+	leaseOwner   string
+	leaseExpires time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{mu: sync.Mutex{}, jobs: map[uuid.UUID]*storedJob{}}
+}
+
+func (s *MemoryStore) Create(_ context.Context, job Job) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job.ID = uuid.Must(uuid.NewV7())
+	job.Payload = slices.Clone(job.Payload)
+	s.jobs[job.ID] = &storedJob{job: job, status: entjob.StatusPending, leaseOwner: "", leaseExpires: time.Time{}}
+
+	return job, nil
+}
+
+func (s *MemoryStore) Lease(
+	_ context.Context,
+	owner string,
+	now time.Time,
+	ttl time.Duration,
+	limit int,
+) ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []*storedJob
+
+	for _, stored := range s.jobs {
+		if stored.status == entjob.StatusPending && !stored.job.RunAt.After(now) && !stored.leaseExpires.After(now) {
+			due = append(due, stored)
+		}
+	}
+
+	slices.SortFunc(due, func(a, b *storedJob) int {
+		if c := a.job.RunAt.Compare(b.job.RunAt); c != 0 {
+			return c
+		}
+
+		return bytes.Compare(a.job.ID[:], b.job.ID[:])
+	})
+
+	leased := make([]Job, 0, min(len(due), limit))
+	for _, stored := range due[:min(len(due), limit)] {
+		stored.leaseOwner = owner
+		stored.leaseExpires = now.Add(ttl)
+		stored.job.Attempts++
+		leased = append(leased, stored.job)
+	}
+
+	return leased, nil
+}
+
+func (s *MemoryStore) Complete(_ context.Context, id uuid.UUID, owner string) error {
+	return s.settle(id, owner, func(stored *storedJob) {
+		stored.status = entjob.StatusDone
+	})
+}
+
+func (s *MemoryStore) Retry(_ context.Context, id uuid.UUID, owner string, runAt time.Time, cause string) error {
+	return s.settle(id, owner, func(stored *storedJob) {
+		stored.job.RunAt = runAt
+		stored.job.LastError = cause
+	})
+}
+
+func (s *MemoryStore) Fail(_ context.Context, id uuid.UUID, owner string, cause string) error {
+	return s.settle(id, owner, func(stored *storedJob) {
+		stored.status = entjob.StatusFailed
+		stored.job.LastError = cause
+	})
+}
+
+func (s *MemoryStore) settle(id uuid.UUID, owner string, update func(*storedJob)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.jobs[id]
+	if !ok || stored.status != entjob.StatusPending || stored.leaseOwner != owner {
+		return fmt.Errorf("%w: %s", ErrLeaseLost, id)
+	}
+
+	update(stored)
+	stored.leaseOwner = ""
+	stored.leaseExpires = time.Time{}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package kiseki
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/tracing"
+)
+
+const (
+	SleepPending   SleepState = "pending"
+	SleepRunning   SleepState = "running"
+	SleepCompleted SleepState = "completed"
+	SleepFailed    SleepState = "failed"
+
+	contentTypeJSON = "application/json"
+	maxErrorBody    = 4096
+)
+
+var ErrDisabled = errors.New("kiseki is not configured")
+
+type Meta struct {
+	MemorizedAt time.Time `json:"memorized_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type SleepState string
+
+type SleepStatus struct {
+	Status    SleepState `json:"status"`
+	Message   string     `json:"message"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("kiseki responded %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+}
+
+func NewClient(cfg config.Config) (*Client, error) {
+	if cfg.Kiseki.URL == "" {
+		return &Client{baseURL: nil, httpClient: nil}, nil
+	}
+
+	baseURL, err := url.Parse(cfg.Kiseki.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parse KISEKI_URL: %w", err)
+	}
+
+	httpClient := new(http.Client)
+	httpClient.Timeout = cfg.Kiseki.Timeout
+	// Propagates the trace context so kiseki's spans join akari's traces.
+	httpClient.Transport = tracing.NewTransport(http.DefaultTransport)
+
+	return &Client{baseURL: baseURL, httpClient: httpClient}, nil
+}
+
+func (c *Client) Enabled() bool {
+	return c.baseURL != nil
+}
+
+func (c *Client) GetMemory(ctx context.Context, characterID string, dType DType, data string) ([]Fragment, error) {
+	query := url.Values{}
+	query.Set("dType", string(dType))
+	query.Set("data", data)
+
+	var body struct {
+		Items []Fragment `json:"items"`
+	}
+
+	err := c.do(ctx, http.MethodGet, c.endpoint(memoryPath(characterID), query), nil, &body)
+	if err != nil {
+		return nil, fmt.Errorf("get memory: %w", err)
+	}
+
+	return body.Items, nil
+}
+
+// PutMemory stores data, of the type dType selects, after checking that it
+// has that type's schema.
+func (c *Client) PutMemory(ctx context.Context, characterID string, dType DType, data any) error {
+	err := Validate(dType, data)
+	if err != nil {
+		return fmt.Errorf("put memory: %w", err)
+	}
+
+	request := struct {
+		DType DType `json:"dType"`
+		Data  any   `json:"data"`
+	}{DType: dType, Data: data}
+
+	err = c.do(ctx, http.MethodPut, c.endpoint(memoryPath(characterID), nil), request, nil)
+	if err != nil {
+		return fmt.Errorf("put memory: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) DeleteMemory(ctx context.Context, characterID string, dType DType, data string) error {
+	query := url.Values{}
+	query.Set("dType", string(dType))
+	query.Set("data", data)
+
+	err := c.do(ctx, http.MethodDelete, c.endpoint(memoryPath(characterID), query), nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete memory: %w", err)
+	}
+
+	return nil
+}
+
+// Sleep starts memory consolidation for a character and returns the URL to
+// poll with SleepStatus.
+func (c *Client) Sleep(ctx context.Context, characterID string) (string, error) {
+	var body struct {
+		PollingURL string `json:"pollingUrl"`
+	}
+
+	path := "/characters/" + url.PathEscape(characterID) + "/sleep"
+
+	err := c.do(ctx, http.MethodPost, c.endpoint(path, nil), nil, &body)
+	if err != nil {
+		return "", fmt.Errorf("sleep: %w", err)
+	}
+
+	return body.PollingURL, nil
+}
+
+func (c *Client) SleepStatus(ctx context.Context, pollingURL string) (SleepStatus, error) {
+	var status SleepStatus
+
+	if !c.Enabled() {
+		return status, ErrDisabled
+	}
+
+	ref, err := url.Parse(pollingURL)
+	if err != nil {
+		return status, fmt.Errorf("parse polling url: %w", err)
+	}
+
+	err = c.do(ctx, http.MethodGet, c.baseURL.ResolveReference(ref), nil, &status)
+	if err != nil {
+		return status, fmt.Errorf("get sleep status: %w", err)
+	}
+
+	return status, nil
+}
+
+func (c *Client) endpoint(path string, query url.Values) *url.URL {
+	if !c.Enabled() {
+		return nil
+	}
+
+	endpoint := c.baseURL.JoinPath(path)
+	endpoint.RawQuery = query.Encode()
+
+	return endpoint
+}
+
+func (c *Client) do(ctx context.Context, method string, endpoint *url.URL, in, out any) error {
+	if !c.Enabled() {
+		return ErrDisabled
+	}
+
+	var body io.Reader
+
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), body)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+
+	req.Header.Set("Accept", contentTypeJSON)
+
+	if in != nil {
+		req.Header.Set("Content-Type", contentTypeJSON)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeError(resp)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(out)
+	if err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+func decodeError(resp *http.Response) error {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Code: "", Message: ""}
+
+	payload, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err == nil {
+		_ = json.Unmarshal(payload, apiErr)
+	}
+
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return apiErr
+}
+
+func memoryPath(characterID string) string {
+	return "/characters/" + url.PathEscape(characterID) + "/memory"
+}
@@ -0,0 +1,171 @@
+package kiseki
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/config"
+)
+
+const (
+	testCharacterID = "0193b1c6-6f5e-7a51-9a3c-3f0d1c2b4e5f"
+	testMemory      = "Alice likes tea"
+	testMemoryPath  = "/characters/" + testCharacterID + "/memory"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	var cfg config.Config
+	cfg.Kiseki.URL = server.URL
+	cfg.Kiseki.Timeout = time.Second
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	return client
+}
+
+func TestClientGetMemory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:   "decodes fragments",
+			status: http.StatusOK,
+			body: `{"items":[{"dType":"text","data":"Alice likes tea","meta":{` +
+				`"memorized_at":"2026-01-01T00:00:00Z","created_at":"2026-01-01T00:00:00Z",` +
+				`"updated_at":"2026-01-01T00:00:00Z"}}]}`,
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:    "returns api error",
+			status:  http.StatusBadRequest,
+			body:    `{"code":"INVALID_REQUEST","message":"bad dType"}`,
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != testMemoryPath || r.Method != http.MethodGet {
+					t.Errorf("request = %s %s", r.Method, r.URL.Path)
+				}
+
+				if r.URL.Query().Get("dType") != string(DTypeText) || r.URL.Query().Get("data") != testMemory {
+					t.Errorf("query = %q", r.URL.RawQuery)
+				}
+
+				w.WriteHeader(testCase.status)
+				_, _ = w.Write([]byte(testCase.body))
+			})
+
+			got, err := client.GetMemory(t.Context(), testCharacterID, DTypeText, testMemory)
+			if testCase.wantErr {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != testCase.status {
+					t.Fatalf("GetMemory() error = %v, want APIError", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetMemory() error = %v", err)
+			}
+
+			if len(got) != testCase.want {
+				t.Fatalf("GetMemory() = %d items, want %d", len(got), testCase.want)
+			}
+		})
+	}
+}
+
+func TestClientPutMemory(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			DType DType  `json:"dType"`
+			Data  string `json:"data"`
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil || r.Method != http.MethodPut || body.Data != testMemory {
+			t.Errorf("request = %s %+v (%v)", r.Method, body, err)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.PutMemory(t.Context(), testCharacterID, DTypeText, testMemory)
+	if err != nil {
+		t.Fatalf("PutMemory() error = %v", err)
+	}
+}
+
+func TestClientDisabled(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(config.Config{})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	err = client.PutMemory(t.Context(), testCharacterID, DTypeText, testMemory)
+	if !errors.Is(err, ErrDisabled) {
+		t.Fatalf("PutMemory() error = %v, want %v", err, ErrDisabled)
+	}
+}
+
+func TestClientSleep(t *testing.T) {
+	t.Parallel()
+
+	const sleepPath = "/characters/" + testCharacterID + "/sleep/0193b1c6-0000-7000-8000-000000000001"
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/characters/"+testCharacterID+"/sleep":
+			_, _ = w.Write([]byte(`{"pollingUrl":"` + sleepPath + `"}`))
+		case r.Method == http.MethodGet && r.URL.Path == sleepPath:
+			_, _ = w.Write([]byte(`{"status":"completed"}`))
+		default:
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	pollingURL, err := client.Sleep(t.Context(), testCharacterID)
+	if err != nil {
+		t.Fatalf("Sleep() error = %v", err)
+	}
+
+	status, err := client.SleepStatus(t.Context(), pollingURL)
+	if err != nil {
+		t.Fatalf("SleepStatus() error = %v", err)
+	}
+
+	if status.Status != SleepCompleted {
+		t.Fatalf("SleepStatus() = %q, want %q", status.Status, SleepCompleted)
+	}
+}
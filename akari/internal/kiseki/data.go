@@ -0,0 +1,243 @@
+package kiseki
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	DTypeText       DType = "text"
+	DTypeFact       DType = "fact"
+	DTypeEvent      DType = "event"
+	DTypePreference DType = "preference"
+	DTypeImageRef   DType = "image_ref"
+	// DTypeImage is what image descriptions were stored as before
+	// DTypeImageRef. Its data is a string.
+	//
+	// Deprecated: Use DTypeImageRef.
+	DTypeImage DType = "image"
+
+	SentimentLikes    Sentiment = "likes"
+	SentimentDislikes Sentiment = "dislikes"
+
+	eventTimeLayout = "2006-01-02 15:04"
+	eventDateLayout = "2006-01-02"
+)
+
+var (
+	ErrUnknownDType = errors.New("unknown dType")
+	ErrInvalidData  = errors.New("data does not match its dType")
+)
+
+// DType selects the type of a fragment's data: a string for DTypeText, and
+// a Fact, Event, Preference or ImageRef for the structured types.
+type DType string
+
+// Fact is a subject-predicate-object statement, such as Alice / lives in /
+// Osaka. SubjectID is the subject's external ID, such as the Discord user ID
+// of a user, which kiseki keys the subject's person entity on.
+type Fact struct {
+	Subject   string `json:"subject"`
+	SubjectID string `json:"subjectId,omitempty"`
+	Predicate string `json:"predicate"`
+	Object    string `json:"object"`
+}
+
+// Event is something that happened, or will happen, at a time.
+type Event struct {
+	Time         time.Time `json:"time"`
+	Participants []string  `json:"participants"`
+	Summary      string    `json:"summary"`
+}
+
+type Sentiment string
+
+// Preference is what someone likes or dislikes. SubjectID is as for a Fact.
+type Preference struct {
+	Subject   string    `json:"subject"`
+	SubjectID string    `json:"subjectId,omitempty"`
+	Object    string    `json:"object"`
+	Sentiment Sentiment `json:"sentiment"`
+}
+
+// ImageRef is an image someone shared, described in text. The image itself
+// is not stored.
+type ImageRef struct {
+	URL         string `json:"url"`
+	MIMEType    string `json:"mimeType"`
+	Filename    string `json:"filename,omitempty"`
+	Description string `json:"description"`
+}
+
+// Fragment is a memory. Data is encoded as its DType describes.
+type Fragment struct {
+	DType DType           `json:"dType"`
+	Data  json.RawMessage `json:"data"`
+	Meta  Meta            `json:"meta"`
+}
+
+// TextFragment returns a text fragment holding data.
+func TextFragment(data string, meta Meta) Fragment {
+	encoded, _ := json.Marshal(data)
+
+	return Fragment{DType: DTypeText, Data: encoded, Meta: meta}
+}
+
+// Text renders the fragment as a line of text for a prompt. Data that does
+// not decode as its type is rendered as it was received.
+func (f Fragment) Text() string {
+	var (
+		text string
+		err  error
+	)
+
+	switch f.DType {
+	case DTypeText, DTypeImage:
+		text, err = render(f.Data, func(text string) string { return text })
+	case DTypeFact:
+		text, err = render(f.Data, func(fact Fact) string {
+			return fact.Subject + " " + fact.Predicate + " " + fact.Object
+		})
+	case DTypeEvent:
+		text, err = render(f.Data, func(event Event) string {
+			if len(event.Participants) == 0 {
+				return event.when() + ": " + event.Summary
+			}
+
+			return event.when() + ": " + event.Summary + " (with " + strings.Join(event.Participants, ", ") + ")"
+		})
+	case DTypePreference:
+		text, err = render(f.Data, func(preference Preference) string {
+			return preference.Subject + " " + string(preference.Sentiment) + " " + preference.Object
+		})
+	case DTypeImageRef:
+		text, err = render(f.Data, func(image ImageRef) string { return image.Description })
+	default:
+		err = fmt.Errorf("%w: %s", ErrUnknownDType, f.DType)
+	}
+
+	if err != nil {
+		return string(f.Data)
+	}
+
+	return text
+}
+
+// Validate checks that data has the schema dType selects, as kiseki's API
+// requires, so that a malformed fragment is rejected before it is sent.
+func Validate(dType DType, data any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidData, err)
+	}
+
+	switch dType {
+	case DTypeText, DTypeImage:
+		var text string
+
+		err = json.Unmarshal(encoded, &text)
+		if err != nil {
+			return fmt.Errorf("%w: %s is not a string", ErrInvalidData, dType)
+		}
+
+		return nil
+	case DTypeFact:
+		return validate[Fact](encoded)
+	case DTypeEvent:
+		return validate[Event](encoded)
+	case DTypePreference:
+		return validate[Preference](encoded)
+	case DTypeImageRef:
+		return validate[ImageRef](encoded)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownDType, dType)
+	}
+}
+
+func (f Fact) validate() error {
+	return required(map[string]string{"subject": f.Subject, "predicate": f.Predicate, "object": f.Object})
+}
+
+func (e Event) validate() error {
+	if e.Time.IsZero() {
+		return fmt.Errorf("%w: event has no time", ErrInvalidData)
+	}
+
+	if e.Participants == nil || slices.Contains(e.Participants, "") {
+		return fmt.Errorf("%w: event participants must be a list of names", ErrInvalidData)
+	}
+
+	return required(map[string]string{"summary": e.Summary})
+}
+
+func (p Preference) validate() error {
+	if p.Sentiment != SentimentLikes && p.Sentiment != SentimentDislikes {
+		return fmt.Errorf("%w: unknown sentiment %q", ErrInvalidData, p.Sentiment)
+	}
+
+	return required(map[string]string{"subject": p.Subject, "object": p.Object})
+}
+
+func (i ImageRef) validate() error {
+	ref, err := url.Parse(i.URL)
+	if err != nil || !ref.IsAbs() {
+		return fmt.Errorf("%w: image url %q is not absolute", ErrInvalidData, i.URL)
+	}
+
+	return required(map[string]string{"mimeType": i.MIMEType, "description": i.Description})
+}
+
+// when is the event's date, with the time of day unless it is midnight,
+// which stands for an event on a day at no particular time.
+func (e Event) when() string {
+	if hour, minute, second := e.Time.Clock(); hour == 0 && minute == 0 && second == 0 {
+		return e.Time.Format(eventDateLayout)
+	}
+
+	return e.Time.Format(eventTimeLayout)
+}
+
+// validate decodes data as T, rejecting unknown properties as the API's
+// schemas do, and checks the decoded value.
+func validate[T interface{ validate() error }](data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var value T
+
+	err := decoder.Decode(&value)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidData, err)
+	}
+
+	return value.validate()
+}
+
+// required checks that the named properties are not empty.
+func required(properties map[string]string) error {
+	for _, name := range slices.Sorted(maps.Keys(properties)) {
+		if strings.TrimSpace(properties[name]) == "" {
+			return fmt.Errorf("%w: %s is required", ErrInvalidData, name)
+		}
+	}
+
+	return nil
+}
+
+func render[T any](data json.RawMessage, format func(T) string) (string, error) {
+	var value T
+
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return "", fmt.Errorf("decode data: %w", err)
+	}
+
+	return format(value), nil
+}
@@ -0,0 +1,145 @@
+package kiseki
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFragmentText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		dType DType
+		data  string
+		want  string
+	}{
+		{name: "text", dType: DTypeText, data: `"Alice likes tea"`, want: "Alice likes tea"},
+		{
+			name:  "fact",
+			dType: DTypeFact,
+			data:  `{"subject":"Alice","predicate":"lives in","object":"Osaka"}`,
+			want:  "Alice lives in Osaka",
+		},
+		{
+			name:  "event",
+			dType: DTypeEvent,
+			data:  `{"time":"2026-10-24T18:00:00Z","participants":["Alice","Bob"],"summary":"Dinner in Kyoto"}`,
+			want:  "2026-10-24 18:00: Dinner in Kyoto (with Alice, Bob)",
+		},
+		{
+			name:  "event alone",
+			dType: DTypeEvent,
+			data:  `{"time":"2026-10-24T18:00:00Z","participants":[],"summary":"Piano recital"}`,
+			want:  "2026-10-24 18:00: Piano recital",
+		},
+		{
+			name:  "event on a day",
+			dType: DTypeEvent,
+			data:  `{"time":"2026-10-24T00:00:00+09:00","participants":["Alice"],"summary":"Piano recital"}`,
+			want:  "2026-10-24: Piano recital (with Alice)",
+		},
+		{
+			name:  "preference",
+			dType: DTypePreference,
+			data:  `{"subject":"Alice","object":"green tea","sentiment":"likes"}`,
+			want:  "Alice likes green tea",
+		},
+		{
+			name:  "image ref",
+			dType: DTypeImageRef,
+			data:  `{"url":"https://cdn.example/cat.png","mimeType":"image/png","description":"A sleeping cat."}`,
+			want:  "A sleeping cat.",
+		},
+		{name: "deprecated image", dType: DTypeImage, data: `"A sleeping cat."`, want: "A sleeping cat."},
+		{name: "mismatched data", dType: DTypeFact, data: `"Alice likes tea"`, want: `"Alice likes tea"`},
+		{name: "unknown type", dType: "audio", data: `"hum"`, want: `"hum"`},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			fragment := Fragment{DType: testCase.dType, Data: json.RawMessage(testCase.data), Meta: Meta{}}
+			if got := fragment.Text(); got != testCase.want {
+				t.Fatalf("Text() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 10, 24, 18, 0, 0, 0, time.UTC)
+	fact := Fact{Subject: "Alice", SubjectID: "", Predicate: "lives in", Object: "Osaka"}
+	event := Event{Time: at, Participants: []string{"Alice"}, Summary: "Piano recital"}
+	preference := Preference{Subject: "Alice", SubjectID: "", Object: "green tea", Sentiment: SentimentLikes}
+	image := ImageRef{URL: "https://cdn.example/cat.png", MIMEType: "image/png", Filename: "", Description: "A cat."}
+
+	tests := []struct {
+		name    string
+		dType   DType
+		data    any
+		wantErr error
+	}{
+		{name: "text", dType: DTypeText, data: "Alice likes tea", wantErr: nil},
+		{name: "text not a string", dType: DTypeText, data: fact, wantErr: ErrInvalidData},
+		{name: "deprecated image", dType: DTypeImage, data: "A sleeping cat.", wantErr: nil},
+		{name: "fact", dType: DTypeFact, data: fact, wantErr: nil},
+		{
+			name:    "fact without object",
+			dType:   DTypeFact,
+			data:    Fact{Subject: fact.Subject, SubjectID: "", Predicate: fact.Predicate, Object: " "},
+			wantErr: ErrInvalidData,
+		},
+		{name: "fact as text", dType: DTypeFact, data: "Alice lives in Osaka", wantErr: ErrInvalidData},
+		{
+			name:    "fact with unknown property",
+			dType:   DTypeFact,
+			data:    map[string]string{"subject": "Alice", "predicate": "lives in", "object": "Osaka", "since": "2020"},
+			wantErr: ErrInvalidData,
+		},
+		{name: "event", dType: DTypeEvent, data: event, wantErr: nil},
+		{
+			name:    "event without time",
+			dType:   DTypeEvent,
+			data:    Event{Time: time.Time{}, Participants: event.Participants, Summary: event.Summary},
+			wantErr: ErrInvalidData,
+		},
+		{
+			name:    "event without participants",
+			dType:   DTypeEvent,
+			data:    Event{Time: at, Participants: nil, Summary: event.Summary},
+			wantErr: ErrInvalidData,
+		},
+		{name: "preference", dType: DTypePreference, data: preference, wantErr: nil},
+		{
+			name:    "preference with unknown sentiment",
+			dType:   DTypePreference,
+			data:    Preference{Subject: preference.Subject, SubjectID: "", Object: preference.Object, Sentiment: "adores"},
+			wantErr: ErrInvalidData,
+		},
+		{name: "image ref", dType: DTypeImageRef, data: image, wantErr: nil},
+		{
+			name:    "image ref with relative url",
+			dType:   DTypeImageRef,
+			data:    ImageRef{URL: "cat.png", MIMEType: image.MIMEType, Filename: "", Description: image.Description},
+			wantErr: ErrInvalidData,
+		},
+		{name: "unknown type", dType: "audio", data: "hum", wantErr: ErrUnknownDType},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Validate(testCase.dType, testCase.data)
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("Validate() error = %v, want %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
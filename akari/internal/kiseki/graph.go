@@ -0,0 +1,151 @@
+package kiseki
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	EntityPerson EntityKind = "person"
+	EntityPlace  EntityKind = "place"
+	EntityTopic  EntityKind = "topic"
+	EntityOther  EntityKind = "other"
+)
+
+type EntityKind string
+
+// Entity is a node of a character's knowledge graph: a person, place or
+// topic its memories mention. ExternalIDs are the IDs it is known by outside
+// kiseki, such as the Discord user ID of a person.
+type Entity struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Kind        EntityKind `json:"kind"`
+	Aliases     []string   `json:"aliases"`
+	ExternalIDs []string   `json:"externalIds"`
+}
+
+// Relation is an edge of the knowledge graph, reading "Source Predicate
+// Target", with the memories it was extracted from.
+type Relation struct {
+	ID         string       `json:"id"`
+	Source     string       `json:"source"`
+	Target     string       `json:"target"`
+	Predicate  string       `json:"predicate"`
+	Confidence float64      `json:"confidence"`
+	Provenance []Provenance `json:"provenance"`
+}
+
+type Provenance struct {
+	Fragment    Fragment  `json:"fragment"`
+	ExtractedAt time.Time `json:"extractedAt"`
+}
+
+// Graph is the neighbourhood of Entity: the entities related to it and the
+// relations between them, the most confident first.
+type Graph struct {
+	Entity Entity     `json:"entity"`
+	Nodes  []Entity   `json:"nodes"`
+	Edges  []Relation `json:"edges"`
+}
+
+// NeighborsQuery narrows the neighbourhood Neighbors returns. Zero values
+// leave kiseki's defaults.
+type NeighborsQuery struct {
+	Depth         int
+	MinConfidence float64
+	Limit         int
+}
+
+// FindEntities returns the entities of a character's knowledge graph named
+// or aliased name.
+func (c *Client) FindEntities(ctx context.Context, characterID string, name string) ([]Entity, error) {
+	query := url.Values{}
+	query.Set("name", name)
+
+	return c.findEntities(ctx, characterID, query)
+}
+
+// FindEntitiesByExternalID returns the entities of a character's knowledge
+// graph known outside kiseki by externalID, such as the person entity of a
+// Discord user.
+func (c *Client) FindEntitiesByExternalID(
+	ctx context.Context,
+	characterID string,
+	externalID string,
+) ([]Entity, error) {
+	query := url.Values{}
+	query.Set("externalId", externalID)
+
+	return c.findEntities(ctx, characterID, query)
+}
+
+func (c *Client) findEntities(ctx context.Context, characterID string, query url.Values) ([]Entity, error) {
+	var body struct {
+		Items []Entity `json:"items"`
+	}
+
+	err := c.do(ctx, http.MethodGet, c.endpoint(graphPath(characterID)+"/entities", query), nil, &body)
+	if err != nil {
+		return nil, fmt.Errorf("find entities: %w", err)
+	}
+
+	return body.Items, nil
+}
+
+// Neighbors returns the neighbourhood of an entity of a character's
+// knowledge graph.
+func (c *Client) Neighbors(
+	ctx context.Context,
+	characterID string,
+	entityID string,
+	neighbors NeighborsQuery,
+) (Graph, error) {
+	query := url.Values{}
+	if neighbors.Depth > 0 {
+		query.Set("depth", strconv.Itoa(neighbors.Depth))
+	}
+
+	if neighbors.MinConfidence > 0 {
+		query.Set("minConfidence", strconv.FormatFloat(neighbors.MinConfidence, 'g', -1, 64))
+	}
+
+	if neighbors.Limit > 0 {
+		query.Set("limit", strconv.Itoa(neighbors.Limit))
+	}
+
+	var graph Graph
+
+	path := graphPath(characterID) + "/entities/" + url.PathEscape(entityID) + "/neighbors"
+
+	err := c.do(ctx, http.MethodGet, c.endpoint(path, query), nil, &graph)
+	if err != nil {
+		return Graph{}, fmt.Errorf("get neighbors: %w", err)
+	}
+
+	return graph, nil
+}
+
+// Name returns the name of the entity with the ID among the graph's
+// entities, or the ID if it is not one of them.
+func (g Graph) Name(id string) string {
+	if g.Entity.ID == id {
+		return g.Entity.Name
+	}
+
+	for _, node := range g.Nodes {
+		if node.ID == id {
+			return node.Name
+		}
+	}
+
+	return id
+}
+
+func graphPath(characterID string) string {
+	return "/characters/" + url.PathEscape(characterID) + "/graph"
+}
@@ -0,0 +1,68 @@
+package kiseki
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestClientGraph(t *testing.T) {
+	t.Parallel()
+
+	const graphPath = "/characters/" + testCharacterID + "/graph/entities"
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case graphPath:
+			if r.URL.RawQuery != "name=Alice" && r.URL.RawQuery != "externalId=123456789" {
+				t.Errorf("query = %q", r.URL.RawQuery)
+			}
+
+			_, _ = w.Write([]byte(`{"items":[{"id":"alice","name":"Alice","kind":"person","aliases":["Ali"],` +
+				`"externalIds":["123456789"]}]}`))
+		case graphPath + "/alice/neighbors":
+			if r.URL.RawQuery != "depth=1&limit=5&minConfidence=0.5" {
+				t.Errorf("query = %q", r.URL.RawQuery)
+			}
+
+			_, _ = w.Write([]byte(`{"entity":{"id":"alice","name":"Alice","kind":"person","aliases":[]},` +
+				`"nodes":[{"id":"osaka","name":"Osaka","kind":"place","aliases":[]}],` +
+				`"edges":[{"id":"e1","source":"alice","target":"osaka","predicate":"lives in","confidence":0.8,` +
+				`"provenance":[{"fragment":{"dType":"fact","data":{"subject":"Alice","predicate":"lives in",` +
+				`"object":"Osaka"},"meta":{"memorized_at":"2026-01-01T00:00:00Z",` +
+				`"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}},` +
+				`"extractedAt":"2026-01-02T00:00:00Z"}]}]}`))
+		default:
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	entities, err := client.FindEntities(t.Context(), testCharacterID, "Alice")
+	if err != nil || len(entities) != 1 || entities[0].Kind != EntityPerson {
+		t.Fatalf("FindEntities() = %+v, %v, want Alice", entities, err)
+	}
+
+	entities, err = client.FindEntitiesByExternalID(t.Context(), testCharacterID, "123456789")
+	if err != nil || len(entities) != 1 || !slices.Equal(entities[0].ExternalIDs, []string{"123456789"}) {
+		t.Fatalf("FindEntitiesByExternalID() = %+v, %v, want Alice", entities, err)
+	}
+
+	graph, err := client.Neighbors(t.Context(), testCharacterID, entities[0].ID,
+		NeighborsQuery{Depth: 1, MinConfidence: 0.5, Limit: 5})
+	if err != nil || len(graph.Edges) != 1 {
+		t.Fatalf("Neighbors() = %+v, %v, want one relation", graph, err)
+	}
+
+	edge := graph.Edges[0]
+
+	got := graph.Name(edge.Source) + " " + edge.Predicate + " " + graph.Name(edge.Target)
+	if got != "Alice lives in Osaka" {
+		t.Fatalf("relation = %q, want Alice lives in Osaka", got)
+	}
+
+	got = edge.Provenance[0].Fragment.Text()
+	if got != "Alice lives in Osaka" {
+		t.Fatalf("provenance = %q, want the fact", got)
+	}
+}
@@ -0,0 +1,115 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// fakeToolPrefix starts a message asking the fake model to call a tool.
+const fakeToolPrefix = "use "
+
+// Fake is a deterministic offline model for local development and tests. It
+// answers by echoing the last user message, naming the type of each of its
+// attachments. A message reading "use <tool> <arguments>" calls a declared
+// tool with the JSON object arguments, and the results of calls are answered
+// by reporting them.
+type Fake struct{}
+
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+func (f *Fake) Generate(ctx context.Context, req Request) (Response, error) {
+	if err := ctx.Err(); err != nil {
+		return Response{}, err
+	}
+
+	for i := len(req.Messages) - 1; i >= 0; i-- {
+		message := req.Messages[i]
+		if message.Role != RoleUser {
+			continue
+		}
+
+		if len(message.Results) > 0 {
+			return Response{Text: reportResults(message.Results), Usage: Usage{}, Calls: nil}, nil
+		}
+
+		if call, ok := requestedCall(req.Tools, message.Text); ok {
+			return Response{Text: "", Usage: Usage{}, Calls: []Call{call}}, nil
+		}
+
+		return Response{Text: echo(message), Usage: Usage{}, Calls: nil}, nil
+	}
+
+	return Response{Text: "...", Usage: Usage{}, Calls: nil}, nil
+}
+
+// Stream streams the reply Generate would give one word at a time.
+func (f *Fake) Stream(ctx context.Context, req Request, onText func(text string)) (Response, error) {
+	resp, err := f.Generate(ctx, req)
+	if err != nil {
+		return Response{}, err
+	}
+
+	if resp.Text == "" {
+		return resp, nil
+	}
+
+	for word := range strings.SplitAfterSeq(resp.Text, " ") {
+		onText(word)
+	}
+
+	return resp, nil
+}
+
+func requestedCall(tools []Tool, text string) (Call, bool) {
+	request, ok := strings.CutPrefix(strings.TrimSpace(text), fakeToolPrefix)
+	if !ok {
+		return Call{}, false
+	}
+
+	name, arguments, _ := strings.Cut(request, " ")
+	if !slices.ContainsFunc(tools, func(tool Tool) bool { return tool.Name == name }) {
+		return Call{}, false
+	}
+
+	arguments = strings.TrimSpace(arguments)
+	if arguments == "" {
+		arguments = "{}"
+	}
+
+	return Call{ID: "", Name: name, Arguments: json.RawMessage(arguments)}, true
+}
+
+func echo(message Message) string {
+	reply := "You said: " + strings.TrimSpace(message.Text)
+	for _, attachment := range message.Attachments {
+		reply += " [" + attachment.MIMEType + "]"
+	}
+
+	return reply
+}
+
+func reportResults(results []Result) string {
+	reports := make([]string, 0, len(results))
+
+	for _, result := range results {
+		if result.Error != "" {
+			reports = append(reports, fmt.Sprintf("%s failed: %s", result.Name, result.Error))
+
+			continue
+		}
+
+		output, err := json.Marshal(result.Output)
+		if err != nil {
+			output = []byte(fmt.Sprint(result.Output))
+		}
+
+		reports = append(reports, fmt.Sprintf("%s returned %s", result.Name, output))
+	}
+
+	return strings.Join(reports, "\n")
+}
@@ -0,0 +1,215 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/tracing"
+	"google.golang.org/genai"
+)
+
+type Gemini struct {
+	client *genai.Client
+	model  string
+}
+
+func NewGemini(ctx context.Context, cfg config.LLM) (*Gemini, error) {
+	clientConfig := new(genai.ClientConfig)
+	clientConfig.Backend = genai.BackendVertexAI
+	clientConfig.Project = cfg.ProjectID
+	clientConfig.Location = cfg.Location
+	clientConfig.HTTPClient = new(http.Client)
+	clientConfig.HTTPClient.Transport = tracing.NewTransport(http.DefaultTransport)
+
+	// A custom HTTP client opts out of genai's credential detection.
+	err := clientConfig.UseDefaultCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("detect google credentials: %w", err)
+	}
+
+	client, err := genai.NewClient(ctx, clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create genai client: %w", err)
+	}
+
+	return &Gemini{client: client, model: cfg.ModelName}, nil
+}
+
+func (g *Gemini) Generate(ctx context.Context, req Request) (Response, error) {
+	contents, generateConfig, err := request(req)
+	if err != nil {
+		return Response{}, err
+	}
+
+	resp, err := g.client.Models.GenerateContent(ctx, g.model, contents, generateConfig)
+	if err != nil {
+		return Response{}, fmt.Errorf("generate content: %w", err)
+	}
+
+	calls, err := functionCalls(resp)
+	if err != nil {
+		return Response{}, err
+	}
+
+	return Response{Text: text(resp), Usage: usage(resp), Calls: calls}, nil
+}
+
+func (g *Gemini) Stream(ctx context.Context, req Request, onText func(text string)) (Response, error) {
+	contents, generateConfig, err := request(req)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var (
+		reply  strings.Builder
+		tokens Usage
+		calls  []Call
+	)
+
+	for resp, err := range g.client.Models.GenerateContentStream(ctx, g.model, contents, generateConfig) {
+		if err != nil {
+			return Response{}, fmt.Errorf("stream content: %w", err)
+		}
+
+		// Every chunk reports the usage so far.
+		if resp.UsageMetadata != nil {
+			tokens = usage(resp)
+		}
+
+		chunkCalls, err := functionCalls(resp)
+		if err != nil {
+			return Response{}, err
+		}
+
+		calls = append(calls, chunkCalls...)
+
+		if chunk := text(resp); chunk != "" {
+			reply.WriteString(chunk)
+			onText(chunk)
+		}
+	}
+
+	return Response{Text: reply.String(), Usage: tokens, Calls: calls}, nil
+}
+
+func request(req Request) ([]*genai.Content, *genai.GenerateContentConfig, error) {
+	contents := make([]*genai.Content, 0, len(req.Messages))
+
+	for _, message := range req.Messages {
+		parts, err := messageParts(message)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		contents = append(contents, genai.NewContentFromParts(parts, genai.Role(message.Role)))
+	}
+
+	generateConfig := new(genai.GenerateContentConfig)
+	generateConfig.Temperature = req.Temperature
+	if req.System != "" {
+		generateConfig.SystemInstruction = genai.NewContentFromText(req.System, genai.RoleUser)
+	}
+
+	if len(req.Tools) > 0 {
+		declarations := make([]*genai.FunctionDeclaration, 0, len(req.Tools))
+		for _, tool := range req.Tools {
+			declaration := new(genai.FunctionDeclaration)
+			declaration.Name = tool.Name
+			declaration.Description = tool.Description
+			declaration.ParametersJsonSchema = tool.Parameters
+			declarations = append(declarations, declaration)
+		}
+
+		functions := new(genai.Tool)
+		functions.FunctionDeclarations = declarations
+		generateConfig.Tools = []*genai.Tool{functions}
+	}
+
+	return contents, generateConfig, nil
+}
+
+func messageParts(message Message) ([]*genai.Part, error) {
+	parts := make([]*genai.Part, 0, len(message.Attachments)+1)
+
+	// Gemini reads a prompt best with its images first.
+	for _, attachment := range message.Attachments {
+		parts = append(parts, genai.NewPartFromBytes(attachment.Data, attachment.MIMEType))
+	}
+
+	if message.Text != "" || len(parts)+len(message.Calls)+len(message.Results) == 0 {
+		parts = append(parts, genai.NewPartFromText(message.Text))
+	}
+
+	for _, call := range message.Calls {
+		var args map[string]any
+
+		err := json.Unmarshal(call.Arguments, &args)
+		if err != nil {
+			return nil, fmt.Errorf("decode arguments of %s: %w", call.Name, err)
+		}
+
+		part := genai.NewPartFromFunctionCall(call.Name, args)
+		part.FunctionCall.ID = call.ID
+		parts = append(parts, part)
+	}
+
+	for _, result := range message.Results {
+		response := map[string]any{"output": result.Output}
+		if result.Error != "" {
+			response = map[string]any{"error": result.Error}
+		}
+
+		part := genai.NewPartFromFunctionResponse(result.Name, response)
+		part.FunctionResponse.ID = result.ID
+		parts = append(parts, part)
+	}
+
+	return parts, nil
+}
+
+func functionCalls(resp *genai.GenerateContentResponse) ([]Call, error) {
+	var calls []Call
+
+	for _, call := range resp.FunctionCalls() {
+		args, err := json.Marshal(call.Args)
+		if err != nil {
+			return nil, fmt.Errorf("encode arguments of %s: %w", call.Name, err)
+		}
+
+		calls = append(calls, Call{ID: call.ID, Name: call.Name, Arguments: args})
+	}
+
+	return calls, nil
+}
+
+// text joins the text of the first candidate. Unlike resp.Text it does not
+// log a warning for the function calls beside the text.
+func text(resp *genai.GenerateContentResponse) string {
+	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+		return ""
+	}
+
+	var texts strings.Builder
+
+	for _, part := range resp.Candidates[0].Content.Parts {
+		if !part.Thought {
+			texts.WriteString(part.Text)
+		}
+	}
+
+	return texts.String()
+}
+
+func usage(resp *genai.GenerateContentResponse) Usage {
+	var tokens Usage
+	if resp.UsageMetadata != nil {
+		tokens.InputTokens = int(resp.UsageMetadata.PromptTokenCount)
+		tokens.OutputTokens = int(resp.UsageMetadata.CandidatesTokenCount)
+	}
+
+	return tokens
+}
@@ -0,0 +1,107 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	RoleUser  Role = "user"
+	RoleModel Role = "model"
+)
+
+type Role string
+
+// Message is a turn of the conversation. A model turn may call tools, and
+// the user turn after it holds their results. A user turn may show the model
+// attachments, such as images, besides its text.
+type Message struct {
+	Role        Role
+	Text        string
+	Attachments []Attachment
+	Calls       []Call
+	Results     []Result
+}
+
+// Attachment is a file passed to the model inline, such as an image.
+type Attachment struct {
+	MIMEType string
+	Data     []byte
+}
+
+type Request struct {
+	System   string
+	Messages []Message
+	// Temperature overrides the model's sampling temperature when set.
+	Temperature *float32
+	// Tools are the tools the model may call instead of answering.
+	Tools []Tool
+}
+
+type Response struct {
+	Text  string
+	Usage Usage
+	// Calls are the tools the model asked to call before it answers.
+	Calls []Call
+}
+
+// Tool declares a tool to the model. Parameters is the JSON schema of the
+// arguments object.
+type Tool struct {
+	Name        string
+	Description string
+	Parameters  map[string]any
+}
+
+// Call is a model's request to call a tool, with its arguments as a JSON
+// object. ID, when the model sets one, pairs the call with its result.
+type Call struct {
+	ID        string
+	Name      string
+	Arguments json.RawMessage
+}
+
+// Result is the outcome of a call: Output on success, Error otherwise.
+type Result struct {
+	ID     string
+	Name   string
+	Output any
+	Error  string
+}
+
+// Usage counts the tokens a generation consumed, when the model reports it.
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// Model generates a character's reply from a conversation.
+type Model interface {
+	Generate(ctx context.Context, req Request) (Response, error)
+	// Stream generates like Generate, calling onText with each piece of text
+	// as soon as the model produces it. The response holds the whole text.
+	Stream(ctx context.Context, req Request, onText func(text string)) (Response, error)
+}
+
+// New returns a Vertex AI Gemini model, or the offline fake model when no
+// Google Cloud project is configured. Either is instrumented on registry.
+func New(cfg config.Config, registry *prometheus.Registry) (Model, error) {
+	var model Model = NewFake()
+
+	if cfg.LLM.ProjectID == "" {
+		slog.Info("LLM_PROJECT_ID is not set, using offline fake model")
+	} else {
+		gemini, err := NewGemini(context.Background(), cfg.LLM)
+		if err != nil {
+			return nil, err
+		}
+
+		model = gemini
+	}
+
+	return NewInstrumented(model, registry)
+}
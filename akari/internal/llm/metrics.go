@@ -0,0 +1,121 @@
+package llm
+
+import (
+	"context"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/kizuna-org/akari/internal/llm"
+
+// Instrumented measures the latency, outcome and token usage of every
+// generation of the model it wraps, and how long streams wait for their
+// first text, and traces them.
+type Instrumented struct {
+	model      Model
+	duration   *prometheus.HistogramVec
+	firstToken prometheus.Histogram
+	tokens     *prometheus.CounterVec
+	tracer     trace.Tracer
+}
+
+func NewInstrumented(model Model, registry *prometheus.Registry) (*Instrumented, error) {
+	duration, err := metrics.Register(registry, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "llm",
+		Name:      "request_duration_seconds",
+		Help:      "Time spent generating a reply, by outcome.",
+		Buckets:   []float64{0.25, 0.5, 1, 2, 4, 8, 16, 32},
+	}, []string{"outcome"}))
+	if err != nil {
+		return nil, err
+	}
+
+	firstToken, err := metrics.Register(registry, prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "llm",
+		Name:      "first_token_seconds",
+		Help:      "Time a streamed reply waited for its first text.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2, 4, 8, 16},
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := metrics.Register(registry, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "llm",
+		Name:      "tokens_total",
+		Help:      "Tokens consumed by generations, by direction (input or output).",
+	}, []string{"direction"}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Instrumented{
+		model:      model,
+		duration:   duration,
+		firstToken: firstToken,
+		tokens:     tokens,
+		tracer:     otel.Tracer(tracerName),
+	}, nil
+}
+
+func (m *Instrumented) Generate(ctx context.Context, req Request) (Response, error) {
+	ctx, span := m.tracer.Start(ctx, "llm.Generate")
+	defer span.End()
+
+	start := time.Now()
+	resp, err := m.model.Generate(ctx, req)
+	m.observe(span, start, resp, err)
+
+	return resp, err
+}
+
+func (m *Instrumented) Stream(ctx context.Context, req Request, onText func(text string)) (Response, error) {
+	ctx, span := m.tracer.Start(ctx, "llm.Stream")
+	defer span.End()
+
+	start := time.Now()
+	first := true
+
+	resp, err := m.model.Stream(ctx, req, func(text string) {
+		if first {
+			first = false
+
+			m.firstToken.Observe(time.Since(start).Seconds())
+			span.AddEvent("first text")
+		}
+
+		onText(text)
+	})
+	m.observe(span, start, resp, err)
+
+	return resp, err
+}
+
+func (m *Instrumented) observe(span trace.Span, start time.Time, resp Response, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.SetAttributes(
+		attribute.Int("llm.usage.input_tokens", resp.Usage.InputTokens),
+		attribute.Int("llm.usage.output_tokens", resp.Usage.OutputTokens),
+		attribute.Int("llm.tool_calls", len(resp.Calls)),
+	)
+
+	m.duration.WithLabelValues(outcome).Observe(time.Since(start).Seconds())
+	m.tokens.WithLabelValues("input").Add(float64(resp.Usage.InputTokens))
+	m.tokens.WithLabelValues("output").Add(float64(resp.Usage.OutputTokens))
+}
@@ -0,0 +1,152 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	RequestIDHeader   = "X-Request-Id"
+	traceparentHeader = "Traceparent"
+	// maxRequestIDLength bounds request IDs accepted from clients.
+	maxRequestIDLength = 128
+
+	// Field counts and lengths of a version 00 traceparent header.
+	traceparentFields = 4
+	traceIDLength     = 32
+	spanIDLength      = 16
+	flagsLength       = 2
+)
+
+type requestIDKey struct{}
+
+type traceKey struct{}
+
+// Trace identifies the W3C trace context a request belongs to.
+type Trace struct {
+	TraceID string
+	SpanID  string
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+
+	return id
+}
+
+func WithTrace(ctx context.Context, trace Trace) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+func TraceFrom(ctx context.Context) (Trace, bool) {
+	trace, ok := ctx.Value(traceKey{}).(Trace)
+
+	return trace, ok
+}
+
+// Middleware tags every request with a request ID, taken from the
+// X-Request-Id header or generated, and with the trace context of its
+// traceparent header. The request ID is echoed in the response.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := req.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx := WithRequestID(req.Context(), id)
+		if trace, ok := parseTraceparent(req.Header.Get(traceparentHeader)); ok {
+			ctx = WithTrace(ctx, trace)
+		}
+
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		if r <= ' ' || r > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseTraceparent reads a version 00 traceparent header:
+// 00-<32 hex trace ID>-<16 hex span ID>-<2 hex flags>.
+func parseTraceparent(header string) (Trace, bool) {
+	parts := strings.Split(header, "-")
+	if len(parts) != traceparentFields || parts[0] != "00" || len(parts[3]) != flagsLength {
+		return Trace{}, false
+	}
+
+	trace := Trace{TraceID: parts[1], SpanID: parts[2]}
+	if !nonZeroHex(trace.TraceID, traceIDLength) || !nonZeroHex(trace.SpanID, spanIDLength) {
+		return Trace{}, false
+	}
+
+	return trace, true
+}
+
+func nonZeroHex(value string, length int) bool {
+	if len(value) != length || strings.Trim(value, "0") == "" {
+		return false
+	}
+
+	for _, r := range value {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// contextHandler adds the request and trace IDs of the context to every
+// record. The active OpenTelemetry span wins over a traceparent header seen
+// by Middleware, which covers routes that are not traced.
+type contextHandler struct {
+	next slog.Handler
+}
+
+func (h contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+
+	if span := oteltrace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+	} else if trace, ok := TraceFrom(ctx); ok {
+		record.AddAttrs(slog.String("trace_id", trace.TraceID), slog.String("span_id", trace.SpanID))
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{next: h.next.WithGroup(name)}
+}
@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/secret"
+	"github.com/kizuna-org/akari/internal/settings"
+	"go.uber.org/fx/fxevent"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+var ErrUnknownFormat = errors.New("unknown log format")
+
+// New builds the process logger from LOG_LEVEL, LOG_FORMAT and LOG_SOURCE.
+// Secrets, including values rotated in later, are redacted and request and
+// trace IDs are read from the context of every record. The returned level can
+// be changed while the logger is in use.
+func New(cfg config.Config, secrets *secret.Store) (*slog.Logger, *slog.LevelVar, error) {
+	return newLogger(cfg, secrets, os.Stderr)
+}
+
+func newLogger(cfg config.Config, secrets *secret.Store, w io.Writer) (*slog.Logger, *slog.LevelVar, error) {
+	level := new(slog.LevelVar)
+
+	err := level.UnmarshalText([]byte(cfg.Log.Level))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse LOG_LEVEL: %w", err)
+	}
+
+	options := new(slog.HandlerOptions)
+	options.Level = level
+	options.AddSource = cfg.Log.AddSource
+	options.ReplaceAttr = newRedactor(cfg, secrets).replaceAttr
+
+	var handler slog.Handler
+
+	switch cfg.Log.Format {
+	case FormatText:
+		handler = slog.NewTextHandler(w, options)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, options)
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnknownFormat, cfg.Log.Format)
+	}
+
+	return slog.New(contextHandler{next: handler}), level, nil
+}
+
+// NewSettingsSubscriber applies runtime changes to the log level.
+func NewSettingsSubscriber(level *slog.LevelVar) settings.Subscriber {
+	return func(current settings.Settings) {
+		level.Set(current.LogLevel)
+	}
+}
+
+// SetDefault makes logger the one behind the package-level slog functions,
+// which the rest of akari logs through.
+func SetDefault(logger *slog.Logger) {
+	slog.SetDefault(logger)
+}
+
+// NewEventLogger logs fx's own events through logger. Routine events such as
+// provides and invokes are logged at debug level; failures stay errors.
+func NewEventLogger(logger *slog.Logger) fxevent.Logger {
+	eventLogger := &fxevent.SlogLogger{Logger: logger}
+	eventLogger.UseLogLevel(slog.LevelDebug)
+
+	return eventLogger
+}
@@ -0,0 +1,250 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/secret"
+)
+
+const (
+	testToken       = "discord-token-value"
+	testPassword    = "db-password-value"
+	testAPIKey      = "api-key-value"
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID      = "00f067aa0ba902b7"
+	testTraceparent = "00-" + testTraceID + "-" + testSpanID + "-01"
+)
+
+func testConfig(level string, format string) config.Config {
+	var cfg config.Config
+	cfg.Discord.Token = testToken
+	cfg.Database.Password = testPassword
+	cfg.Auth.APIKeys = "ops:admin:" + testAPIKey
+	cfg.Log = config.Log{Level: level, Format: format, AddSource: false}
+
+	return cfg
+}
+
+// testLogger builds a logger writing to w whose secrets come from cfg alone.
+func testLogger(t *testing.T, cfg config.Config, w io.Writer) (*slog.Logger, error) {
+	t.Helper()
+
+	secrets, err := secret.NewStore(cfg, secret.NoProvider{})
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	logger, _, err := newLogger(cfg, secrets, w)
+
+	return logger, err
+}
+
+func decodeRecord(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	record := make(map[string]any)
+
+	err := json.Unmarshal(buf.Bytes(), &record)
+	if err != nil {
+		t.Fatalf("decode %q: %v", buf.String(), err)
+	}
+
+	return record
+}
+
+func TestNewLogger(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		level   string
+		format  string
+		wantErr error
+	}{
+		{name: "text", level: "info", format: FormatText, wantErr: nil},
+		{name: "json", level: "debug", format: FormatJSON, wantErr: nil},
+		{name: "unknown format", level: "info", format: "xml", wantErr: ErrUnknownFormat},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := testLogger(t, testConfig(testCase.level, testCase.format), new(bytes.Buffer))
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("newLogger() error = %v, want %v", err, testCase.wantErr)
+			}
+		})
+	}
+
+	_, err := testLogger(t, testConfig("loud", FormatText), new(bytes.Buffer))
+	if err == nil {
+		t.Fatal("newLogger() with an unknown level error = nil")
+	}
+}
+
+func TestLevel(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger, err := testLogger(t, testConfig("warn", FormatJSON), &buf)
+	if err != nil {
+		t.Fatalf("newLogger() error = %v", err)
+	}
+
+	logger.Info("hidden")
+
+	if buf.Len() != 0 {
+		t.Fatalf("info record logged at warn level: %s", buf.String())
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		attr slog.Attr
+		want string
+	}{
+		{name: "sensitive key", attr: slog.String("token", "anything"), want: redacted},
+		{name: "discord token in value", attr: slog.String("detail", "Bot "+testToken), want: "Bot " + redacted},
+		{
+			name: "password in error",
+			attr: slog.Any("error", errors.New("connect: password="+testPassword+" rejected")),
+			want: "connect: password=" + redacted + " rejected",
+		},
+		{name: "api key", attr: slog.String("key", testAPIKey), want: redacted},
+		{name: "other value", attr: slog.Int("count", 3), want: "3"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			logger, err := testLogger(t, testConfig("info", FormatJSON), &buf)
+			if err != nil {
+				t.Fatalf("newLogger() error = %v", err)
+			}
+
+			logger.Info("event", testCase.attr)
+
+			got := decodeRecord(t, &buf)[testCase.attr.Key]
+			if value, _ := json.Marshal(got); strings.Trim(string(value), `"`) != testCase.want {
+				t.Fatalf("%s = %v, want %q", testCase.attr.Key, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRedactionRotated(t *testing.T) {
+	t.Parallel()
+
+	const rotated = "rotated-password-value"
+
+	dir := t.TempDir()
+	cfg := testConfig("info", FormatJSON)
+
+	secrets, err := secret.NewStore(cfg, secret.NewFileProvider(dir))
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	logger, _, err := newLogger(cfg, secrets, &buf)
+	if err != nil {
+		t.Fatalf("newLogger() error = %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, secret.DatabasePassword), []byte(rotated), 0o600)
+	if err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	err = secrets.Refresh(t.Context())
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	logger.Info("event", "detail", "password="+rotated)
+
+	if got, want := decodeRecord(t, &buf)["detail"], "password="+redacted; got != want {
+		t.Fatalf("detail = %v, want %q", got, want)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		requestID     string
+		traceparent   string
+		wantRequestID string
+		wantTraceID   any
+	}{
+		{
+			name:          "propagated",
+			requestID:     "req-1",
+			traceparent:   testTraceparent,
+			wantRequestID: "req-1",
+			wantTraceID:   testTraceID,
+		},
+		{name: "generated", requestID: "", traceparent: "", wantRequestID: "", wantTraceID: nil},
+		{name: "invalid trace", requestID: "", traceparent: "00-zz-00-01", wantRequestID: "", wantTraceID: nil},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			logger, err := testLogger(t, testConfig("info", FormatJSON), &buf)
+			if err != nil {
+				t.Fatalf("newLogger() error = %v", err)
+			}
+
+			handler := Middleware(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+				logger.InfoContext(req.Context(), "handled")
+			}))
+
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/", nil)
+			req.Header.Set(RequestIDHeader, testCase.requestID)
+			req.Header.Set(traceparentHeader, testCase.traceparent)
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			record := decodeRecord(t, &buf)
+			echoed := recorder.Header().Get(RequestIDHeader)
+
+			if echoed == "" || record["request_id"] != echoed {
+				t.Fatalf("request_id = %v, response header = %q", record["request_id"], echoed)
+			}
+
+			if testCase.wantRequestID != "" && echoed != testCase.wantRequestID {
+				t.Fatalf("request_id = %q, want %q", echoed, testCase.wantRequestID)
+			}
+
+			if record["trace_id"] != testCase.wantTraceID {
+				t.Fatalf("trace_id = %v, want %v", record["trace_id"], testCase.wantTraceID)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package logging
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/secret"
+)
+
+const (
+	redacted = "[REDACTED]"
+	// apiKeyFields is the number of fields in an AUTH_API_KEYS entry.
+	apiKeyFields = 3
+)
+
+// sensitiveKeys are attribute keys whose values are never logged.
+var sensitiveKeys = map[string]struct{}{
+	"authorization": {},
+	"api_key":       {},
+	"password":      {},
+	"secret":        {},
+	"token":         {},
+}
+
+// redactor masks secrets wherever they appear in a record, such as a
+// database password inside a connection error. It follows the secret store,
+// so a rotated secret is masked from the moment it takes effect.
+type redactor struct {
+	mu sync.Mutex
+	// fixed are the configured secrets the store does not hold, the tokens
+	// of characters without a secret of their own.
+	fixed    []string
+	rotating map[string][]string
+	replacer atomic.Pointer[strings.Replacer]
+}
+
+func newRedactor(cfg config.Config, secrets *secret.Store) *redactor {
+	r := &redactor{
+		mu:       sync.Mutex{},
+		fixed:    nil,
+		rotating: map[string][]string{},
+		replacer: atomic.Pointer[strings.Replacer]{},
+	}
+
+	for _, character := range cfg.Characters {
+		if character.TokenSecret == "" {
+			r.fixed = append(r.fixed, character.Token)
+		}
+	}
+
+	for _, name := range secrets.Names() {
+		r.rotating[name] = secretValues(name, secrets.Get(name))
+
+		secrets.Subscribe(name, func(value string) { r.rotate(name, value) })
+	}
+
+	r.rebuild()
+
+	return r
+}
+
+// secretValues splits a secret into the values to mask. AUTH_API_KEYS holds
+// a list of keys, of which only the keys themselves are secret.
+func secretValues(name string, value string) []string {
+	if name != secret.APIKeys {
+		return []string{value}
+	}
+
+	var keys []string
+
+	for entry := range strings.SplitSeq(value, ",") {
+		fields := strings.SplitN(strings.TrimSpace(entry), ":", apiKeyFields)
+		if len(fields) == apiKeyFields {
+			keys = append(keys, fields[2])
+		}
+	}
+
+	return keys
+}
+
+func (r *redactor) rotate(name string, value string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rotating[name] = secretValues(name, value)
+	r.rebuild()
+}
+
+// rebuild replaces the replacer with one for the current secrets. The
+// caller holds mu, or is the constructor.
+func (r *redactor) rebuild() {
+	var pairs []string
+
+	for _, value := range slices.Concat(append(slices.Collect(maps.Values(r.rotating)), r.fixed)...) {
+		if value != "" {
+			pairs = append(pairs, value, redacted)
+		}
+	}
+
+	if len(pairs) == 0 {
+		r.replacer.Store(nil)
+
+		return
+	}
+
+	r.replacer.Store(strings.NewReplacer(pairs...))
+}
+
+func (r *redactor) replaceAttr(_ []string, attr slog.Attr) slog.Attr {
+	if _, ok := sensitiveKeys[strings.ToLower(attr.Key)]; ok {
+		return slog.String(attr.Key, redacted)
+	}
+
+	replacer := r.replacer.Load()
+	if replacer == nil {
+		return attr
+	}
+
+	var text string
+
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		text = attr.Value.String()
+	case slog.KindAny:
+		err, ok := attr.Value.Any().(error)
+		if !ok {
+			return attr
+		}
+
+		text = err.Error()
+	default:
+		return attr
+	}
+
+	if masked := replacer.Replace(text); masked != text {
+		return slog.String(attr.Key, masked)
+	}
+
+	return attr
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"sync"
+	"time"
+)
+
+// breaker stops calling kiseki for a cooldown after consecutive failures so
+// that an outage doesn't add a timeout to every reply.
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	now       func() time.Time
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{
+		mu:        sync.Mutex{},
+		threshold: threshold,
+		cooldown:  cooldown,
+		failures:  0,
+		openUntil: time.Time{},
+		now:       time.Now,
+	}
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return !b.now().Before(b.openUntil)
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+}
+
+func (b *breaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.failures = 0
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
@@ -0,0 +1,293 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/kiseki"
+)
+
+const (
+	failureThreshold = 3
+	failureCooldown  = 30 * time.Second
+
+	// aboutMinConfidence leaves out the relations kiseki is unsure of, so a
+	// passing remark is not presented as knowledge.
+	aboutMinConfidence = 0.5
+	aboutLimit         = 20
+)
+
+type Store interface {
+	GetMemory(ctx context.Context, characterID string, dType kiseki.DType, data string) ([]kiseki.Fragment, error)
+	PutMemory(ctx context.Context, characterID string, dType kiseki.DType, data any) error
+	DeleteMemory(ctx context.Context, characterID string, dType kiseki.DType, data string) error
+	FindEntities(ctx context.Context, characterID string, name string) ([]kiseki.Entity, error)
+	FindEntitiesByExternalID(ctx context.Context, characterID string, externalID string) ([]kiseki.Entity, error)
+	Neighbors(
+		ctx context.Context,
+		characterID string,
+		entityID string,
+		query kiseki.NeighborsQuery,
+	) (kiseki.Graph, error)
+}
+
+// Turn is one exchange between a user and a character. AuthorID is the
+// author's Discord user ID, or their account's subject when they talk
+// through the API unlinked. Images describes the images attached to the
+// message.
+type Turn struct {
+	CharacterID string
+	AuthorID    string
+	AuthorName  string
+	Message     string
+	Images      []kiseki.ImageRef
+	Reply       string
+}
+
+// Known is something a character knows about an entity, and how sure it is
+// of it, from 0 to 1.
+type Known struct {
+	Statement  string
+	Confidence float64
+}
+
+// Service reads and writes long-term memories in kiseki. Kiseki failures are
+// logged and swallowed so that a reply can always be generated without them.
+type Service struct {
+	store     Store
+	timeout   time.Duration
+	breaker   *breaker
+	locations map[string]*time.Location
+	now       func() time.Time
+}
+
+func NewService(cfg config.Config, client *kiseki.Client) *Service {
+	var service *Service
+
+	if client.Enabled() {
+		service = newService(client, cfg.Kiseki.Timeout)
+	} else {
+		slog.Info("kiseki is not configured, long-term memory disabled")
+
+		service = newService(nil, cfg.Kiseki.Timeout)
+	}
+
+	// The time zones were validated when the configuration was loaded.
+	for _, hosted := range append([]config.Character{cfg.Character}, cfg.Characters...) {
+		if location, err := time.LoadLocation(hosted.Timezone); err == nil {
+			service.locations[hosted.ID] = location
+		}
+	}
+
+	return service
+}
+
+func newService(store Store, timeout time.Duration) *Service {
+	return &Service{
+		store:     store,
+		timeout:   timeout,
+		breaker:   newBreaker(failureThreshold, failureCooldown),
+		locations: map[string]*time.Location{},
+		now:       time.Now,
+	}
+}
+
+// Recall returns fragments relevant to query, or nil when kiseki is
+// unavailable.
+func (s *Service) Recall(ctx context.Context, characterID string, query string) []kiseki.Fragment {
+	if !s.ready(characterID) || query == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	fragments, err := s.store.GetMemory(ctx, characterID, kiseki.DTypeText, query)
+	if err != nil {
+		s.breaker.failure()
+		slog.WarnContext(ctx, "recall memory failed", "character_id", characterID, "error", err)
+
+		return nil
+	}
+
+	s.breaker.success()
+
+	return fragments
+}
+
+// Memorize stores the salient facts of turn as text, what its author states
+// about themselves as structured fragments, and the images they shared. It
+// reports nothing to the caller because a lost memory must never break a
+// conversation.
+func (s *Service) Memorize(ctx context.Context, turn Turn) {
+	if !s.ready(turn.CharacterID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var statements []Statement
+
+	for _, fact := range Salient(turn) {
+		statements = append(statements, Statement{DType: kiseki.DTypeText, Data: fact})
+	}
+
+	statements = append(statements, Structured(turn, s.now().In(s.location(turn.CharacterID)))...)
+
+	for _, image := range imageMemories(turn) {
+		statements = append(statements, Statement{DType: kiseki.DTypeImageRef, Data: image})
+	}
+
+	for _, statement := range statements {
+		err := s.store.PutMemory(ctx, turn.CharacterID, statement.DType, statement.Data)
+		if errors.Is(err, kiseki.ErrInvalidData) {
+			// The extraction is at fault, not kiseki.
+			slog.WarnContext(ctx, "skipped invalid memory", "character_id", turn.CharacterID,
+				"dtype", statement.DType, "error", err)
+
+			continue
+		}
+
+		if err != nil {
+			s.breaker.failure()
+			slog.WarnContext(ctx, "memorize failed", "character_id", turn.CharacterID,
+				"dtype", statement.DType, "error", err)
+
+			return
+		}
+	}
+
+	s.breaker.success()
+}
+
+// Enabled reports whether memories are kept, so callers can skip preparing
+// them otherwise.
+func (s *Service) Enabled() bool {
+	return s.store != nil
+}
+
+// Remember stores fact on explicit request, so unlike Memorize it reports
+// failures to the caller.
+func (s *Service) Remember(ctx context.Context, characterID string, fact string) error {
+	if s.store == nil {
+		return kiseki.ErrDisabled
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	err := s.store.PutMemory(ctx, characterID, kiseki.DTypeText, fact)
+	if err != nil {
+		return fmt.Errorf("remember: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Forget(ctx context.Context, characterID string, fact string) error {
+	if s.store == nil {
+		return kiseki.ErrDisabled
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	err := s.store.DeleteMemory(ctx, characterID, kiseki.DTypeText, fact)
+	if err != nil {
+		return fmt.Errorf("forget: %w", err)
+	}
+
+	return nil
+}
+
+// About returns what a character knows about the people, places or topics
+// named name, from its knowledge graph, the most confident first. Like
+// Remember it is asked for explicitly, so it reports failures.
+func (s *Service) About(ctx context.Context, characterID string, name string) ([]Known, error) {
+	if s.store == nil {
+		return nil, kiseki.ErrDisabled
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	entities, err := s.store.FindEntities(ctx, characterID, name)
+	if err != nil {
+		return nil, fmt.Errorf("about %s: %w", name, err)
+	}
+
+	return s.known(ctx, characterID, name, entities)
+}
+
+// AboutUser is About for the user with the ID, a Turn's AuthorID. Users are
+// looked up by ID rather than by name, which they can change and share.
+func (s *Service) AboutUser(ctx context.Context, characterID string, userID string) ([]Known, error) {
+	if s.store == nil {
+		return nil, kiseki.ErrDisabled
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	entities, err := s.store.FindEntitiesByExternalID(ctx, characterID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("about user %s: %w", userID, err)
+	}
+
+	return s.known(ctx, characterID, userID, entities)
+}
+
+// known collects the relations of entities, which were looked up as
+// subject.
+func (s *Service) known(
+	ctx context.Context,
+	characterID string,
+	subject string,
+	entities []kiseki.Entity,
+) ([]Known, error) {
+	var known []Known
+
+	for _, entity := range entities {
+		graph, err := s.store.Neighbors(ctx, characterID, entity.ID, kiseki.NeighborsQuery{
+			Depth:         1,
+			MinConfidence: aboutMinConfidence,
+			Limit:         aboutLimit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("about %s: %w", subject, err)
+		}
+
+		for _, edge := range graph.Edges {
+			known = append(known, Known{
+				Statement:  graph.Name(edge.Source) + " " + edge.Predicate + " " + graph.Name(edge.Target),
+				Confidence: edge.Confidence,
+			})
+		}
+	}
+
+	slices.SortStableFunc(known, func(a, b Known) int {
+		return cmp.Compare(b.Confidence, a.Confidence)
+	})
+
+	return known, nil
+}
+
+// location returns the time zone of a character, UTC when it has none.
+func (s *Service) location(characterID string) *time.Location {
+	if location, ok := s.locations[characterID]; ok {
+		return location
+	}
+
+	return time.UTC
+}
+
+func (s *Service) ready(characterID string) bool {
+	return s.store != nil && characterID != "" && s.breaker.allow()
+}
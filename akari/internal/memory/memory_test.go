@@ -0,0 +1,456 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kizuna-org/akari/internal/kiseki"
+)
+
+const (
+	testCharacterID = "0193b1c6-6f5e-7a51-9a3c-3f0d1c2b4e5f"
+	testAuthor      = "Alice"
+	testAuthorID    = "123456789"
+	testTimeout     = time.Second
+)
+
+var errUnavailable = errors.New("kiseki unavailable")
+
+type fakeStore struct {
+	mu    sync.Mutex
+	err   error
+	calls int
+	puts  []string
+}
+
+func (s *fakeStore) GetMemory(context.Context, string, kiseki.DType, string) ([]kiseki.Fragment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return []kiseki.Fragment{kiseki.TextFragment("Alice: I like tea.", kiseki.Meta{})}, nil
+}
+
+func (s *fakeStore) PutMemory(_ context.Context, _ string, dType kiseki.DType, data any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.err != nil {
+		return s.err
+	}
+
+	s.puts = append(s.puts, fmt.Sprintf("%s: %v", dType, data))
+
+	return nil
+}
+
+func (s *fakeStore) DeleteMemory(context.Context, string, kiseki.DType, string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+
+	return s.err
+}
+
+func (s *fakeStore) FindEntities(_ context.Context, _ string, name string) ([]kiseki.Entity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.err != nil || name != testAuthor {
+		return nil, s.err
+	}
+
+	return []kiseki.Entity{testEntity()}, nil
+}
+
+func (s *fakeStore) FindEntitiesByExternalID(_ context.Context, _ string, externalID string) ([]kiseki.Entity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.err != nil || externalID != testAuthorID {
+		return nil, s.err
+	}
+
+	return []kiseki.Entity{testEntity()}, nil
+}
+
+func testEntity() kiseki.Entity {
+	return kiseki.Entity{
+		ID:          "alice",
+		Name:        testAuthor,
+		Kind:        kiseki.EntityPerson,
+		Aliases:     nil,
+		ExternalIDs: []string{testAuthorID},
+	}
+}
+
+func (s *fakeStore) Neighbors(
+	_ context.Context,
+	_ string,
+	entityID string,
+	_ kiseki.NeighborsQuery,
+) (kiseki.Graph, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+
+	relation := func(target string, predicate string, confidence float64) kiseki.Relation {
+		return kiseki.Relation{
+			ID:         target,
+			Source:     entityID,
+			Target:     target,
+			Predicate:  predicate,
+			Confidence: confidence,
+			Provenance: nil,
+		}
+	}
+
+	return kiseki.Graph{
+		Entity: kiseki.Entity{ID: entityID, Name: testAuthor, Kind: kiseki.EntityPerson, Aliases: nil, ExternalIDs: nil},
+		Nodes: []kiseki.Entity{
+			{ID: "osaka", Name: "Osaka", Kind: kiseki.EntityPlace, Aliases: nil},
+			{ID: "tea", Name: "green tea", Kind: kiseki.EntityTopic, Aliases: nil},
+		},
+		Edges: []kiseki.Relation{relation("osaka", "lives in", 0.6), relation("tea", "likes", 0.9)},
+	}, s.err
+}
+
+func TestSalient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		turn Turn
+		want []string
+	}{
+		{
+			name: "keeps statements and drops questions",
+			turn: Turn{
+				CharacterID: testCharacterID,
+				AuthorID:    "",
+				AuthorName:  testAuthor,
+				Message:     "I moved to Osaka last week. Do you like cats? Hi!",
+				Images:      nil,
+				Reply:       "",
+			},
+			want: []string{"Alice: I moved to Osaka last week."},
+		},
+		{
+			name: "splits japanese sentences and deduplicates",
+			turn: Turn{
+				CharacterID: testCharacterID,
+				AuthorID:    "",
+				AuthorName:  "",
+				Message:     "明日は友達と京都へ行きます。\n明日は友達と京都へ行きます。元気？",
+				Images:      nil,
+				Reply:       "",
+			},
+			want: []string{"明日は友達と京都へ行きます。"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Salient(testCase.turn); !slices.Equal(got, testCase.want) {
+				t.Fatalf("Salient() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestStructured(t *testing.T) {
+	t.Parallel()
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	at := time.Date(2026, 10, 23, 21, 30, 0, 0, tokyo)
+	tomorrow := time.Date(2026, 10, 24, 0, 0, 0, 0, tokyo)
+
+	tests := []struct {
+		name    string
+		author  string
+		message string
+		want    []Statement
+	}{
+		{
+			name:    "fact",
+			author:  testAuthor,
+			message: "I live in Osaka.",
+			want: []Statement{{
+				DType: kiseki.DTypeFact,
+				Data:  kiseki.Fact{Subject: testAuthor, SubjectID: testAuthorID, Predicate: "lives in", Object: "Osaka"},
+			}},
+		},
+		{
+			name:    "preferences",
+			author:  testAuthor,
+			message: "I love green tea! I don't like coffee. Do you like tea?",
+			want: []Statement{
+				{
+					DType: kiseki.DTypePreference,
+					Data: kiseki.Preference{
+						Subject:   testAuthor,
+						SubjectID: testAuthorID,
+						Object:    "green tea",
+						Sentiment: kiseki.SentimentLikes,
+					},
+				},
+				{
+					DType: kiseki.DTypePreference,
+					Data: kiseki.Preference{
+						Subject:   testAuthor,
+						SubjectID: testAuthorID,
+						Object:    "coffee",
+						Sentiment: kiseki.SentimentDislikes,
+					},
+				},
+			},
+		},
+		{
+			name:    "event",
+			author:  testAuthor,
+			message: "I have a piano recital tomorrow.",
+			want: []Statement{{
+				DType: kiseki.DTypeEvent,
+				Data: kiseki.Event{
+					Time:         tomorrow,
+					Participants: []string{testAuthor},
+					Summary:      "I have a piano recital",
+				},
+			}},
+		},
+		{
+			name:    "japanese",
+			author:  "アリス",
+			message: "私は大阪に住んでいます。抹茶が大好きです！明日は友達と京都へ行きます。",
+			want: []Statement{
+				{
+					DType: kiseki.DTypeFact,
+					Data: kiseki.Fact{
+						Subject:   "アリス",
+						SubjectID: testAuthorID,
+						Predicate: "lives in",
+						Object:    "大阪",
+					},
+				},
+				{
+					DType: kiseki.DTypePreference,
+					Data: kiseki.Preference{
+						Subject:   "アリス",
+						SubjectID: testAuthorID,
+						Object:    "抹茶",
+						Sentiment: kiseki.SentimentLikes,
+					},
+				},
+				{
+					DType: kiseki.DTypeEvent,
+					Data: kiseki.Event{
+						Time:         tomorrow,
+						Participants: []string{"アリス"},
+						Summary:      "友達と京都へ行きます",
+					},
+				},
+			},
+		},
+		{
+			name:    "event said first",
+			author:  testAuthor,
+			message: "Tomorrow we're off to Kyoto.",
+			want: []Statement{{
+				DType: kiseki.DTypeEvent,
+				Data: kiseki.Event{
+					Time:         tomorrow,
+					Participants: []string{testAuthor},
+					Summary:      "we're off to Kyoto",
+				},
+			}},
+		},
+		{
+			name:    "event with text that changes length when lower-cased",
+			author:  testAuthor,
+			message: "I painted ȺİİİİİİİK tomorrow.",
+			want: []Statement{{
+				DType: kiseki.DTypeEvent,
+				Data: kiseki.Event{
+					Time:         tomorrow,
+					Participants: []string{testAuthor},
+					Summary:      "I painted ȺİİİİİİİK",
+				},
+			}},
+		},
+		{name: "non-ascii before a day", author: testAuthor, message: "Ⱥ today, ok.", want: nil},
+		{name: "third person event", author: testAuthor, message: "The stock market crashed today.", want: nil},
+		{name: "day inside a word", author: testAuthor, message: "I went to Tomorrowland.", want: nil},
+		{name: "japanese third person event", author: testAuthor, message: "株価が今日暴落しました。", want: nil},
+		{name: "plain statement", author: testAuthor, message: "I moved last week.", want: nil},
+		{name: "no author", author: "", message: "I live in Osaka.", want: nil},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			turn := Turn{
+				CharacterID: testCharacterID,
+				AuthorID:    testAuthorID,
+				AuthorName:  testCase.author,
+				Message:     testCase.message,
+				Images:      nil,
+				Reply:       "",
+			}
+
+			got := Structured(turn, at)
+			if !reflect.DeepEqual(got, testCase.want) {
+				t.Fatalf("Structured() = %+v, want %+v", got, testCase.want)
+			}
+
+			for _, statement := range got {
+				if event, ok := statement.Data.(kiseki.Event); ok && !utf8.ValidString(event.Summary) {
+					t.Fatalf("Structured() summary %q is not valid UTF-8", event.Summary)
+				}
+
+				err := kiseki.Validate(statement.DType, statement.Data)
+				if err != nil {
+					t.Fatalf("Validate(%+v) error = %v", statement, err)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceRecall(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		err         error
+		characterID string
+		want        int
+	}{
+		{name: "returns fragments", err: nil, characterID: testCharacterID, want: 1},
+		{name: "isolates kiseki failures", err: errUnavailable, characterID: testCharacterID, want: 0},
+		{name: "skips unknown character", err: nil, characterID: "", want: 0},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := &fakeStore{mu: sync.Mutex{}, err: testCase.err, calls: 0, puts: nil}
+			service := newService(store, testTimeout)
+
+			if got := service.Recall(t.Context(), testCase.characterID, "tea"); len(got) != testCase.want {
+				t.Fatalf("Recall() = %d fragments, want %d", len(got), testCase.want)
+			}
+		})
+	}
+}
+
+func TestServiceMemorize(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeStore{mu: sync.Mutex{}, err: nil, calls: 0, puts: nil}
+	service := newService(store, testTimeout)
+
+	service.Memorize(t.Context(), Turn{
+		CharacterID: testCharacterID,
+		AuthorID:    testAuthorID,
+		AuthorName:  testAuthor,
+		Message:     "My birthday is on March 3rd. I love green tea.",
+		Images: []kiseki.ImageRef{
+			{URL: "https://cdn.example/cake.png", MIMEType: "image/png", Filename: "cake.png", Description: "A cake."},
+			{URL: "https://cdn.example/blank.png", MIMEType: "image/png", Filename: "blank.png", Description: " "},
+		},
+		Reply: "I'll remember that!",
+	})
+
+	want := []string{
+		"text: Alice: My birthday is on March 3rd.",
+		"text: Alice: I love green tea.",
+		"preference: {Alice 123456789 green tea likes}",
+		"image_ref: {https://cdn.example/cake.png image/png cake.png Alice shared an image: A cake.}",
+	}
+	if !slices.Equal(store.puts, want) {
+		t.Fatalf("PutMemory() calls = %q, want %q", store.puts, want)
+	}
+}
+
+func TestServiceAbout(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeStore{mu: sync.Mutex{}, err: nil, calls: 0, puts: nil}
+	service := newService(store, testTimeout)
+
+	got, err := service.About(t.Context(), testCharacterID, testAuthor)
+	if err != nil {
+		t.Fatalf("About() error = %v", err)
+	}
+
+	want := []Known{
+		{Statement: "Alice likes green tea", Confidence: 0.9},
+		{Statement: "Alice lives in Osaka", Confidence: 0.6},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("About() = %+v, want %+v", got, want)
+	}
+
+	got, err = service.About(t.Context(), testCharacterID, "Bob")
+	if err != nil || len(got) != 0 {
+		t.Fatalf("About() of a stranger = %+v, %v, want nothing", got, err)
+	}
+
+	got, err = service.AboutUser(t.Context(), testCharacterID, testAuthorID)
+	if err != nil || !slices.Equal(got, want) {
+		t.Fatalf("AboutUser() = %+v, %v, want %+v", got, err, want)
+	}
+
+	// Another user who goes by the same name is someone else.
+	got, err = service.AboutUser(t.Context(), testCharacterID, "987654321")
+	if err != nil || len(got) != 0 {
+		t.Fatalf("AboutUser() of a namesake = %+v, %v, want nothing", got, err)
+	}
+
+	_, err = newService(nil, testTimeout).About(t.Context(), testCharacterID, testAuthor)
+	if !errors.Is(err, kiseki.ErrDisabled) {
+		t.Fatalf("About() without kiseki error = %v, want %v", err, kiseki.ErrDisabled)
+	}
+}
+
+func TestServiceBreaker(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeStore{mu: sync.Mutex{}, err: errUnavailable, calls: 0, puts: nil}
+	service := newService(store, testTimeout)
+
+	for range failureThreshold + 2 {
+		service.Recall(t.Context(), testCharacterID, "tea")
+	}
+
+	if store.calls != failureThreshold {
+		t.Fatalf("store calls = %d, want %d", store.calls, failureThreshold)
+	}
+
+	now := time.Now().Add(failureCooldown)
+	service.breaker.now = func() time.Time { return now }
+
+	if !service.breaker.allow() {
+		t.Fatal("allow() = false after cooldown, want true")
+	}
+}
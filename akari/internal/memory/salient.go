@@ -0,0 +1,310 @@
+package memory
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kizuna-org/akari/internal/kiseki"
+)
+
+const (
+	minFactRunes = 8
+	maxFacts     = 5
+)
+
+// Statement is a structured memory of a turn: Data has the schema DType
+// selects.
+type Statement struct {
+	DType kiseki.DType
+	Data  any
+}
+
+// predicates maps how authors state a fact about themselves to the
+// predicate of the fact. English phrases open the sentence; Japanese ones
+// close it.
+var predicates = []struct {
+	prefix    string
+	suffix    string
+	predicate string
+}{
+	{prefix: "i live in ", suffix: "", predicate: "lives in"},
+	{prefix: "i work at ", suffix: "", predicate: "works at"},
+	{prefix: "i work as ", suffix: "", predicate: "works as"},
+	{prefix: "i'm from ", suffix: "", predicate: "is from"},
+	{prefix: "i am from ", suffix: "", predicate: "is from"},
+	{prefix: "", suffix: "に住んでいます", predicate: "lives in"},
+	{prefix: "", suffix: "に住んでいる", predicate: "lives in"},
+	{prefix: "", suffix: "出身", predicate: "is from"},
+}
+
+// sentiments maps how authors state a preference to its sentiment, like
+// predicates.
+var sentiments = []struct {
+	prefix    string
+	suffix    string
+	sentiment kiseki.Sentiment
+}{
+	{prefix: "i don't like ", suffix: "", sentiment: kiseki.SentimentDislikes},
+	{prefix: "i do not like ", suffix: "", sentiment: kiseki.SentimentDislikes},
+	{prefix: "i dislike ", suffix: "", sentiment: kiseki.SentimentDislikes},
+	{prefix: "i hate ", suffix: "", sentiment: kiseki.SentimentDislikes},
+	{prefix: "i like ", suffix: "", sentiment: kiseki.SentimentLikes},
+	{prefix: "i love ", suffix: "", sentiment: kiseki.SentimentLikes},
+	{prefix: "i enjoy ", suffix: "", sentiment: kiseki.SentimentLikes},
+	{prefix: "", suffix: "が嫌い", sentiment: kiseki.SentimentDislikes},
+	{prefix: "", suffix: "が大好き", sentiment: kiseki.SentimentLikes},
+	{prefix: "", suffix: "が好き", sentiment: kiseki.SentimentLikes},
+}
+
+// Events are dated by a day word: English ones anywhere in a clause that
+// opens with the author speaking of themselves, as in "I have a recital
+// tomorrow", Japanese ones opening the clause, as in 明日は京都へ行きます,
+// whose subject is left out when it is the author.
+var (
+	englishDay  = regexp.MustCompile(`(?i)\b(today|tonight|tomorrow)\b`)
+	japaneseDay = regexp.MustCompile(`^(?:(?:私|僕|俺|わたし)は)?(今日|今夜|明日)(?:は|、)?`)
+	firstPerson = regexp.MustCompile(`(?i)^(?:i|we|my|our)\b`)
+	daysLater   = map[string]int{"today": 0, "tonight": 0, "tomorrow": 1, "今日": 0, "今夜": 0, "明日": 1}
+)
+
+// Salient extracts the statements worth remembering from a turn. Questions,
+// short interjections and duplicates are dropped; every fact is attributed to
+// its author so that recalls stay meaningful out of context.
+func Salient(turn Turn) []string {
+	seen := make(map[string]struct{})
+	facts := make([]string, 0, maxFacts)
+
+	for _, sentence := range splitSentences(turn.Message) {
+		if len(facts) == maxFacts {
+			break
+		}
+
+		if isQuestion(sentence) || utf8.RuneCountInString(sentence) < minFactRunes {
+			continue
+		}
+
+		fact := sentence
+		if turn.AuthorName != "" {
+			fact = turn.AuthorName + ": " + sentence
+		}
+
+		if _, ok := seen[fact]; ok {
+			continue
+		}
+
+		seen[fact] = struct{}{}
+		facts = append(facts, fact)
+	}
+
+	return facts
+}
+
+// Structured extracts what the author of a turn states about themselves as
+// facts ("I live in Osaka"), preferences ("I love green tea") and events
+// ("I have a piano recital tomorrow"), so that memories can be queried by
+// structure. at is when the turn was sent, in the character's time zone, and
+// dates the events. Turns without an author name state nothing.
+func Structured(turn Turn, at time.Time) []Statement {
+	if turn.AuthorName == "" {
+		return nil
+	}
+
+	var statements []Statement
+
+	for _, sentence := range splitSentences(turn.Message) {
+		if len(statements) == maxFacts {
+			break
+		}
+
+		if isQuestion(sentence) || utf8.RuneCountInString(sentence) < minFactRunes {
+			continue
+		}
+
+		if statement, ok := structure(turn.AuthorName, turn.AuthorID, sentence, at); ok {
+			statements = append(statements, statement)
+		}
+	}
+
+	return statements
+}
+
+// structure reads a sentence the author, whose user ID is authorID, said
+// about themselves. Facts and preferences carry the ID so that kiseki files
+// them under the author's person entity whatever name they go by.
+func structure(author string, authorID string, sentence string, at time.Time) (Statement, bool) {
+	clause := trimClause(sentence)
+
+	for _, said := range predicates {
+		if object, ok := cutPhrase(clause, said.prefix, said.suffix); ok {
+			return Statement{
+				DType: kiseki.DTypeFact,
+				Data: kiseki.Fact{
+					Subject:   author,
+					SubjectID: authorID,
+					Predicate: said.predicate,
+					Object:    object,
+				},
+			}, true
+		}
+	}
+
+	for _, said := range sentiments {
+		if object, ok := cutPhrase(clause, said.prefix, said.suffix); ok {
+			return Statement{
+				DType: kiseki.DTypePreference,
+				Data: kiseki.Preference{
+					Subject:   author,
+					SubjectID: authorID,
+					Object:    object,
+					Sentiment: said.sentiment,
+				},
+			}, true
+		}
+	}
+
+	day, summary, ok := dated(clause)
+	if ok {
+		year, month, date := at.Date()
+
+		return Statement{
+			DType: kiseki.DTypeEvent,
+			Data: kiseki.Event{
+				Time:         time.Date(year, month, date+daysLater[day], 0, 0, 0, 0, at.Location()),
+				Participants: []string{author},
+				Summary:      summary,
+			},
+		}, true
+	}
+
+	return Statement{DType: "", Data: nil}, false
+}
+
+// dated finds the day word of a clause in which the author tells of their
+// own plans. It returns the day word, lower-cased, and the clause without
+// it: the event is dated, so the word would mislead once the day has
+// passed.
+func dated(clause string) (string, string, bool) {
+	if match := japaneseDay.FindStringSubmatchIndex(clause); match != nil {
+		summary := strings.TrimSpace(clause[match[1]:])
+
+		return clause[match[2]:match[3]], summary, summary != ""
+	}
+
+	match := englishDay.FindStringSubmatchIndex(clause)
+	if match == nil {
+		return "", "", false
+	}
+
+	summary := clause[:match[0]] + clause[match[1]:]
+	summary = strings.Trim(strings.Join(strings.Fields(summary), " "), " ,")
+
+	if !firstPerson.MatchString(summary) {
+		return "", "", false
+	}
+
+	return strings.ToLower(clause[match[2]:match[3]]), summary, true
+}
+
+// cutPhrase returns what follows prefix, or precedes suffix, in clause,
+// matching the English prefix regardless of case.
+func cutPhrase(clause string, prefix string, suffix string) (string, bool) {
+	var (
+		object string
+		ok     bool
+	)
+
+	if prefix != "" && len(clause) > len(prefix) && strings.EqualFold(clause[:len(prefix)], prefix) {
+		object, ok = clause[len(prefix):], true
+	}
+
+	if suffix != "" {
+		object, ok = strings.CutSuffix(clause, suffix)
+		object = trimJapaneseSubject(object)
+	}
+
+	object = strings.TrimSpace(object)
+
+	return object, ok && object != ""
+}
+
+// trimClause drops the closing punctuation and the polite copula of a
+// sentence.
+func trimClause(sentence string) string {
+	clause := strings.TrimRight(sentence, ".!。！ ")
+	clause = strings.TrimSuffix(clause, "です")
+
+	return strings.TrimSpace(clause)
+}
+
+// trimJapaneseSubject drops an opening "I" topic such as 私は, which
+// Japanese authors often leave out anyway.
+func trimJapaneseSubject(clause string) string {
+	for _, subject := range []string{"私は", "僕は", "俺は", "わたしは"} {
+		if rest, ok := strings.CutPrefix(clause, subject); ok {
+			return rest
+		}
+	}
+
+	return clause
+}
+
+// imageMemories attributes the descriptions of the images in a turn to
+// their author, like Salient does for statements. Images without a
+// description are dropped.
+func imageMemories(turn Turn) []kiseki.ImageRef {
+	memories := make([]kiseki.ImageRef, 0, len(turn.Images))
+
+	for _, image := range turn.Images {
+		image.Description = strings.TrimSpace(image.Description)
+		if image.Description == "" {
+			continue
+		}
+
+		if turn.AuthorName != "" {
+			image.Description = turn.AuthorName + " shared an image: " + image.Description
+		}
+
+		memories = append(memories, image)
+	}
+
+	return memories
+}
+
+func splitSentences(text string) []string {
+	var (
+		sentences []string
+		current   strings.Builder
+	)
+
+	flush := func() {
+		if sentence := strings.TrimSpace(current.String()); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+
+		current.Reset()
+	}
+
+	for _, r := range text {
+		if r == '\n' {
+			flush()
+
+			continue
+		}
+
+		current.WriteRune(r)
+
+		if strings.ContainsRune(".!?。！？", r) {
+			flush()
+		}
+	}
+
+	flush()
+
+	return sentences
+}
+
+func isQuestion(sentence string) bool {
+	return strings.HasSuffix(sentence, "?") || strings.HasSuffix(sentence, "？")
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kizuna-org/akari/internal/server"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// Namespace prefixes every metric exported by akari.
+	Namespace = "akari"
+	path      = "/metrics"
+)
+
+// NewRegistry returns the registry served on /metrics, with Go runtime and
+// process metrics already registered. Subsystems register their own
+// collectors on it.
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{PidFn: nil, Namespace: "", ReportErrors: false}),
+	)
+
+	return registry
+}
+
+// NewRoutes mounts the Prometheus scrape endpoint.
+func NewRoutes(registry *prometheus.Registry) []server.Route {
+	var opts promhttp.HandlerOpts
+	opts.Registry = registry
+
+	return []server.Route{
+		{Path: path, Handler: promhttp.HandlerFor(registry, opts)},
+	}
+}
+
+// Register registers collectors on registry. A collector that is already
+// registered, as happens when a constructor runs twice against the same
+// registry in tests, is replaced by the existing one.
+func Register[T prometheus.Collector](registry *prometheus.Registry, collector T) (T, error) {
+	err := registry.Register(collector)
+
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		existing, ok := registered.ExistingCollector.(T)
+		if ok {
+			return existing, nil
+		}
+	}
+
+	if err != nil {
+		var zero T
+
+		return zero, fmt.Errorf("register collector: %w", err)
+	}
+
+	return collector, nil
+}
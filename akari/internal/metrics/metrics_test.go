@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/kizuna-org/akari/internal/server"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const testProcedure = "/akari.test.v1.TestService/Ping"
+
+var errTestFailure = errors.New("ping failed")
+
+func TestRPCInterceptor(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+
+	interceptor, err := NewRPCInterceptor(registry)
+	if err != nil {
+		t.Fatalf("NewRPCInterceptor() error = %v", err)
+	}
+
+	fail := true
+	handler := connect.NewUnaryHandler(
+		testProcedure,
+		func(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+			fail = !fail
+			if fail {
+				return nil, connect.NewError(connect.CodeUnavailable, errTestFailure)
+			}
+
+			return connect.NewResponse(new(emptypb.Empty)), nil
+		},
+		connect.WithInterceptors(interceptor),
+	)
+
+	routes := append(NewRoutes(registry), server.Route{Path: testProcedure, Handler: handler})
+	httpServer := httptest.NewServer(server.NewMux(routes))
+	t.Cleanup(httpServer.Close)
+
+	client := connect.NewClient[emptypb.Empty, emptypb.Empty](http.DefaultClient, httpServer.URL+testProcedure)
+	for range 3 {
+		_, _ = client.CallUnary(t.Context(), connect.NewRequest(new(emptypb.Empty)))
+	}
+
+	tests := []struct {
+		code string
+		want float64
+	}{
+		{code: codeOK, want: 2},
+		{code: connect.CodeUnavailable.String(), want: 1},
+	}
+
+	for _, testCase := range tests {
+		got := testutil.ToFloat64(interceptor.requests.WithLabelValues(testProcedure, testCase.code))
+		if got != testCase.want {
+			t.Fatalf("requests_total{code=%q} = %v, want %v", testCase.code, got, testCase.want)
+		}
+	}
+
+	body := scrape(t, httpServer.URL)
+	for _, want := range []string{"akari_rpc_duration_seconds_bucket", "go_goroutines"} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("/metrics does not expose %s", want)
+		}
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+
+	first, err := NewRPCInterceptor(registry)
+	if err != nil {
+		t.Fatalf("NewRPCInterceptor() error = %v", err)
+	}
+
+	second, err := NewRPCInterceptor(registry)
+	if err != nil {
+		t.Fatalf("NewRPCInterceptor() again error = %v", err)
+	}
+
+	if first.requests != second.requests {
+		t.Fatal("second registration did not reuse the existing collector")
+	}
+}
+
+func scrape(t *testing.T, url string) string {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, url+path, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /metrics error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read /metrics: %v", err)
+	}
+
+	return string(body)
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// codeOK labels calls that succeeded; connect has no Code for it.
+const codeOK = "ok"
+
+// RPCInterceptor counts Connect calls and measures their latency by
+// procedure and status code.
+type RPCInterceptor struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+func NewRPCInterceptor(registry *prometheus.Registry) (*RPCInterceptor, error) {
+	requests, err := Register(registry, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "rpc",
+		Name:      "requests_total",
+		Help:      "Connect RPCs handled, by procedure and code.",
+	}, []string{"procedure", "code"}))
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := Register(registry, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "rpc",
+		Name:      "duration_seconds",
+		Help:      "Time spent handling Connect RPCs, by procedure and code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"procedure", "code"}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &RPCInterceptor{requests: requests, duration: duration}, nil
+}
+
+func (i *RPCInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+
+		start := time.Now()
+		resp, err := next(ctx, req)
+		i.observe(req.Spec().Procedure, err, start)
+
+		return resp, err
+	}
+}
+
+func (i *RPCInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *RPCInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		start := time.Now()
+		err := next(ctx, conn)
+		i.observe(conn.Spec().Procedure, err, start)
+
+		return err
+	}
+}
+
+func (i *RPCInterceptor) observe(procedure string, err error, start time.Time) {
+	code := codeOK
+	if err != nil {
+		code = connect.CodeOf(err).String()
+	}
+
+	i.requests.WithLabelValues(procedure, code).Inc()
+	i.duration.WithLabelValues(procedure, code).Observe(time.Since(start).Seconds())
+}
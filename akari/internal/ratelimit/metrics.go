@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"github.com/kizuna-org/akari/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collector exports the limiter's decision counters, the same ones Stats
+// reports.
+type collector struct {
+	limiter   *Limiter
+	decisions *prometheus.Desc
+}
+
+// RegisterMetrics exports the limiter's decisions as
+// akari_ratelimit_decisions_total, labeled by outcome: allowed, or limited
+// by the user, channel or global bucket.
+func RegisterMetrics(registry *prometheus.Registry, limiter *Limiter) error {
+	_, err := metrics.Register(registry, &collector{
+		limiter: limiter,
+		decisions: prometheus.NewDesc(
+			prometheus.BuildFQName(metrics.Namespace, "ratelimit", "decisions_total"),
+			"Rate limit decisions on replies, by outcome.",
+			[]string{"outcome"},
+			nil,
+		),
+	})
+
+	return err
+}
+
+func (c *collector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- c.decisions
+}
+
+func (c *collector) Collect(values chan<- prometheus.Metric) {
+	stats := c.limiter.Stats()
+
+	for outcome, count := range map[string]int64{
+		"allowed":         stats.Allowed,
+		"limited_user":    stats.LimitedUser,
+		"limited_channel": stats.LimitedChannel,
+		"limited_global":  stats.LimitedGlobal,
+	} {
+		values <- prometheus.MustNewConstMetric(c.decisions, prometheus.CounterValue, float64(count), outcome)
+	}
+}
@@ -0,0 +1,284 @@
+package ratelimit
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/settings"
+	"golang.org/x/time/rate"
+)
+
+const (
+	ScopeUser    Scope = "user"
+	ScopeChannel Scope = "channel"
+	ScopeGlobal  Scope = "global"
+
+	idleTTL        = 10 * time.Minute
+	noticeInterval = time.Minute
+)
+
+type Scope string
+
+// Decision is the outcome of Allow. Notify is set on the first denial for a
+// user within a minute so that callers can answer once instead of on every
+// message.
+type Decision struct {
+	Allowed bool
+	Scope   Scope
+	Notify  bool
+}
+
+type Stats struct {
+	Allowed        int64
+	LimitedUser    int64
+	LimitedChannel int64
+	LimitedGlobal  int64
+}
+
+type entry struct {
+	characterID string
+	limiter     *rate.Limiter
+	lastSeen    time.Time
+	lastNotice  time.Time
+}
+
+// key identifies a bucket: a user's or a channel's for one character, or
+// the character's global bucket when id is empty.
+type key struct {
+	characterID string
+	id          string
+}
+
+// Limiter applies token buckets per Discord user, per channel and globally,
+// separately for each character. A message consumes a token from every
+// bucket or from none. Characters without limits of their own share cfg,
+// which runtime settings may change.
+type Limiter struct {
+	mu        sync.Mutex
+	cfg       config.RateLimit
+	overrides map[string]config.RateLimit
+	users     map[key]*entry
+	channels  map[key]*entry
+	globals   map[key]*entry
+	lastSweep time.Time
+	now       func() time.Time
+
+	allowed        atomic.Int64
+	limitedUser    atomic.Int64
+	limitedChannel atomic.Int64
+	limitedGlobal  atomic.Int64
+}
+
+func NewLimiter(cfg config.Config) *Limiter {
+	overrides := map[string]config.RateLimit{}
+
+	for _, character := range cfg.Characters {
+		if character.RateLimit != nil {
+			overrides[character.ID] = *character.RateLimit
+		}
+	}
+
+	return newLimiter(cfg.RateLimit, overrides)
+}
+
+func newLimiter(cfg config.RateLimit, overrides map[string]config.RateLimit) *Limiter {
+	return &Limiter{
+		mu:        sync.Mutex{},
+		cfg:       cfg,
+		overrides: overrides,
+		users:     make(map[key]*entry),
+		channels:  make(map[key]*entry),
+		globals:   make(map[key]*entry),
+		lastSweep: time.Time{},
+		now:       time.Now,
+	}
+}
+
+// NewSettingsSubscriber applies runtime changes to the rate limits.
+func NewSettingsSubscriber(limiter *Limiter) settings.Subscriber {
+	return func(current settings.Settings) {
+		limiter.SetLimits(current.RateLimit)
+	}
+}
+
+// SetLimits changes the shared limits of every bucket in place, so tokens
+// already spent still count against the new limits. Characters with limits
+// of their own keep them.
+func (l *Limiter) SetLimits(cfg config.RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cfg == cfg {
+		return
+	}
+
+	now := l.now()
+	l.cfg = cfg
+
+	for _, scope := range []struct {
+		entries map[key]*entry
+		bucket  config.Bucket
+	}{
+		{entries: l.users, bucket: cfg.User},
+		{entries: l.channels, bucket: cfg.Channel},
+		{entries: l.globals, bucket: cfg.Global},
+	} {
+		for _, found := range scope.entries {
+			if _, ok := l.overrides[found.characterID]; !ok {
+				found.limiter = retune(found.limiter, scope.bucket, now)
+			}
+		}
+	}
+}
+
+// Allow decides whether a message from userID in channelID may get a reply
+// from the character.
+func (l *Limiter) Allow(characterID string, userID string, channelID string) Decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	limits := l.limits(characterID)
+	user := l.entry(l.users, key{characterID: characterID, id: userID}, limits.User, now)
+	channel := l.entry(l.channels, key{characterID: characterID, id: channelID}, limits.Channel, now)
+	global := l.entry(l.globals, key{characterID: characterID, id: ""}, limits.Global, now)
+
+	scope, ok := reserve(now, []scoped{
+		{scope: ScopeUser, limiter: user.limiter},
+		{scope: ScopeChannel, limiter: channel.limiter},
+		{scope: ScopeGlobal, limiter: global.limiter},
+	})
+	if ok {
+		l.allowed.Add(1)
+
+		return Decision{Allowed: true, Scope: "", Notify: false}
+	}
+
+	l.count(scope)
+
+	notify := now.Sub(user.lastNotice) >= noticeInterval
+	if notify {
+		user.lastNotice = now
+	}
+
+	return Decision{Allowed: false, Scope: scope, Notify: notify}
+}
+
+func (l *Limiter) Stats() Stats {
+	return Stats{
+		Allowed:        l.allowed.Load(),
+		LimitedUser:    l.limitedUser.Load(),
+		LimitedChannel: l.limitedChannel.Load(),
+		LimitedGlobal:  l.limitedGlobal.Load(),
+	}
+}
+
+func (l *Limiter) limits(characterID string) config.RateLimit {
+	if limits, ok := l.overrides[characterID]; ok {
+		return limits
+	}
+
+	return l.cfg
+}
+
+func (l *Limiter) count(scope Scope) {
+	switch scope {
+	case ScopeUser:
+		l.limitedUser.Add(1)
+	case ScopeChannel:
+		l.limitedChannel.Add(1)
+	case ScopeGlobal:
+		l.limitedGlobal.Add(1)
+	}
+}
+
+func (l *Limiter) entry(entries map[key]*entry, id key, bucket config.Bucket, now time.Time) *entry {
+	found, ok := entries[id]
+	if !ok {
+		found = &entry{characterID: id.characterID, limiter: newBucket(bucket), lastSeen: now, lastNotice: time.Time{}}
+		entries[id] = found
+	}
+
+	found.lastSeen = now
+
+	return found
+}
+
+// sweep drops buckets that have been idle long enough to be full again.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleTTL {
+		return
+	}
+
+	l.lastSweep = now
+
+	for _, entries := range []map[key]*entry{l.users, l.channels, l.globals} {
+		for id, found := range entries {
+			if now.Sub(found.lastSeen) >= idleTTL {
+				delete(entries, id)
+			}
+		}
+	}
+}
+
+type scoped struct {
+	scope   Scope
+	limiter *rate.Limiter
+}
+
+// reserve takes a token from every limiter, or returns the first scope that
+// has none left after giving back the tokens already taken.
+func reserve(now time.Time, limiters []scoped) (Scope, bool) {
+	reservations := make([]*rate.Reservation, 0, len(limiters))
+
+	for _, limiter := range limiters {
+		reservation := limiter.limiter.ReserveN(now, 1)
+		if reservation.OK() && reservation.DelayFrom(now) == 0 {
+			reservations = append(reservations, reservation)
+
+			continue
+		}
+
+		reservation.CancelAt(now)
+
+		for _, taken := range reservations {
+			taken.CancelAt(now)
+		}
+
+		return limiter.scope, false
+	}
+
+	return "", true
+}
+
+func newBucket(bucket config.Bucket) *rate.Limiter {
+	limit, burst := bucketRate(bucket)
+
+	return rate.NewLimiter(limit, burst)
+}
+
+// retune applies bucket to limiter. An unlimited limiter does not track
+// tokens, so it is replaced by a full bucket instead.
+func retune(limiter *rate.Limiter, bucket config.Bucket, now time.Time) *rate.Limiter {
+	if limiter.Limit() == rate.Inf {
+		return newBucket(bucket)
+	}
+
+	limit, burst := bucketRate(bucket)
+	limiter.SetLimitAt(now, limit)
+	limiter.SetBurstAt(now, burst)
+
+	return limiter
+}
+
+func bucketRate(bucket config.Bucket) (rate.Limit, int) {
+	if bucket.PerMinute <= 0 {
+		return rate.Inf, 0
+	}
+
+	return rate.Limit(float64(bucket.PerMinute) / time.Minute.Seconds()), max(bucket.Burst, 1)
+}
@@ -0,0 +1,233 @@
+package ratelimit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const (
+	testCharacter = "akari"
+	testHosted    = "luna"
+	testUser      = "user"
+	testOther     = "other"
+	testChannel   = "channel"
+)
+
+func testLimiter(cfg config.RateLimit) (*Limiter, *time.Time) {
+	return testHostingLimiter(cfg, map[string]config.RateLimit{})
+}
+
+func testHostingLimiter(cfg config.RateLimit, overrides map[string]config.RateLimit) (*Limiter, *time.Time) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	limiter := newLimiter(cfg, overrides)
+	limiter.now = func() time.Time { return now }
+
+	return limiter, &now
+}
+
+func TestLimiterAllow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		cfg   config.RateLimit
+		calls [][2]string
+		want  []Decision
+	}{
+		{
+			name: "limits a chatty user",
+			cfg: config.RateLimit{
+				User:    config.Bucket{PerMinute: 1, Burst: 1},
+				Channel: config.Bucket{PerMinute: 0, Burst: 0},
+				Global:  config.Bucket{PerMinute: 0, Burst: 0},
+			},
+			calls: [][2]string{
+				{testUser, testChannel}, {testUser, testChannel}, {testUser, testChannel}, {testOther, testChannel},
+			},
+			want: []Decision{
+				{Allowed: true, Scope: "", Notify: false},
+				{Allowed: false, Scope: ScopeUser, Notify: true},
+				{Allowed: false, Scope: ScopeUser, Notify: false},
+				{Allowed: true, Scope: "", Notify: false},
+			},
+		},
+		{
+			name: "limits a busy channel without charging the user",
+			cfg: config.RateLimit{
+				User:    config.Bucket{PerMinute: 1, Burst: 2},
+				Channel: config.Bucket{PerMinute: 1, Burst: 1},
+				Global:  config.Bucket{PerMinute: 0, Burst: 0},
+			},
+			calls: [][2]string{{testOther, testChannel}, {testUser, testChannel}, {testUser, "quiet"}, {testUser, "calm"}},
+			want: []Decision{
+				{Allowed: true, Scope: "", Notify: false},
+				{Allowed: false, Scope: ScopeChannel, Notify: true},
+				{Allowed: true, Scope: "", Notify: false},
+				{Allowed: true, Scope: "", Notify: false},
+			},
+		},
+		{
+			name: "limits everyone globally",
+			cfg: config.RateLimit{
+				User:    config.Bucket{PerMinute: 0, Burst: 0},
+				Channel: config.Bucket{PerMinute: 0, Burst: 0},
+				Global:  config.Bucket{PerMinute: 1, Burst: 1},
+			},
+			calls: [][2]string{{testUser, testChannel}, {testOther, "quiet"}},
+			want: []Decision{
+				{Allowed: true, Scope: "", Notify: false},
+				{Allowed: false, Scope: ScopeGlobal, Notify: true},
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			limiter, _ := testLimiter(testCase.cfg)
+
+			for i, call := range testCase.calls {
+				if got := limiter.Allow(testCharacter, call[0], call[1]); got != testCase.want[i] {
+					t.Fatalf("Allow() call %d = %+v, want %+v", i, got, testCase.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLimiterRefill(t *testing.T) {
+	t.Parallel()
+
+	limiter, now := testLimiter(config.RateLimit{
+		User:    config.Bucket{PerMinute: 1, Burst: 1},
+		Channel: config.Bucket{PerMinute: 0, Burst: 0},
+		Global:  config.Bucket{PerMinute: 0, Burst: 0},
+	})
+
+	limiter.Allow(testCharacter, testUser, testChannel)
+
+	if limiter.Allow(testCharacter, testUser, testChannel).Allowed {
+		t.Fatal("Allow() = allowed, want limited")
+	}
+
+	*now = now.Add(time.Minute)
+
+	if !limiter.Allow(testCharacter, testUser, testChannel).Allowed {
+		t.Fatal("Allow() = limited after refill, want allowed")
+	}
+
+	want := Stats{Allowed: 2, LimitedUser: 1, LimitedChannel: 0, LimitedGlobal: 0}
+	if got := limiter.Stats(); got != want {
+		t.Fatalf("Stats() = %+v, want %+v", got, want)
+	}
+
+	registry := prometheus.NewRegistry()
+
+	err := RegisterMetrics(registry, limiter)
+	if err != nil {
+		t.Fatalf("RegisterMetrics() error = %v", err)
+	}
+
+	err = testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP akari_ratelimit_decisions_total Rate limit decisions on replies, by outcome.
+# TYPE akari_ratelimit_decisions_total counter
+akari_ratelimit_decisions_total{outcome="allowed"} 2
+akari_ratelimit_decisions_total{outcome="limited_channel"} 0
+akari_ratelimit_decisions_total{outcome="limited_global"} 0
+akari_ratelimit_decisions_total{outcome="limited_user"} 1
+`))
+	if err != nil {
+		t.Fatalf("metrics: %v", err)
+	}
+}
+
+func TestLimiterSetLimits(t *testing.T) {
+	t.Parallel()
+
+	limiter, now := testLimiter(config.RateLimit{
+		User:    config.Bucket{PerMinute: 1, Burst: 1},
+		Channel: config.Bucket{PerMinute: 0, Burst: 0},
+		Global:  config.Bucket{PerMinute: 0, Burst: 0},
+	})
+
+	if !limiter.Allow(testCharacter, testUser, testChannel).Allowed {
+		t.Fatal("Allow() denied the first message")
+	}
+
+	if limiter.Allow(testCharacter, testUser, testChannel).Allowed {
+		t.Fatal("Allow() allowed a message over the user limit")
+	}
+
+	limiter.SetLimits(config.RateLimit{
+		User:    config.Bucket{PerMinute: 60, Burst: 3},
+		Channel: config.Bucket{PerMinute: 0, Burst: 0},
+		Global:  config.Bucket{PerMinute: 1, Burst: 1},
+	})
+
+	// Spent tokens still count, but refill at the new rate.
+	*now = now.Add(time.Second)
+
+	decision := limiter.Allow(testCharacter, testUser, testChannel)
+	if !decision.Allowed {
+		t.Fatalf("Allow() after raising the user limit = %+v, want allowed", decision)
+	}
+
+	decision = limiter.Allow(testCharacter, testOther, testChannel)
+	if decision.Allowed || decision.Scope != ScopeGlobal {
+		t.Fatalf("Allow() after adding a global limit = %+v, want limited globally", decision)
+	}
+}
+
+func TestLimiterCharacters(t *testing.T) {
+	t.Parallel()
+
+	shared := config.RateLimit{
+		User:    config.Bucket{PerMinute: 1, Burst: 1},
+		Channel: config.Bucket{PerMinute: 0, Burst: 0},
+		Global:  config.Bucket{PerMinute: 0, Burst: 0},
+	}
+
+	limiter, _ := testHostingLimiter(shared, map[string]config.RateLimit{
+		testHosted: {
+			User:    config.Bucket{PerMinute: 60, Burst: 2},
+			Channel: config.Bucket{PerMinute: 0, Burst: 0},
+			Global:  config.Bucket{PerMinute: 0, Burst: 0},
+		},
+	})
+
+	if !limiter.Allow(testCharacter, testUser, testChannel).Allowed {
+		t.Fatal("Allow() denied the first message")
+	}
+
+	if limiter.Allow(testCharacter, testUser, testChannel).Allowed {
+		t.Fatal("Allow() allowed a message over the user limit")
+	}
+
+	// Each character has buckets of its own, and luna has its own limits.
+	for i := range 2 {
+		if !limiter.Allow(testHosted, testUser, testChannel).Allowed {
+			t.Fatalf("Allow() for %s denied message %d", testHosted, i)
+		}
+	}
+
+	// Runtime settings leave luna's limits alone.
+	limiter.SetLimits(config.RateLimit{
+		User:    config.Bucket{PerMinute: 0, Burst: 0},
+		Channel: config.Bucket{PerMinute: 0, Burst: 0},
+		Global:  config.Bucket{PerMinute: 0, Burst: 0},
+	})
+
+	if !limiter.Allow(testCharacter, testUser, testChannel).Allowed {
+		t.Fatal("Allow() after lifting the shared limits = limited, want allowed")
+	}
+
+	if limiter.Allow(testHosted, testUser, testChannel).Allowed {
+		t.Fatalf("Allow() for %s = allowed over its own limit", testHosted)
+	}
+}
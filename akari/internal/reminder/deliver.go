@@ -0,0 +1,69 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/kizuna-org/akari/internal/chat"
+	"github.com/kizuna-org/akari/internal/discord"
+	"github.com/kizuna-org/akari/internal/job"
+)
+
+const (
+	instruction = "The time has come to remind the user of something they asked you to remind them of: %q. " +
+		"Write the reminder to them in a sentence or two, in your own voice, without greeting them by name."
+	fallbackMessage = "It's time! You asked me to remind you: %s"
+)
+
+// Speaker writes messages in a character's voice.
+type Speaker interface {
+	Speak(ctx context.Context, characterID string, instruction string) (string, error)
+}
+
+// Sender posts messages to Discord as a character.
+type Sender interface {
+	Send(characterID string, channelID string, content string) error
+}
+
+// NewHandler delivers due reminders as messages in the voice of the
+// character that was asked, mentioning the user.
+func NewHandler(responder *chat.Responder, bots *discord.Bots) job.Handler {
+	return newHandler(responder, bots)
+}
+
+func newHandler(speaker Speaker, sender Sender) job.Handler {
+	return job.Handler{
+		Kind:    Kind,
+		Timeout: 0,
+		Run: func(ctx context.Context, payload json.RawMessage) error {
+			var reminder Reminder
+
+			err := json.Unmarshal(payload, &reminder)
+			if err != nil {
+				return fmt.Errorf("decode reminder: %w", err)
+			}
+
+			return sender.Send(reminder.CharacterID, reminder.ChannelID,
+				"<@"+reminder.UserID+"> "+compose(ctx, speaker, reminder))
+		},
+	}
+}
+
+// compose has the character phrase the reminder. A reminder is better
+// delivered plainly than late, so a failure falls back to a template.
+func compose(ctx context.Context, speaker Speaker, reminder Reminder) string {
+	message, err := speaker.Speak(ctx, reminder.CharacterID, fmt.Sprintf(instruction, reminder.Text))
+	if err != nil {
+		slog.WarnContext(ctx, "compose reminder failed", "character_id", reminder.CharacterID, "error", err)
+	}
+
+	message = strings.TrimSpace(message)
+	if err != nil || message == "" {
+		return fmt.Sprintf(fallbackMessage, reminder.Text)
+	}
+
+	return message
+}
@@ -0,0 +1,159 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/job"
+)
+
+const (
+	// Kind is the kind of the jobs that deliver reminders.
+	Kind = "reminder"
+	// maxAhead is how far ahead a reminder may be set.
+	maxAhead = 366 * 24 * time.Hour
+
+	dateTimeLayout = "2006-01-02 15:04"
+	timeLayout     = "15:04"
+)
+
+var (
+	ErrEmpty       = errors.New("reminder text is empty")
+	ErrInvalidTime = errors.New("invalid reminder time")
+	ErrPast        = errors.New("reminder time has passed")
+	ErrTooFar      = errors.New("reminder time is too far ahead")
+)
+
+// Reminder is something a user asked a character to remind them of in a
+// channel. It is the payload of the job that delivers it.
+type Reminder struct {
+	CharacterID string `json:"character_id"`
+	ChannelID   string `json:"channel_id"`
+	UserID      string `json:"user_id"`
+	Text        string `json:"text"`
+}
+
+// Service schedules reminders, reading times in the timezone of the
+// character that is asked.
+type Service struct {
+	queue     *job.Queue
+	timezones map[string]string
+	now       func() time.Time
+}
+
+func NewService(cfg config.Config, queue *job.Queue) *Service {
+	timezones := map[string]string{cfg.Character.ID: cfg.Character.Timezone}
+	for _, hosted := range cfg.Characters {
+		timezones[hosted.ID] = hosted.Timezone
+	}
+
+	return &Service{queue: queue, timezones: timezones, now: time.Now}
+}
+
+// Schedule sets a reminder for when, as ParseTime reads it, and returns the
+// time it will be delivered.
+func (s *Service) Schedule(ctx context.Context, reminder Reminder, when string) (time.Time, error) {
+	reminder.Text = strings.TrimSpace(reminder.Text)
+	if reminder.Text == "" {
+		return time.Time{}, ErrEmpty
+	}
+
+	location, err := time.LoadLocation(s.timezones[reminder.CharacterID])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("load time zone of character %q: %w", reminder.CharacterID, err)
+	}
+
+	runAt, err := ParseTime(when, s.now().In(location))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	_, err = s.queue.Enqueue(ctx, Kind, reminder, runAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("schedule reminder: %w", err)
+	}
+
+	return runAt, nil
+}
+
+// ParseTime reads when relative to now and in its location. It is a
+// duration such as 30m, 2h or 1d12h, optionally preceded by "in", a time of
+// day such as 18:30, which is tomorrow once it has passed today, a date and
+// time such as 2026-12-24 09:00, or an RFC 3339 timestamp.
+func ParseTime(when string, now time.Time) (time.Time, error) {
+	when = strings.ToLower(strings.TrimSpace(when))
+
+	at, ok := parseTime(when, now)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTime, when)
+	}
+
+	if !at.After(now) {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrPast, at.Format(time.RFC3339))
+	}
+
+	if at.Sub(now) > maxAhead {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrTooFar, at.Format(time.RFC3339))
+	}
+
+	return at, nil
+}
+
+func parseTime(when string, now time.Time) (time.Time, bool) {
+	if duration, ok := parseDuration(strings.TrimPrefix(when, "in ")); ok {
+		return now.Add(duration), true
+	}
+
+	if at, err := time.Parse(time.RFC3339, strings.ToUpper(when)); err == nil {
+		return at, true
+	}
+
+	if at, err := time.ParseInLocation(dateTimeLayout, when, now.Location()); err == nil {
+		return at, true
+	}
+
+	clock, err := time.Parse(timeLayout, when)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	at := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+	if !at.After(now) {
+		at = at.AddDate(0, 0, 1)
+	}
+
+	return at, true
+}
+
+// parseDuration parses a Go duration that may start with a number of days.
+func parseDuration(value string) (time.Duration, bool) {
+	value = strings.ReplaceAll(value, " ", "")
+
+	var days time.Duration
+
+	if before, after, ok := strings.Cut(value, "d"); ok {
+		count, err := strconv.Atoi(before)
+		if err != nil {
+			return 0, false
+		}
+
+		days = time.Duration(count) * 24 * time.Hour
+		value = after
+	}
+
+	if value == "" {
+		return days, days > 0
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return days + duration, true
+}
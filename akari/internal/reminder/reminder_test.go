@@ -0,0 +1,166 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/job"
+	"github.com/kizuna-org/akari/internal/tool"
+)
+
+func TestParseTime(t *testing.T) {
+	t.Parallel()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, tokyo)
+
+	tests := []struct {
+		name    string
+		when    string
+		want    time.Time
+		wantErr error
+	}{
+		{name: "duration", when: "30m", want: now.Add(30 * time.Minute), wantErr: nil},
+		{name: "in duration", when: "in 1h30m", want: now.Add(90 * time.Minute), wantErr: nil},
+		{name: "days", when: "1d12h", want: now.Add(36 * time.Hour), wantErr: nil},
+		{name: "time today", when: "18:30", want: time.Date(2026, 10, 19, 18, 30, 0, 0, tokyo), wantErr: nil},
+		{name: "time tomorrow", when: "09:00", want: time.Date(2026, 10, 20, 9, 0, 0, 0, tokyo), wantErr: nil},
+		{
+			name:    "date and time",
+			when:    "2026-12-24 09:00",
+			want:    time.Date(2026, 12, 24, 9, 0, 0, 0, tokyo),
+			wantErr: nil,
+		},
+		{
+			name:    "rfc3339",
+			when:    "2026-10-19T12:00:00Z",
+			want:    time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+			wantErr: nil,
+		},
+		{name: "past", when: "2026-10-18 09:00", want: time.Time{}, wantErr: ErrPast},
+		{name: "too far", when: "400d", want: time.Time{}, wantErr: ErrTooFar},
+		{name: "invalid", when: "someday", want: time.Time{}, wantErr: ErrInvalidTime},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseTime(testCase.when, now)
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("ParseTime(%q) error = %v, want %v", testCase.when, err, testCase.wantErr)
+			}
+
+			if !got.Equal(testCase.want) {
+				t.Fatalf("ParseTime(%q) = %s, want %s", testCase.when, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestTool(t *testing.T) {
+	t.Parallel()
+
+	var cfg config.Config
+	cfg.Character = config.Character{ID: "akari", Name: "Akari", Timezone: "Asia/Tokyo"}
+
+	store := job.NewMemoryStore()
+	service := NewService(cfg, job.NewQueue(store))
+	service.now = func() time.Time { return time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC) }
+
+	handler := NewTool(service).Handler
+	caller := tool.Caller{CharacterID: "akari", ChannelID: "channel", UserID: "user", UserName: "Alice"}
+
+	output, err := handler(t.Context(), tool.Request{
+		Caller:    caller,
+		Arguments: json.RawMessage(`{"when":"18:30","text":"stretch"}`),
+	})
+	if err != nil {
+		t.Fatalf("set_reminder error = %v", err)
+	}
+
+	if got, _ := output.(Scheduled); got.RemindAt != "2026-10-19T18:30:00+09:00" {
+		t.Fatalf("set_reminder = %+v, want 18:30 in the character's timezone", output)
+	}
+
+	leased, _ := store.Lease(t.Context(), "test", time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC), time.Minute, 1)
+	if len(leased) != 1 || leased[0].Kind != Kind ||
+		string(leased[0].Payload) != `{"character_id":"akari","channel_id":"channel","user_id":"user","text":"stretch"}` {
+		t.Fatalf("scheduled jobs = %+v, want the reminder", leased)
+	}
+
+	_, err = handler(t.Context(), tool.Request{
+		Caller:    caller,
+		Arguments: json.RawMessage(`{"when":"someday","text":"stretch"}`),
+	})
+	if !errors.Is(err, tool.ErrInvalidArguments) {
+		t.Fatalf("set_reminder with an invalid time error = %v, want %v", err, tool.ErrInvalidArguments)
+	}
+}
+
+type fakeSpeaker struct {
+	message string
+	err     error
+}
+
+func (f fakeSpeaker) Speak(context.Context, string, string) (string, error) {
+	return f.message, f.err
+}
+
+type fakeSender struct {
+	sent []string
+}
+
+func (f *fakeSender) Send(_ string, channelID string, content string) error {
+	f.sent = append(f.sent, channelID+": "+content)
+
+	return nil
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	payload := json.RawMessage(`{"character_id":"akari","channel_id":"channel","user_id":"user","text":"stretch"}`)
+
+	tests := []struct {
+		name    string
+		speaker fakeSpeaker
+		want    string
+	}{
+		{
+			name:    "in character",
+			speaker: fakeSpeaker{message: "Time to stretch!", err: nil},
+			want:    "channel: <@user> Time to stretch!",
+		},
+		{
+			name:    "fallback",
+			speaker: fakeSpeaker{message: "", err: errors.New("model unavailable")},
+			want:    "channel: <@user> It's time! You asked me to remind you: stretch",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			sender := new(fakeSender)
+
+			err := newHandler(testCase.speaker, sender).Run(t.Context(), payload)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if len(sender.sent) != 1 || sender.sent[0] != testCase.want {
+				t.Fatalf("sent %q, want %q", sender.sent, testCase.want)
+			}
+		})
+	}
+}
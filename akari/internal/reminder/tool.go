@@ -0,0 +1,73 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/tool"
+)
+
+// Scheduled is what the set_reminder tool returns.
+type Scheduled struct {
+	RemindAt string `json:"remind_at"`
+}
+
+// NewTool lets characters set reminders for the user they are talking to,
+// in the channel they are talking in.
+func NewTool(reminders *Service) tool.Tool {
+	return tool.Tool{
+		Name:        "set_reminder",
+		Description: "Remind the user of something later, in this channel.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"when": map[string]any{
+					"type": "string",
+					"description": "When to remind them: a delay such as 30m, 2h or 1d12h, a time of day such as " +
+						"18:30, or a date and time such as 2026-12-24 09:00, in your timezone.",
+				},
+				"text": map[string]any{
+					"type":        "string",
+					"description": "What to remind them of, in a few words.",
+				},
+			},
+			"required": []string{"when", "text"},
+		},
+		Timeout: 0,
+		Handler: func(ctx context.Context, req tool.Request) (any, error) {
+			var args struct {
+				When string `json:"when"`
+				Text string `json:"text"`
+			}
+
+			err := req.Decode(&args)
+			if err != nil {
+				return nil, err
+			}
+
+			at, err := reminders.Schedule(ctx, Reminder{
+				CharacterID: req.CharacterID,
+				ChannelID:   req.ChannelID,
+				UserID:      req.UserID,
+				Text:        args.Text,
+			}, args.When)
+			if invalid(err) {
+				return nil, fmt.Errorf("%w: %w", tool.ErrInvalidArguments, err)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			return Scheduled{RemindAt: at.Format(time.RFC3339)}, nil
+		},
+	}
+}
+
+// invalid reports whether err is the user's mistake rather than a failure.
+func invalid(err error) bool {
+	return errors.Is(err, ErrEmpty) || errors.Is(err, ErrInvalidTime) ||
+		errors.Is(err, ErrPast) || errors.Is(err, ErrTooFar)
+}
@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	akariv1 "github.com/kizuna-org/akari/gen/proto/akari/v1"
+	"github.com/kizuna-org/akari/internal/account"
+	"github.com/kizuna-org/akari/internal/auth"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type AccountServer struct {
+	linker *account.Linker
+}
+
+func NewAccountServer(linker *account.Linker) *AccountServer {
+	return &AccountServer{linker: linker}
+}
+
+func (s *AccountServer) CreateLinkCode(
+	ctx context.Context,
+	_ *connect.Request[akariv1.CreateLinkCodeRequest],
+) (*connect.Response[akariv1.CreateLinkCodeResponse], error) {
+	principal, _ := auth.PrincipalFrom(ctx)
+
+	code, expiresAt, err := s.linker.CreateCode(ctx, principal.Subject)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	resp := new(akariv1.CreateLinkCodeResponse)
+	resp.Code = code
+	resp.ExpireTime = timestamppb.New(expiresAt)
+
+	return connect.NewResponse(resp), nil
+}
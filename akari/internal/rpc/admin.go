@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	akariv1 "github.com/kizuna-org/akari/gen/proto/akari/v1"
+	"github.com/kizuna-org/akari/internal/ratelimit"
+	"github.com/kizuna-org/akari/internal/settings"
+	"github.com/kizuna-org/akari/internal/sleep"
+)
+
+type AdminServer struct {
+	limiter    *ratelimit.Limiter
+	schedulers *sleep.Schedulers
+	settings   *settings.Manager
+}
+
+func NewAdminServer(
+	limiter *ratelimit.Limiter,
+	schedulers *sleep.Schedulers,
+	runtime *settings.Manager,
+) *AdminServer {
+	return &AdminServer{limiter: limiter, schedulers: schedulers, settings: runtime}
+}
+
+func (s *AdminServer) GetRateLimitStats(
+	_ context.Context,
+	_ *connect.Request[akariv1.GetRateLimitStatsRequest],
+) (*connect.Response[akariv1.GetRateLimitStatsResponse], error) {
+	stats := s.limiter.Stats()
+
+	resp := new(akariv1.GetRateLimitStatsResponse)
+	resp.Allowed = stats.Allowed
+	resp.LimitedUser = stats.LimitedUser
+	resp.LimitedChannel = stats.LimitedChannel
+	resp.LimitedGlobal = stats.LimitedGlobal
+
+	return connect.NewResponse(resp), nil
+}
+
+func (s *AdminServer) Sleep(
+	ctx context.Context,
+	req *connect.Request[akariv1.SleepRequest],
+) (*connect.Response[akariv1.SleepResponse], error) {
+	if !s.schedulers.Hosts(req.Msg.GetCharacterId()) {
+		return nil, connect.NewError(
+			connect.CodeNotFound,
+			fmt.Errorf("%w: %s", ErrCharacterNotFound, req.Msg.GetCharacterId()),
+		)
+	}
+
+	scheduler, ok := s.schedulers.Get(req.Msg.GetCharacterId())
+	if !ok {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, ErrSleepDisabled)
+	}
+
+	err := scheduler.Sleep(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	return connect.NewResponse(new(akariv1.SleepResponse)), nil
+}
+
+func (s *AdminServer) GetRuntimeConfig(
+	_ context.Context,
+	_ *connect.Request[akariv1.GetRuntimeConfigRequest],
+) (*connect.Response[akariv1.GetRuntimeConfigResponse], error) {
+	resp := new(akariv1.GetRuntimeConfigResponse)
+	resp.Entries = runtimeConfigEntries(s.settings.Entries())
+
+	return connect.NewResponse(resp), nil
+}
+
+func (s *AdminServer) PatchRuntimeConfig(
+	ctx context.Context,
+	req *connect.Request[akariv1.PatchRuntimeConfigRequest],
+) (*connect.Response[akariv1.PatchRuntimeConfigResponse], error) {
+	err := s.settings.Patch(ctx, req.Msg.GetSet(), req.Msg.GetUnset())
+	if errors.Is(err, settings.ErrUnknownKey) || errors.Is(err, settings.ErrInvalidValue) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := new(akariv1.PatchRuntimeConfigResponse)
+	resp.Entries = runtimeConfigEntries(s.settings.Entries())
+
+	return connect.NewResponse(resp), nil
+}
+
+func runtimeConfigEntries(entries []settings.Entry) []*akariv1.RuntimeConfigEntry {
+	converted := make([]*akariv1.RuntimeConfigEntry, 0, len(entries))
+
+	for _, entry := range entries {
+		message := new(akariv1.RuntimeConfigEntry)
+		message.Key = entry.Key
+		message.Value = entry.Value
+		message.Source = entry.Source
+		converted = append(converted, message)
+	}
+
+	return converted
+}
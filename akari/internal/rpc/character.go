@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	akariv1 "github.com/kizuna-org/akari/gen/proto/akari/v1"
+	"github.com/kizuna-org/akari/internal/character"
+	"github.com/kizuna-org/akari/internal/config"
+)
+
+type CharacterServer struct {
+	characters *character.Registry
+	sleep      map[string]config.Character
+}
+
+func NewCharacterServer(cfg config.Config, characters *character.Registry) *CharacterServer {
+	sleep := map[string]config.Character{cfg.Character.ID: cfg.Character}
+	for _, hosted := range cfg.Characters {
+		sleep[hosted.ID] = hosted
+	}
+
+	return &CharacterServer{characters: characters, sleep: sleep}
+}
+
+func (s *CharacterServer) ListCharacters(
+	_ context.Context,
+	_ *connect.Request[akariv1.ListCharactersRequest],
+) (*connect.Response[akariv1.ListCharactersResponse], error) {
+	hosted := s.characters.List()
+
+	characters := make([]*akariv1.Character, 0, len(hosted))
+	for _, entry := range hosted {
+		characters = append(characters, s.characterMessage(entry))
+	}
+
+	resp := new(akariv1.ListCharactersResponse)
+	resp.Characters = characters
+
+	return connect.NewResponse(resp), nil
+}
+
+func (s *CharacterServer) GetCharacter(
+	_ context.Context,
+	req *connect.Request[akariv1.GetCharacterRequest],
+) (*connect.Response[akariv1.GetCharacterResponse], error) {
+	found, ok := s.characters.Get(req.Msg.GetId())
+	if !ok || req.Msg.GetId() == "" {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("%w: %s", ErrCharacterNotFound, req.Msg.GetId()))
+	}
+
+	resp := new(akariv1.GetCharacterResponse)
+	resp.Character = s.characterMessage(found)
+
+	return connect.NewResponse(resp), nil
+}
+
+// characterMessage describes a character. The sleep schedule is still read
+// from the configuration and only applies to hosted characters.
+func (s *CharacterServer) characterMessage(hosted character.Character) *akariv1.Character {
+	message := new(akariv1.Character)
+	message.Id = hosted.ID
+	message.Name = hosted.Name
+
+	if configured, ok := s.sleep[hosted.ID]; ok {
+		message.SleepSchedule = configured.SleepSchedule
+		message.Timezone = configured.Timezone
+	}
+
+	return message
+}
@@ -0,0 +1,203 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"connectrpc.com/connect"
+	akariv1 "github.com/kizuna-org/akari/gen/proto/akari/v1"
+	"github.com/kizuna-org/akari/internal/character"
+	"github.com/kizuna-org/akari/internal/tool"
+)
+
+// profileFields are the update_mask paths UpdateCharacter accepts. An empty
+// mask replaces all of them.
+var profileFields = []string{"name", "persona", "prompt_template", "channel_ids", "tools"}
+
+type CharacterAdminServer struct {
+	characters *character.Registry
+	tools      *tool.Runner
+}
+
+func NewCharacterAdminServer(characters *character.Registry, tools *tool.Runner) *CharacterAdminServer {
+	return &CharacterAdminServer{characters: characters, tools: tools}
+}
+
+func (s *CharacterAdminServer) ListCharacterProfiles(
+	_ context.Context,
+	_ *connect.Request[akariv1.ListCharacterProfilesRequest],
+) (*connect.Response[akariv1.ListCharacterProfilesResponse], error) {
+	characters := s.characters.List()
+
+	profiles := make([]*akariv1.CharacterProfile, 0, len(characters))
+	for _, entry := range characters {
+		profiles = append(profiles, profileMessage(entry))
+	}
+
+	resp := new(akariv1.ListCharacterProfilesResponse)
+	resp.Profiles = profiles
+
+	return connect.NewResponse(resp), nil
+}
+
+func (s *CharacterAdminServer) CreateCharacter(
+	ctx context.Context,
+	req *connect.Request[akariv1.CreateCharacterRequest],
+) (*connect.Response[akariv1.CreateCharacterResponse], error) {
+	profile := req.Msg.GetProfile()
+
+	err := s.tools.Validate(profile.GetTools())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	created, err := s.characters.Create(ctx, character.Character{
+		ID:             profile.GetId(),
+		Name:           profile.GetName(),
+		Persona:        profile.GetPersona(),
+		PromptTemplate: profile.GetPromptTemplate(),
+		ChannelIDs:     profile.GetChannelIds(),
+		Tools:          profile.GetTools(),
+	})
+	if err != nil {
+		return nil, characterError(err)
+	}
+
+	resp := new(akariv1.CreateCharacterResponse)
+	resp.Profile = profileMessage(created)
+
+	return connect.NewResponse(resp), nil
+}
+
+func (s *CharacterAdminServer) UpdateCharacter(
+	ctx context.Context,
+	req *connect.Request[akariv1.UpdateCharacterRequest],
+) (*connect.Response[akariv1.UpdateCharacterResponse], error) {
+	profile := req.Msg.GetProfile()
+
+	paths := req.Msg.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = profileFields
+	}
+
+	for _, path := range paths {
+		if !slices.Contains(profileFields, path) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: %s", ErrUnknownField, path))
+		}
+	}
+
+	if slices.Contains(paths, "tools") {
+		err := s.tools.Validate(profile.GetTools())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	updated, err := s.characters.Update(ctx, profile.GetId(), func(target *character.Character) {
+		for _, path := range paths {
+			switch path {
+			case "name":
+				target.Name = profile.GetName()
+			case "persona":
+				target.Persona = profile.GetPersona()
+			case "prompt_template":
+				target.PromptTemplate = profile.GetPromptTemplate()
+			case "channel_ids":
+				target.ChannelIDs = profile.GetChannelIds()
+			case "tools":
+				target.Tools = profile.GetTools()
+			}
+		}
+	})
+	if err != nil {
+		return nil, characterError(err)
+	}
+
+	resp := new(akariv1.UpdateCharacterResponse)
+	resp.Profile = profileMessage(updated)
+
+	return connect.NewResponse(resp), nil
+}
+
+func (s *CharacterAdminServer) SetChannelEnabled(
+	ctx context.Context,
+	req *connect.Request[akariv1.SetChannelEnabledRequest],
+) (*connect.Response[akariv1.SetChannelEnabledResponse], error) {
+	channelID := req.Msg.GetChannelId()
+	if channelID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: channel_id", ErrMissingField))
+	}
+
+	updated, err := s.characters.Update(ctx, req.Msg.GetCharacterId(), func(target *character.Character) {
+		target.ChannelIDs = slices.DeleteFunc(target.ChannelIDs, func(id string) bool { return id == channelID })
+		if req.Msg.GetEnabled() {
+			target.ChannelIDs = append(target.ChannelIDs, channelID)
+		}
+	})
+	if err != nil {
+		return nil, characterError(err)
+	}
+
+	resp := new(akariv1.SetChannelEnabledResponse)
+	resp.Profile = profileMessage(updated)
+
+	return connect.NewResponse(resp), nil
+}
+
+func (s *CharacterAdminServer) PreviewSystemPrompt(
+	_ context.Context,
+	req *connect.Request[akariv1.PreviewSystemPromptRequest],
+) (*connect.Response[akariv1.PreviewSystemPromptResponse], error) {
+	preview, ok := s.characters.Get(req.Msg.GetCharacterId())
+	if !ok || req.Msg.GetCharacterId() == "" {
+		return nil, connect.NewError(
+			connect.CodeNotFound,
+			fmt.Errorf("%w: %s", ErrCharacterNotFound, req.Msg.GetCharacterId()),
+		)
+	}
+
+	if req.Msg.Persona != nil {
+		preview.Persona = req.Msg.GetPersona()
+	}
+
+	if req.Msg.PromptTemplate != nil {
+		preview.PromptTemplate = req.Msg.GetPromptTemplate()
+	}
+
+	prompt, err := preview.SystemPrompt(req.Msg.GetMemories())
+	if err != nil {
+		return nil, characterError(err)
+	}
+
+	resp := new(akariv1.PreviewSystemPromptResponse)
+	resp.SystemPrompt = prompt
+
+	return connect.NewResponse(resp), nil
+}
+
+func profileMessage(hosted character.Character) *akariv1.CharacterProfile {
+	profile := new(akariv1.CharacterProfile)
+	profile.Id = hosted.ID
+	profile.Name = hosted.Name
+	profile.Persona = hosted.Persona
+	profile.PromptTemplate = hosted.PromptTemplate
+	profile.ChannelIds = hosted.ChannelIDs
+	profile.Tools = hosted.Tools
+
+	return profile
+}
+
+func characterError(err error) error {
+	switch {
+	case errors.Is(err, character.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, character.ErrExists):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	case errors.Is(err, character.ErrInvalid), errors.Is(err, character.ErrInvalidTemplate):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
@@ -0,0 +1,157 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	akariv1 "github.com/kizuna-org/akari/gen/proto/akari/v1"
+	"github.com/kizuna-org/akari/internal/account"
+	"github.com/kizuna-org/akari/internal/auth"
+	"github.com/kizuna-org/akari/internal/character"
+	"github.com/kizuna-org/akari/internal/chat"
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/conversation"
+	"github.com/kizuna-org/akari/internal/memory"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ConversationServer struct {
+	primary       string
+	responder     *chat.Responder
+	memories      *memory.Service
+	conversations conversation.Store
+	accounts      *account.Linker
+}
+
+func NewConversationServer(
+	cfg config.Config,
+	responder *chat.Responder,
+	memories *memory.Service,
+	conversations conversation.Store,
+	accounts *account.Linker,
+) *ConversationServer {
+	return &ConversationServer{
+		primary:       cfg.Character.ID,
+		responder:     responder,
+		memories:      memories,
+		conversations: conversations,
+		accounts:      accounts,
+	}
+}
+
+// Reply records the message as the authenticated caller's, unless an admin
+// names another author, so that a user cannot speak for someone else in the
+// history and memories. A caller whose account is linked speaks as their
+// Discord user. As with Recall, only an admin may talk to a character other
+// than the primary one.
+func (s *ConversationServer) Reply(
+	ctx context.Context,
+	req *connect.Request[akariv1.ReplyRequest],
+) (*connect.Response[akariv1.ReplyResponse], error) {
+	principal, _ := auth.PrincipalFrom(ctx)
+
+	authorID, err := s.accounts.DiscordUser(ctx, principal.Subject)
+	if errors.Is(err, account.ErrNotLinked) {
+		authorID = principal.Subject
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	authorName := principal.Subject
+
+	if req.Msg.GetAuthorId() != "" || req.Msg.GetAuthorName() != "" {
+		field := "author_id"
+		if req.Msg.GetAuthorId() == "" {
+			field = "author_name"
+		}
+
+		err = adminOnly(principal, field)
+		if err != nil {
+			return nil, err
+		}
+
+		authorID, authorName = req.Msg.GetAuthorId(), req.Msg.GetAuthorName()
+	}
+
+	characterID, err := s.character(principal, req.Msg.GetCharacterId())
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.responder.Reply(ctx, chat.Message{
+		CharacterID: characterID,
+		GuildID:     "",
+		ChannelID:   req.Msg.GetChannelId(),
+		AuthorID:    authorID,
+		AuthorName:  authorName,
+		Content:     req.Msg.GetContent(),
+		Attachments: nil,
+	})
+	if errors.Is(err, character.ErrNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("reply: %w", err))
+	}
+
+	resp := new(akariv1.ReplyResponse)
+	resp.Content = content
+
+	return connect.NewResponse(resp), nil
+}
+
+// Recall searches the primary character's memories, or for an admin those
+// of the character the request names.
+func (s *ConversationServer) Recall(
+	ctx context.Context,
+	req *connect.Request[akariv1.RecallRequest],
+) (*connect.Response[akariv1.RecallResponse], error) {
+	principal, _ := auth.PrincipalFrom(ctx)
+
+	characterID, err := s.character(principal, req.Msg.GetCharacterId())
+	if err != nil {
+		return nil, err
+	}
+
+	fragments := s.memories.Recall(ctx, characterID, req.Msg.GetQuery())
+
+	memories := make([]*akariv1.Memory, 0, len(fragments))
+	for _, fragment := range fragments {
+		memory := new(akariv1.Memory)
+		memory.Data = fragment.Text()
+		memory.MemorizedAt = timestamppb.New(fragment.Meta.MemorizedAt)
+		memories = append(memories, memory)
+	}
+
+	resp := new(akariv1.RecallResponse)
+	resp.Memories = memories
+
+	return connect.NewResponse(resp), nil
+}
+
+// character returns the character a request names, the primary character
+// when it names none. Only an admin may name another character.
+func (s *ConversationServer) character(principal auth.Principal, requested string) (string, error) {
+	if requested == "" || requested == s.primary {
+		return s.primary, nil
+	}
+
+	err := adminOnly(principal, "character_id")
+	if err != nil {
+		return "", err
+	}
+
+	return requested, nil
+}
+
+// adminOnly rejects a request that sets field unless principal is an admin.
+func adminOnly(principal auth.Principal, field string) error {
+	if principal.Role == auth.RoleAdmin {
+		return nil
+	}
+
+	return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%w: %s", ErrAdminOnlyField, field))
+}
@@ -0,0 +1,158 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"connectrpc.com/connect"
+	akariv1 "github.com/kizuna-org/akari/gen/proto/akari/v1"
+	"github.com/kizuna-org/akari/internal/conversation"
+)
+
+const (
+	// exportChunkSize is roughly how many bytes of records each export
+	// message carries.
+	exportChunkSize = 64 << 10
+	// importBatchSize is how many turns are written to the store at a time.
+	importBatchSize = 100
+)
+
+func (s *ConversationServer) ExportConversations(
+	ctx context.Context,
+	req *connect.Request[akariv1.ExportConversationsRequest],
+	stream *connect.ServerStream[akariv1.ExportConversationsResponse],
+) error {
+	filter := conversation.Filter{
+		UserID:      req.Msg.GetUserId(),
+		ChannelID:   req.Msg.GetChannelId(),
+		CharacterID: req.Msg.GetCharacterId(),
+	}
+	if filter.UserID == "" && filter.ChannelID == "" {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: user_id or channel_id", ErrMissingField))
+	}
+
+	var buf bytes.Buffer
+
+	flush := func() error {
+		if buf.Len() == 0 {
+			return nil
+		}
+
+		resp := new(akariv1.ExportConversationsResponse)
+		resp.Data = bytes.Clone(buf.Bytes())
+		buf.Reset()
+
+		err := stream.Send(resp)
+		if err != nil {
+			return fmt.Errorf("send archive chunk: %w", err)
+		}
+
+		return nil
+	}
+
+	err := s.conversations.Each(ctx, filter, func(turn conversation.Turn) error {
+		err := conversation.Encode(&buf, turn)
+		if err != nil {
+			return err
+		}
+
+		if buf.Len() < exportChunkSize {
+			return nil
+		}
+
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("export conversations: %w", err))
+	}
+
+	return nil
+}
+
+func (s *ConversationServer) ImportConversations(
+	ctx context.Context,
+	stream *connect.ClientStream[akariv1.ImportConversationsRequest],
+) (*connect.Response[akariv1.ImportConversationsResponse], error) {
+	decoder := conversation.NewDecoder(&streamReader{stream: stream, buf: nil})
+	batch := make([]conversation.Turn, 0, importBatchSize)
+	resp := new(akariv1.ImportConversationsResponse)
+
+	save := func() error {
+		imported, err := s.conversations.Import(ctx, batch)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("import conversations: %w", err))
+		}
+
+		resp.Imported += int64(imported)
+		resp.Skipped += int64(len(batch) - imported)
+		batch = batch[:0]
+
+		return nil
+	}
+
+	for {
+		turn, err := decoder.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if errors.Is(err, conversation.ErrInvalidRecord) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		if err != nil {
+			return nil, connect.NewError(connect.CodeOf(err), err)
+		}
+
+		batch = append(batch, turn)
+		if len(batch) < importBatchSize {
+			continue
+		}
+
+		err = save()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := save()
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// streamReader exposes the data of an import stream as one byte stream, so
+// records split across messages decode as usual.
+type streamReader struct {
+	stream *connect.ClientStream[akariv1.ImportConversationsRequest]
+	buf    []byte
+}
+
+func (r *streamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if !r.stream.Receive() {
+			err := r.stream.Err()
+			if err != nil {
+				return 0, fmt.Errorf("receive archive chunk: %w", err)
+			}
+
+			return 0, io.EOF
+		}
+
+		r.buf = r.stream.Msg().GetData()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
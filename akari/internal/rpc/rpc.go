@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"errors"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/grpcreflect"
+	"connectrpc.com/otelconnect"
+	"github.com/kizuna-org/akari/gen/proto/akari/v1/akariv1connect"
+	"github.com/kizuna-org/akari/internal/auth"
+	"github.com/kizuna-org/akari/internal/health"
+	"github.com/kizuna-org/akari/internal/metrics"
+	"github.com/kizuna-org/akari/internal/server"
+)
+
+var (
+	ErrCharacterNotFound = errors.New("character not found")
+	ErrSleepDisabled     = errors.New("sleep is not configured")
+	ErrUnknownField      = errors.New("unknown field")
+	ErrMissingField      = errors.New("missing field")
+	ErrAdminOnlyField    = errors.New("field may only be set by an admin")
+)
+
+// reflectedServices are listed by gRPC server reflection so tools such as
+// grpcurl can discover the API without local proto files.
+var reflectedServices = []string{
+	akariv1connect.CharacterServiceName,
+	akariv1connect.ConversationServiceName,
+	akariv1connect.AdminServiceName,
+	akariv1connect.CharacterAdminServiceName,
+	akariv1connect.AccountServiceName,
+	health.ServiceName,
+}
+
+// NewRoutes mounts the akari.v1 Connect services and gRPC server reflection,
+// all behind the authentication interceptor. Calls are traced and measured
+// before they are authenticated, so rejected calls show up too.
+func NewRoutes(
+	character *CharacterServer,
+	conversation *ConversationServer,
+	admin *AdminServer,
+	characterAdmin *CharacterAdminServer,
+	account *AccountServer,
+	interceptor *auth.Interceptor,
+	rpcMetrics *metrics.RPCInterceptor,
+	rpcTracing *otelconnect.Interceptor,
+) []server.Route {
+	reflector := grpcreflect.NewStaticReflector(reflectedServices...)
+	options := connect.WithInterceptors(rpcTracing, rpcMetrics, interceptor)
+
+	return []server.Route{
+		route(akariv1connect.NewCharacterServiceHandler(character, options)),
+		route(akariv1connect.NewConversationServiceHandler(conversation, options)),
+		route(akariv1connect.NewAdminServiceHandler(admin, options)),
+		route(akariv1connect.NewCharacterAdminServiceHandler(characterAdmin, options)),
+		route(akariv1connect.NewAccountServiceHandler(account, options)),
+		route(grpcreflect.NewHandlerV1(reflector, options)),
+		route(grpcreflect.NewHandlerV1Alpha(reflector, options)),
+	}
+}
+
+func route(path string, handler http.Handler) server.Route {
+	return server.Route{Path: path, Handler: handler}
+}
@@ -0,0 +1,831 @@
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/grpcreflect"
+	"github.com/google/uuid"
+	akariv1 "github.com/kizuna-org/akari/gen/proto/akari/v1"
+	"github.com/kizuna-org/akari/gen/proto/akari/v1/akariv1connect"
+	"github.com/kizuna-org/akari/internal/account"
+	"github.com/kizuna-org/akari/internal/attachment"
+	"github.com/kizuna-org/akari/internal/auth"
+	"github.com/kizuna-org/akari/internal/character"
+	"github.com/kizuna-org/akari/internal/chat"
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/conversation"
+	"github.com/kizuna-org/akari/internal/kiseki"
+	"github.com/kizuna-org/akari/internal/llm"
+	"github.com/kizuna-org/akari/internal/memory"
+	"github.com/kizuna-org/akari/internal/metrics"
+	"github.com/kizuna-org/akari/internal/ratelimit"
+	"github.com/kizuna-org/akari/internal/secret"
+	"github.com/kizuna-org/akari/internal/server"
+	"github.com/kizuna-org/akari/internal/settings"
+	"github.com/kizuna-org/akari/internal/sleep"
+	"github.com/kizuna-org/akari/internal/tool"
+	"github.com/kizuna-org/akari/internal/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const (
+	testCharacterID = "0193b1c6-6f5e-7a51-9a3c-3f0d1c2b4e5f"
+	testAdminKey    = "admin-key"
+	testUserKey     = "user-key"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return newTestServerWithAccounts(t, account.NewMemoryStore())
+}
+
+// newTestServerWithAccounts links accounts through accounts, which a test
+// shares to redeem the codes the server issues.
+func newTestServerWithAccounts(t *testing.T, accounts account.Store) *httptest.Server {
+	t.Helper()
+
+	var cfg config.Config
+	cfg.Character.ID = testCharacterID
+	cfg.Character.Name = "Akari"
+	cfg.Auth.APIKeys = "ops:admin:" + testAdminKey + ",alice:user:" + testUserKey
+
+	secrets, err := secret.NewStore(cfg, secret.NoProvider{})
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	interceptor, err := auth.NewInterceptor(cfg, secrets)
+	if err != nil {
+		t.Fatalf("NewInterceptor() error = %v", err)
+	}
+
+	client, err := kiseki.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	rpcMetrics, err := metrics.NewRPCInterceptor(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("NewRPCInterceptor() error = %v", err)
+	}
+
+	rpcTracing, err := tracing.NewRPCInterceptor()
+	if err != nil {
+		t.Fatalf("NewRPCInterceptor() error = %v", err)
+	}
+
+	characters := character.NewRegistry(cfg, character.NewMemoryStore())
+
+	err = characters.Load(t.Context())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	memories := memory.NewService(cfg, client)
+	limiter := ratelimit.NewLimiter(cfg)
+	conversations := conversation.NewMemoryStore()
+
+	runtime, err := settings.NewManager(cfg, settings.NewMemoryStore(), []settings.Subscriber{
+		ratelimit.NewSettingsSubscriber(limiter),
+	})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	tools, err := tool.NewRunner(cfg, prometheus.NewRegistry(), characters, nil)
+	if err != nil {
+		t.Fatalf("NewRunner() error = %v", err)
+	}
+
+	fetcher, err := attachment.NewFetcher(cfg, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("NewFetcher() error = %v", err)
+	}
+
+	responder := chat.NewResponder(llm.NewFake(), memories, limiter, characters, conversations, runtime, tools, fetcher)
+
+	// kiseki is disabled, so no character sleeps and no bot is needed.
+	schedulers, err := sleep.NewSchedulers(cfg, client, nil, nil)
+	if err != nil {
+		t.Fatalf("NewSchedulers() error = %v", err)
+	}
+
+	linker := account.NewLinker(accounts)
+
+	routes := NewRoutes(
+		NewCharacterServer(cfg, characters),
+		NewConversationServer(cfg, responder, memories, conversations, linker),
+		NewAdminServer(limiter, schedulers, runtime),
+		NewCharacterAdminServer(characters, tools),
+		NewAccountServer(linker),
+		interceptor,
+		rpcMetrics,
+		rpcTracing,
+	)
+
+	mux := server.NewMux(routes)
+	httpServer := httptest.NewUnstartedServer(mux)
+	httpServer.Config = server.NewHTTPServer(cfg, mux)
+	httpServer.Start()
+	t.Cleanup(httpServer.Close)
+
+	return httpServer
+}
+
+// bearer authenticates every request with token.
+type bearer struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (b bearer) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+
+	return b.base.RoundTrip(req)
+}
+
+func newClient(token string) *http.Client {
+	client := new(http.Client)
+	client.Transport = bearer{token: token, base: http.DefaultTransport}
+
+	return client
+}
+
+// newH2CClient speaks cleartext HTTP/2, as gRPC clients do.
+func newH2CClient(token string) *http.Client {
+	transport := new(http.Transport)
+	transport.Protocols = new(http.Protocols)
+	transport.Protocols.SetUnencryptedHTTP2(true)
+
+	client := new(http.Client)
+	client.Transport = bearer{token: token, base: transport}
+
+	return client
+}
+
+func TestProtocols(t *testing.T) {
+	t.Parallel()
+
+	httpServer := newTestServer(t)
+
+	tests := []struct {
+		name       string
+		httpClient *http.Client
+		options    []connect.ClientOption
+	}{
+		{name: "connect", httpClient: newClient(testUserKey), options: nil},
+		{
+			name:       "grpc-web",
+			httpClient: newClient(testUserKey),
+			options:    []connect.ClientOption{connect.WithGRPCWeb()},
+		},
+		{
+			name:       "grpc over h2c",
+			httpClient: newH2CClient(testUserKey),
+			options:    []connect.ClientOption{connect.WithGRPC()},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := akariv1connect.NewCharacterServiceClient(testCase.httpClient, httpServer.URL, testCase.options...)
+
+			_, err := client.ListCharacters(t.Context(), connect.NewRequest(new(akariv1.ListCharactersRequest)))
+			if err != nil {
+				t.Fatalf("ListCharacters() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestReflection(t *testing.T) {
+	t.Parallel()
+
+	httpServer := newTestServer(t)
+	stream := grpcreflect.NewClient(newH2CClient(testUserKey), httpServer.URL).NewStream(t.Context())
+
+	t.Cleanup(func() { _, _ = stream.Close() })
+
+	names, err := stream.ListServices()
+	if err != nil {
+		t.Fatalf("ListServices() error = %v", err)
+	}
+
+	if len(names) != len(reflectedServices) {
+		t.Fatalf("ListServices() = %v, want %v", names, reflectedServices)
+	}
+
+	for _, name := range reflectedServices {
+		_, err := stream.FileContainingSymbol(protoreflect.FullName(name))
+		if err != nil {
+			t.Errorf("FileContainingSymbol(%s) error = %v", name, err)
+		}
+	}
+}
+
+func TestCharacterServer(t *testing.T) {
+	t.Parallel()
+
+	httpServer := newTestServer(t)
+	client := akariv1connect.NewCharacterServiceClient(newClient(testUserKey), httpServer.URL)
+
+	list, err := client.ListCharacters(t.Context(), connect.NewRequest(new(akariv1.ListCharactersRequest)))
+	if err != nil {
+		t.Fatalf("ListCharacters() error = %v", err)
+	}
+
+	if len(list.Msg.GetCharacters()) != 1 || list.Msg.GetCharacters()[0].GetName() != "Akari" {
+		t.Fatalf("ListCharacters() = %v", list.Msg.GetCharacters())
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{name: "known character", id: testCharacterID, wantErr: false},
+		{name: "unknown character", id: "unknown", wantErr: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := new(akariv1.GetCharacterRequest)
+			req.Id = testCase.id
+
+			_, err := client.GetCharacter(t.Context(), connect.NewRequest(req))
+			if testCase.wantErr && connect.CodeOf(err) != connect.CodeNotFound {
+				t.Fatalf("GetCharacter() error = %v, want not found", err)
+			}
+
+			if !testCase.wantErr && err != nil {
+				t.Fatalf("GetCharacter() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestConversationServerReply(t *testing.T) {
+	t.Parallel()
+
+	httpServer := newTestServer(t)
+
+	tests := []struct {
+		name        string
+		token       string
+		authorID    string
+		authorName  string
+		characterID string
+		want        connect.Code
+		wantField   string
+	}{
+		{
+			name: "caller is the author", token: testUserKey, authorID: "", authorName: "", characterID: "",
+			want: 0, wantField: "",
+		},
+		{
+			name: "user names an author", token: testUserKey, authorID: "bob", authorName: "", characterID: "",
+			want: connect.CodePermissionDenied, wantField: "author_id",
+		},
+		{
+			name: "user names an author name", token: testUserKey, authorID: "", authorName: "Bob", characterID: "",
+			want: connect.CodePermissionDenied, wantField: "author_name",
+		},
+		{
+			name: "admin names an author", token: testAdminKey, authorID: "bob", authorName: "", characterID: "",
+			want: 0, wantField: "",
+		},
+		{
+			name: "user names the primary character", token: testUserKey, authorID: "", authorName: "",
+			characterID: testCharacterID, want: 0, wantField: "",
+		},
+		{
+			name: "user names a character", token: testUserKey, authorID: "", authorName: "", characterID: "luna",
+			want: connect.CodePermissionDenied, wantField: "character_id",
+		},
+		{
+			name: "admin names a character", token: testAdminKey, authorID: "", authorName: "", characterID: "luna",
+			want: connect.CodeNotFound, wantField: "",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := akariv1connect.NewConversationServiceClient(newClient(testCase.token), httpServer.URL)
+
+			req := new(akariv1.ReplyRequest)
+			req.CharacterId = testCase.characterID
+			req.ChannelId = "channel-" + testCase.name
+			req.AuthorId = testCase.authorID
+			req.AuthorName = testCase.authorName
+			req.Content = "hello"
+
+			resp, err := client.Reply(t.Context(), connect.NewRequest(req))
+			if testCase.want != 0 {
+				if connect.CodeOf(err) != testCase.want {
+					t.Fatalf("Reply() error = %v, want code %v", err, testCase.want)
+				}
+
+				if !strings.HasSuffix(err.Error(), testCase.wantField) {
+					t.Fatalf("Reply() error = %v, want it to name %q", err, testCase.wantField)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Reply() error = %v", err)
+			}
+
+			if resp.Msg.GetContent() != "You said: hello" {
+				t.Fatalf("Reply() = %q", resp.Msg.GetContent())
+			}
+		})
+	}
+}
+
+func TestAccountServerLink(t *testing.T) {
+	t.Parallel()
+
+	accounts := account.NewMemoryStore()
+	httpServer := newTestServerWithAccounts(t, accounts)
+	user := akariv1connect.NewConversationServiceClient(newClient(testUserKey), httpServer.URL)
+	admin := akariv1connect.NewConversationServiceClient(newClient(testAdminKey), httpServer.URL)
+
+	code, err := akariv1connect.NewAccountServiceClient(newClient(testUserKey), httpServer.URL).
+		CreateLinkCode(t.Context(), connect.NewRequest(new(akariv1.CreateLinkCodeRequest)))
+	if err != nil {
+		t.Fatalf("CreateLinkCode() error = %v", err)
+	}
+
+	// What /link does when the Discord user redeems the code.
+	subject, err := account.NewLinker(accounts).Link(t.Context(), code.Msg.GetCode(), "discord-alice")
+	if err != nil || subject != "alice" {
+		t.Fatalf("Link() = %q, %v, want alice", subject, err)
+	}
+
+	reply := new(akariv1.ReplyRequest)
+	reply.ChannelId = "channel"
+	reply.Content = "hello"
+
+	_, err = user.Reply(t.Context(), connect.NewRequest(reply))
+	if err != nil {
+		t.Fatalf("Reply() error = %v", err)
+	}
+
+	export := new(akariv1.ExportConversationsRequest)
+	export.UserId = "discord-alice"
+
+	archive, err := exportArchive(t, admin, export)
+	if err != nil || !bytes.Contains(archive, []byte("hello")) {
+		t.Fatalf("ExportConversations() = %q, %v, want the reply recorded as the Discord user", archive, err)
+	}
+}
+
+func TestConversationServerRecall(t *testing.T) {
+	t.Parallel()
+
+	httpServer := newTestServer(t)
+
+	tests := []struct {
+		name        string
+		token       string
+		characterID string
+		want        connect.Code
+	}{
+		{name: "primary character", token: testUserKey, characterID: "", want: 0},
+		{name: "user names a character", token: testUserKey, characterID: "luna", want: connect.CodePermissionDenied},
+		{name: "admin names a character", token: testAdminKey, characterID: "luna", want: 0},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := akariv1connect.NewConversationServiceClient(newClient(testCase.token), httpServer.URL)
+
+			req := new(akariv1.RecallRequest)
+			req.CharacterId = testCase.characterID
+			req.Query = "tea"
+
+			_, err := client.Recall(t.Context(), connect.NewRequest(req))
+			if testCase.want == 0 && err != nil {
+				t.Fatalf("Recall() error = %v", err)
+			}
+
+			if testCase.want != 0 && connect.CodeOf(err) != testCase.want {
+				t.Fatalf("Recall() error = %v, want code %v", err, testCase.want)
+			}
+		})
+	}
+}
+
+func testArchive(t *testing.T) []byte {
+	t.Helper()
+
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	turns := []conversation.Turn{
+		{
+			ID: uuid.MustParse("0199a1b2-0000-7000-8000-000000000001"), CharacterID: testCharacterID, GuildID: "guild",
+			ChannelID: "general", AuthorID: "alice", AuthorName: "Alice", Message: "hello", Attachments: nil,
+			Reply: "hi!", Memories: []string{"Alice likes tea."}, CreatedAt: base,
+		},
+		{
+			ID: uuid.MustParse("0199a1b2-0000-7000-8000-000000000002"), CharacterID: testCharacterID, GuildID: "",
+			ChannelID: "dm", AuthorID: "alice", AuthorName: "Alice", Message: "are you there?", Attachments: nil,
+			Reply: "always", Memories: []string{}, CreatedAt: base.Add(time.Minute),
+		},
+		{
+			ID: uuid.MustParse("0199a1b2-0000-7000-8000-000000000003"), CharacterID: testCharacterID, GuildID: "guild",
+			ChannelID: "general", AuthorID: "bob", AuthorName: "Bob", Message: "hey", Attachments: nil,
+			Reply: "hey Bob", Memories: []string{}, CreatedAt: base.Add(2 * time.Minute),
+		},
+	}
+
+	var buf bytes.Buffer
+
+	for _, turn := range turns {
+		err := conversation.Encode(&buf, turn)
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+	}
+
+	return buf.Bytes()
+}
+
+// importArchive uploads archive in chunks that split records.
+func importArchive(
+	t *testing.T,
+	client akariv1connect.ConversationServiceClient,
+	archive []byte,
+) (*akariv1.ImportConversationsResponse, error) {
+	t.Helper()
+
+	const chunkSize = 100
+
+	stream := client.ImportConversations(t.Context())
+
+	for chunk := range slices.Chunk(archive, chunkSize) {
+		req := new(akariv1.ImportConversationsRequest)
+		req.Data = chunk
+
+		err := stream.Send(req)
+		if err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	resp, err := stream.CloseAndReceive()
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg, nil
+}
+
+func exportArchive(
+	t *testing.T,
+	client akariv1connect.ConversationServiceClient,
+	req *akariv1.ExportConversationsRequest,
+) ([]byte, error) {
+	t.Helper()
+
+	stream, err := client.ExportConversations(t.Context(), connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	var archive []byte
+	for stream.Receive() {
+		archive = append(archive, stream.Msg().GetData()...)
+	}
+
+	return archive, stream.Err()
+}
+
+func TestConversationArchive(t *testing.T) {
+	t.Parallel()
+
+	httpServer := newTestServer(t)
+	client := akariv1connect.NewConversationServiceClient(newClient(testAdminKey), httpServer.URL)
+	archive := testArchive(t)
+
+	for _, want := range [][2]int64{{3, 0}, {0, 3}} {
+		resp, err := importArchive(t, client, archive)
+		if err != nil {
+			t.Fatalf("ImportConversations() error = %v", err)
+		}
+
+		if resp.GetImported() != want[0] || resp.GetSkipped() != want[1] {
+			t.Fatalf("ImportConversations() = %d imported, %d skipped, want %v", resp.GetImported(), resp.GetSkipped(), want)
+		}
+	}
+
+	lines := bytes.SplitAfter(archive, []byte("\n"))
+
+	tests := []struct {
+		name      string
+		userID    string
+		channelID string
+		want      []byte
+	}{
+		{name: "user", userID: "alice", channelID: "", want: bytes.Join(lines[:2], nil)},
+		{name: "channel", userID: "", channelID: "general", want: append(slices.Clone(lines[0]), lines[2]...)},
+		{name: "user in channel", userID: "bob", channelID: "general", want: lines[2]},
+		{name: "nobody", userID: "carol", channelID: "", want: nil},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := new(akariv1.ExportConversationsRequest)
+			req.UserId = testCase.userID
+			req.ChannelId = testCase.channelID
+
+			got, err := exportArchive(t, client, req)
+			if err != nil {
+				t.Fatalf("ExportConversations() error = %v", err)
+			}
+
+			if !bytes.Equal(got, testCase.want) {
+				t.Fatalf("ExportConversations() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestConversationArchiveErrors(t *testing.T) {
+	t.Parallel()
+
+	httpServer := newTestServer(t)
+	admin := akariv1connect.NewConversationServiceClient(newClient(testAdminKey), httpServer.URL)
+	user := akariv1connect.NewConversationServiceClient(newClient(testUserKey), httpServer.URL)
+
+	export := new(akariv1.ExportConversationsRequest)
+	export.UserId = "alice"
+
+	_, err := exportArchive(t, user, export)
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("ExportConversations() as user error = %v, want permission denied", err)
+	}
+
+	_, err = importArchive(t, user, testArchive(t))
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("ImportConversations() as user error = %v, want permission denied", err)
+	}
+
+	_, err = exportArchive(t, admin, new(akariv1.ExportConversationsRequest))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("ExportConversations() without filter error = %v, want invalid argument", err)
+	}
+
+	_, err = importArchive(t, admin, []byte(`{"id":"not-a-uuid"}`+"\n"))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("ImportConversations() of a bad record error = %v, want invalid argument", err)
+	}
+}
+
+func TestAdminServer(t *testing.T) {
+	t.Parallel()
+
+	httpServer := newTestServer(t)
+	client := akariv1connect.NewAdminServiceClient(newClient(testAdminKey), httpServer.URL)
+
+	_, err := client.GetRateLimitStats(t.Context(), connect.NewRequest(new(akariv1.GetRateLimitStatsRequest)))
+	if err != nil {
+		t.Fatalf("GetRateLimitStats() error = %v", err)
+	}
+
+	req := new(akariv1.SleepRequest)
+	req.CharacterId = testCharacterID
+
+	_, err = client.Sleep(t.Context(), connect.NewRequest(req))
+
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeFailedPrecondition {
+		t.Fatalf("Sleep() error = %v, want failed precondition", err)
+	}
+}
+
+func TestRuntimeConfig(t *testing.T) {
+	t.Parallel()
+
+	httpServer := newTestServer(t)
+	client := akariv1connect.NewAdminServiceClient(newClient(testAdminKey), httpServer.URL)
+
+	patch := new(akariv1.PatchRuntimeConfigRequest)
+	patch.Set = map[string]string{settings.KeyRateLimitUserBurst: "5", settings.KeyLLMTemperature: "0.2"}
+
+	patched, err := client.PatchRuntimeConfig(t.Context(), connect.NewRequest(patch))
+	if err != nil {
+		t.Fatalf("PatchRuntimeConfig() error = %v", err)
+	}
+
+	sources := map[string]string{}
+	for _, entry := range patched.Msg.GetEntries() {
+		sources[entry.GetKey()] = entry.GetSource() + "=" + entry.GetValue()
+	}
+
+	for key, want := range map[string]string{
+		settings.KeyRateLimitUserBurst:     settings.SourceOverride + "=5",
+		settings.KeyLLMTemperature:         settings.SourceOverride + "=0.2",
+		settings.KeyRateLimitUserPerMinute: settings.SourceDefault + "=0",
+	} {
+		if sources[key] != want {
+			t.Errorf("entry %s = %q, want %q", key, sources[key], want)
+		}
+	}
+
+	invalid := new(akariv1.PatchRuntimeConfigRequest)
+	invalid.Set = map[string]string{settings.KeyRateLimitUserBurst: "-1", "discord.token": "secret"}
+	invalid.Unset = []string{settings.KeyLLMTemperature}
+
+	_, err = client.PatchRuntimeConfig(t.Context(), connect.NewRequest(invalid))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("PatchRuntimeConfig() error = %v, want invalid argument", err)
+	}
+
+	current, err := client.GetRuntimeConfig(t.Context(), connect.NewRequest(new(akariv1.GetRuntimeConfigRequest)))
+	if err != nil {
+		t.Fatalf("GetRuntimeConfig() error = %v", err)
+	}
+
+	for _, entry := range current.Msg.GetEntries() {
+		if entry.GetKey() == settings.KeyLLMTemperature && entry.GetValue() != "0.2" {
+			t.Fatalf("rejected patch changed %s to %q", entry.GetKey(), entry.GetValue())
+		}
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	t.Parallel()
+
+	httpServer := newTestServer(t)
+
+	tests := []struct {
+		name       string
+		httpClient *http.Client
+		want       connect.Code
+	}{
+		{name: "anonymous", httpClient: http.DefaultClient, want: connect.CodeUnauthenticated},
+		{name: "unknown key", httpClient: newClient("wrong"), want: connect.CodeUnauthenticated},
+		{name: "regular user", httpClient: newClient(testUserKey), want: connect.CodePermissionDenied},
+		{name: "admin", httpClient: newClient(testAdminKey), want: 0},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := akariv1connect.NewAdminServiceClient(testCase.httpClient, httpServer.URL)
+
+			_, err := client.GetRateLimitStats(t.Context(), connect.NewRequest(new(akariv1.GetRateLimitStatsRequest)))
+			if testCase.want == 0 && err != nil {
+				t.Fatalf("GetRateLimitStats() error = %v", err)
+			}
+
+			if testCase.want != 0 && connect.CodeOf(err) != testCase.want {
+				t.Fatalf("GetRateLimitStats() error = %v, want code %v", err, testCase.want)
+			}
+		})
+	}
+}
+
+func TestCharacterAdminServer(t *testing.T) {
+	t.Parallel()
+
+	httpServer := newTestServer(t)
+	admin := akariv1connect.NewCharacterAdminServiceClient(newClient(testAdminKey), httpServer.URL)
+	characters := akariv1connect.NewCharacterServiceClient(newClient(testUserKey), httpServer.URL)
+
+	created := new(akariv1.CreateCharacterRequest)
+	created.Profile = new(akariv1.CharacterProfile)
+	created.Profile.Id = "hikari"
+	created.Profile.Name = "Hikari"
+
+	_, err := admin.CreateCharacter(t.Context(), connect.NewRequest(created))
+	if err != nil {
+		t.Fatalf("CreateCharacter() error = %v", err)
+	}
+
+	_, err = admin.CreateCharacter(t.Context(), connect.NewRequest(created))
+	if connect.CodeOf(err) != connect.CodeAlreadyExists {
+		t.Fatalf("CreateCharacter() error = %v, want already exists", err)
+	}
+
+	update := new(akariv1.UpdateCharacterRequest)
+	update.Profile = new(akariv1.CharacterProfile)
+	update.Profile.Id = testCharacterID
+	update.Profile.Name = "ignored"
+	update.Profile.Persona = "You love green tea."
+	update.UpdateMask = new(fieldmaskpb.FieldMask)
+	update.UpdateMask.Paths = []string{"persona"}
+
+	updated, err := admin.UpdateCharacter(t.Context(), connect.NewRequest(update))
+	if err != nil {
+		t.Fatalf("UpdateCharacter() error = %v", err)
+	}
+
+	if updated.Msg.GetProfile().GetName() != "Akari" || updated.Msg.GetProfile().GetPersona() != update.Profile.Persona {
+		t.Fatalf("UpdateCharacter() = %v", updated.Msg.GetProfile())
+	}
+
+	update.Profile.Tools = []string{"missing"}
+	update.UpdateMask.Paths = []string{"tools"}
+
+	_, err = admin.UpdateCharacter(t.Context(), connect.NewRequest(update))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("UpdateCharacter() with an unknown tool error = %v, want invalid argument", err)
+	}
+
+	channel := new(akariv1.SetChannelEnabledRequest)
+	channel.CharacterId = testCharacterID
+	channel.ChannelId = "general"
+	channel.Enabled = true
+
+	enabled, err := admin.SetChannelEnabled(t.Context(), connect.NewRequest(channel))
+	if err != nil {
+		t.Fatalf("SetChannelEnabled() error = %v", err)
+	}
+
+	if got := enabled.Msg.GetProfile().GetChannelIds(); len(got) != 1 || got[0] != "general" {
+		t.Fatalf("SetChannelEnabled() channels = %v", got)
+	}
+
+	list, err := characters.ListCharacters(t.Context(), connect.NewRequest(new(akariv1.ListCharactersRequest)))
+	if err != nil {
+		t.Fatalf("ListCharacters() error = %v", err)
+	}
+
+	if len(list.Msg.GetCharacters()) != 2 {
+		t.Fatalf("ListCharacters() = %v, want the created character listed", list.Msg.GetCharacters())
+	}
+
+	tests := []struct {
+		name     string
+		template *string
+		want     string
+		wantCode connect.Code
+	}{
+		{
+			name:     "saved template",
+			template: nil,
+			want: "You are Akari, a friendly companion chatting on Discord.\n\nYou love green tea.\n\n" +
+				"Things you remember:\n- Alice: I like tea.",
+		},
+		{
+			name:     "unsaved template",
+			template: proto.String("{{.Name}}: {{.Persona}} ({{len .Memories}})"),
+			want:     "Akari: You love green tea. (1)",
+		},
+		{name: "invalid template", template: proto.String("{{.Name"), wantCode: connect.CodeInvalidArgument},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := new(akariv1.PreviewSystemPromptRequest)
+			req.CharacterId = testCharacterID
+			req.PromptTemplate = testCase.template
+			req.Memories = []string{"Alice: I like tea."}
+
+			resp, err := admin.PreviewSystemPrompt(t.Context(), connect.NewRequest(req))
+			if testCase.wantCode != 0 {
+				if connect.CodeOf(err) != testCase.wantCode {
+					t.Fatalf("PreviewSystemPrompt() error = %v, want code %v", err, testCase.wantCode)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("PreviewSystemPrompt() error = %v", err)
+			}
+
+			if resp.Msg.GetSystemPrompt() != testCase.want {
+				t.Fatalf("PreviewSystemPrompt() = %q, want %q", resp.Msg.GetSystemPrompt(), testCase.want)
+			}
+		})
+	}
+}
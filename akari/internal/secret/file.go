@@ -0,0 +1,37 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileProvider reads each secret from a file named after it in a directory,
+// such as a mounted Kubernetes secret volume. It stands in for Secret
+// Manager locally.
+type FileProvider struct {
+	dir string
+}
+
+var _ Provider = (*FileProvider)(nil)
+
+func NewFileProvider(dir string) *FileProvider {
+	return &FileProvider{dir: dir}
+}
+
+func (p *FileProvider) Secret(_ context.Context, name string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(p.dir, filepath.Base(name)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("read secret %s: %w", name, err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,73 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"cloud.google.com/go/auth/credentials"
+	"cloud.google.com/go/auth/httptransport"
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/tracing"
+)
+
+const (
+	ProviderNone = "none"
+	ProviderFile = "file"
+	ProviderGCP  = "gcp"
+
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+// Names of the secrets a provider may supply. They match the environment
+// variables they replace, which are also valid Secret Manager IDs.
+const (
+	DatabasePassword = "POSTGRES_PASSWORD"
+	DiscordToken     = "DISCORD_TOKEN"
+	APIKeys          = "AUTH_API_KEYS"
+)
+
+var (
+	ErrNotFound        = errors.New("secret not found")
+	ErrUnknownProvider = errors.New("unknown secret provider")
+)
+
+// Provider fetches the current value of a named secret. It returns
+// ErrNotFound when it does not hold the secret, in which case the value from
+// the environment is kept.
+type Provider interface {
+	Secret(ctx context.Context, name string) (string, error)
+}
+
+// NoProvider holds no secrets, leaving the values read at startup in place.
+type NoProvider struct{}
+
+func (NoProvider) Secret(context.Context, string) (string, error) {
+	return "", ErrNotFound
+}
+
+// NewProvider builds the provider selected by SECRETS_PROVIDER, NoProvider
+// for "none".
+func NewProvider(cfg config.Config) (Provider, error) {
+	switch cfg.Secrets.Provider {
+	case ProviderNone:
+		return NoProvider{}, nil
+	case ProviderFile:
+		return NewFileProvider(cfg.Secrets.Dir), nil
+	case ProviderGCP:
+		options := new(httptransport.Options)
+		options.BaseRoundTripper = tracing.NewTransport(http.DefaultTransport)
+		options.DetectOpts = new(credentials.DetectOptions)
+		options.DetectOpts.Scopes = []string{cloudPlatformScope}
+
+		client, err := httptransport.NewClient(options)
+		if err != nil {
+			return nil, fmt.Errorf("create secret manager client: %w", err)
+		}
+
+		return NewSecretManager(client, cfg.Secrets.Endpoint, cfg.Secrets.ProjectID), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, cfg.Secrets.Provider)
+	}
+}
@@ -0,0 +1,202 @@
+package secret
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/config"
+)
+
+const (
+	testProject  = "kizuna-org"
+	testPassword = "rotated-password"
+)
+
+var errUnavailable = errors.New("unavailable")
+
+func TestFileProvider(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, DatabasePassword), []byte(testPassword+"\n"), 0o600)
+	if err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		secret  string
+		want    string
+		wantErr error
+	}{
+		{name: "reads trimmed file", secret: DatabasePassword, want: testPassword, wantErr: nil},
+		{name: "missing file", secret: DiscordToken, want: "", wantErr: ErrNotFound},
+		{name: "stays in directory", secret: "../" + DatabasePassword, want: testPassword, wantErr: nil},
+	}
+
+	provider := NewFileProvider(dir)
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := provider.Secret(t.Context(), testCase.secret)
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("Secret() error = %v, want %v", err, testCase.wantErr)
+			}
+
+			if got != testCase.want {
+				t.Fatalf("Secret() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestSecretManager(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/projects/" + testProject + "/secrets/" + DatabasePassword + "/versions/latest:access":
+			_, _ = fmt.Fprintf(w, `{"name":"%s","payload":{"data":"%s"}}`,
+				req.URL.Path, base64.StdEncoding.EncodeToString([]byte(testPassword)))
+		case "/v1/projects/" + testProject + "/secrets/" + DiscordToken + "/versions/latest:access":
+			http.Error(w, `{"error":{"code":403}}`, http.StatusForbidden)
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name    string
+		secret  string
+		want    string
+		wantErr error
+	}{
+		{name: "decodes payload", secret: DatabasePassword, want: testPassword, wantErr: nil},
+		{name: "not found", secret: APIKeys, want: "", wantErr: ErrNotFound},
+		{name: "denied", secret: DiscordToken, want: "", wantErr: ErrUnexpectedStatus},
+	}
+
+	provider := NewSecretManager(server.Client(), server.URL+"/", testProject)
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := provider.Secret(t.Context(), testCase.secret)
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("Secret() error = %v, want %v", err, testCase.wantErr)
+			}
+
+			if got != testCase.want {
+				t.Fatalf("Secret() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+type fakeProvider struct {
+	mu      sync.Mutex
+	secrets map[string]string
+	err     error
+}
+
+func (p *fakeProvider) Secret(_ context.Context, name string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err != nil {
+		return "", p.err
+	}
+
+	value, ok := p.secrets[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return value, nil
+}
+
+func (p *fakeProvider) set(name string, value string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.secrets[name] = value
+}
+
+func TestStoreRefresh(t *testing.T) {
+	t.Parallel()
+
+	provider := &fakeProvider{mu: sync.Mutex{}, secrets: map[string]string{DatabasePassword: "first"}, err: nil}
+	store := newStore(map[string]string{DatabasePassword: "env", DiscordToken: "env-token"}, provider, time.Minute)
+
+	var rotations []string
+
+	store.Subscribe(DatabasePassword, func(value string) { rotations = append(rotations, value) })
+
+	for _, password := range []string{"first", "first", testPassword} {
+		provider.set(DatabasePassword, password)
+
+		err := store.Refresh(t.Context())
+		if err != nil {
+			t.Fatalf("Refresh() error = %v", err)
+		}
+	}
+
+	if got := store.Get(DatabasePassword); got != testPassword {
+		t.Fatalf("Get(%s) = %q, want %q", DatabasePassword, got, testPassword)
+	}
+
+	if got := store.Get(DiscordToken); got != "env-token" {
+		t.Fatalf("Get(%s) = %q, want the environment value", DiscordToken, got)
+	}
+
+	if len(rotations) != 2 || rotations[0] != "first" || rotations[1] != testPassword {
+		t.Fatalf("rotations = %v, want [first %s]", rotations, testPassword)
+	}
+
+	provider.err = errUnavailable
+
+	err := store.Refresh(t.Context())
+	if err == nil {
+		t.Fatal("Refresh() error = nil, want error")
+	}
+
+	if got := store.Get(DatabasePassword); got != testPassword {
+		t.Fatalf("Get(%s) after failed refresh = %q, want %q", DatabasePassword, got, testPassword)
+	}
+}
+
+func TestNoProvider(t *testing.T) {
+	t.Parallel()
+
+	var cfg config.Config
+	cfg.Secrets.Provider = ProviderNone
+	cfg.Database.Password = testPassword
+
+	provider, err := NewProvider(cfg)
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+
+	store, err := NewStore(cfg, provider)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	if got := store.Get(DatabasePassword); got != testPassword {
+		t.Fatalf("Get(%s) = %q, want the environment value", DatabasePassword, got)
+	}
+}
@@ -0,0 +1,79 @@
+package secret
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const maxErrorBody = 1 << 10
+
+var ErrUnexpectedStatus = errors.New("unexpected secret manager status")
+
+// SecretManager reads the latest version of each secret from Google Secret
+// Manager, or any server implementing its AccessSecretVersion REST method.
+type SecretManager struct {
+	client   *http.Client
+	endpoint string
+	project  string
+}
+
+var _ Provider = (*SecretManager)(nil)
+
+// NewSecretManager reads secrets of project from endpoint. client must add
+// credentials to requests.
+func NewSecretManager(client *http.Client, endpoint string, project string) *SecretManager {
+	return &SecretManager{client: client, endpoint: strings.TrimSuffix(endpoint, "/"), project: project}
+}
+
+type accessResponse struct {
+	Payload struct {
+		Data string `json:"data"`
+	} `json:"payload"`
+}
+
+func (m *SecretManager) Secret(ctx context.Context, name string) (string, error) {
+	endpoint := fmt.Sprintf("%s/v1/projects/%s/secrets/%s/versions/latest:access",
+		m.endpoint, url.PathEscape(m.project), url.PathEscape(name))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("create secret request: %w", err)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("access secret %s: %w", name, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+		return "", fmt.Errorf("%w: %s: %s", ErrUnexpectedStatus, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var access accessResponse
+
+	err = json.NewDecoder(resp.Body).Decode(&access)
+	if err != nil {
+		return "", fmt.Errorf("decode secret %s: %w", name, err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(access.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("decode secret %s payload: %w", name, err)
+	}
+
+	return string(data), nil
+}
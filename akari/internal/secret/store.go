@@ -0,0 +1,192 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"go.uber.org/fx"
+)
+
+const fetchTimeout = 30 * time.Second
+
+// Store holds the current value of each secret, starting from the
+// configuration and refreshed from the provider so rotated secrets take
+// effect without a restart.
+type Store struct {
+	provider Provider
+	interval time.Duration
+
+	mu          sync.RWMutex
+	values      map[string]string
+	subscribers map[string][]func(string)
+}
+
+// NewStore fetches every secret the provider holds once, so components are
+// built with the current values. Besides the fixed
+// secrets, the store holds the bot token of each character with its own.
+func NewStore(cfg config.Config, provider Provider) (*Store, error) {
+	values := map[string]string{
+		DatabasePassword: cfg.Database.Password,
+		DiscordToken:     cfg.Discord.Token,
+		APIKeys:          cfg.Auth.APIKeys,
+	}
+
+	for _, character := range cfg.Characters {
+		if character.TokenSecret != "" {
+			values[character.TokenSecret] = character.Token
+		}
+	}
+
+	store := newStore(values, provider, cfg.Secrets.RefreshInterval)
+
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	err := store.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func newStore(values map[string]string, provider Provider, interval time.Duration) *Store {
+	return &Store{
+		provider:    provider,
+		interval:    interval,
+		mu:          sync.RWMutex{},
+		values:      values,
+		subscribers: map[string][]func(string){},
+	}
+}
+
+// Get returns the current value of the named secret.
+func (s *Store) Get(name string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.values[name]
+}
+
+// Names returns the names of the secrets the store holds, sorted.
+func (s *Store) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(s.values))
+}
+
+// Subscribe calls fn with the new value whenever the named secret changes.
+func (s *Store) Subscribe(name string, fn func(value string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subscribers[name] = append(s.subscribers[name], fn)
+}
+
+// Refresh fetches every secret from the provider and notifies subscribers of
+// those that changed. Secrets the provider does not hold keep their value.
+func (s *Store) Refresh(ctx context.Context) error {
+	var errs []error
+
+	for _, name := range s.Names() {
+		value, err := s.provider.Secret(ctx, name)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fetch secret %s: %w", name, err))
+
+			continue
+		}
+
+		s.set(name, value)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Store) set(name string, value string) {
+	s.mu.Lock()
+
+	if s.values[name] == value {
+		s.mu.Unlock()
+
+		return
+	}
+
+	s.values[name] = value
+	subscribers := s.subscribers[name]
+	s.mu.Unlock()
+
+	slog.Info("secret rotated", "secret", name)
+
+	for _, fn := range subscribers {
+		fn(value)
+	}
+}
+
+// RegisterLifecycle refreshes secrets every SECRETS_REFRESH_INTERVAL while
+// the app runs. A failed refresh keeps the previous values.
+func RegisterLifecycle(lc fx.Lifecycle, store *Store) {
+	if _, ok := store.provider.(NoProvider); ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+
+				store.Run(ctx)
+			}()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+				return fmt.Errorf("stop secret refresh: %w", stopCtx.Err())
+			}
+
+			return nil
+		},
+	})
+}
+
+// Run refreshes secrets every interval until ctx is cancelled.
+func (s *Store) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		refreshCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+		err := s.Refresh(refreshCtx)
+
+		cancel()
+
+		if err != nil {
+			slog.ErrorContext(ctx, "refresh secrets failed", "error", err)
+		}
+	}
+}
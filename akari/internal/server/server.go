@@ -8,34 +8,41 @@ import (
 	"net/http"
 	"time"
 
-	"connectrpc.com/connect"
 	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/logging"
 	"go.uber.org/fx"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-const (
-	healthProcedure   = "/akari.v1.HealthService/Check"
-	readHeaderTimeout = 5 * time.Second
-)
+const readHeaderTimeout = 5 * time.Second
+
+// Route is an HTTP handler mounted on the server mux, such as a Connect
+// service.
+type Route struct {
+	Path    string
+	Handler http.Handler
+}
 
-func NewMux() *http.ServeMux {
+func NewMux(routes []Route) *http.ServeMux {
 	mux := http.NewServeMux()
-	mux.Handle(healthProcedure, connect.NewUnaryHandler(
-		healthProcedure,
-		func(_ context.Context, _ *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
-			return connect.NewResponse(&emptypb.Empty{}), nil
-		},
-	))
+
+	for _, route := range routes {
+		mux.Handle(route.Path, route.Handler)
+	}
 
 	return mux
 }
 
+// NewHTTPServer serves the mux over HTTP/1.1 and cleartext HTTP/2 (h2c), so
+// gRPC clients, which require HTTP/2, work alongside Connect and gRPC-Web
+// without TLS termination in front of the server.
 func NewHTTPServer(cfg config.Config, mux *http.ServeMux) *http.Server {
 	server := new(http.Server)
 	server.Addr = cfg.Addr
-	server.Handler = mux
+	server.Handler = logging.Middleware(mux)
 	server.ReadHeaderTimeout = readHeaderTimeout
+	server.Protocols = new(http.Protocols)
+	server.Protocols.SetHTTP1(true)
+	server.Protocols.SetUnencryptedHTTP2(true)
 
 	return server
 }
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -9,6 +10,8 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+const testProcedure = "/akari.test.v1.TestService/Ping"
+
 func TestNewMux(t *testing.T) {
 	t.Parallel()
 
@@ -17,7 +20,7 @@ func TestNewMux(t *testing.T) {
 		path string
 		want int
 	}{
-		{name: "health check", path: healthProcedure, want: http.StatusOK},
+		{name: "mounted route", path: testProcedure, want: http.StatusOK},
 		{name: "unknown route", path: "/unknown", want: http.StatusNotFound},
 	}
 
@@ -25,7 +28,15 @@ func TestNewMux(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
 
-			mux := NewMux()
+			mux := NewMux([]Route{{
+				Path: testProcedure,
+				Handler: connect.NewUnaryHandler(
+					testProcedure,
+					func(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+						return connect.NewResponse(&emptypb.Empty{}), nil
+					},
+				),
+			}})
 			server := httptest.NewServer(mux)
 			t.Cleanup(server.Close)
 
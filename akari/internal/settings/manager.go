@@ -0,0 +1,286 @@
+package settings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"go.uber.org/fx"
+)
+
+// Sources of a setting's effective value, from lowest to highest precedence.
+const (
+	SourceDefault  = "default"
+	SourceFile     = "file"
+	SourceOverride = "override"
+
+	reloadInterval = 30 * time.Second
+)
+
+// Entry is the effective value of one setting and the layer it comes from.
+type Entry struct {
+	Key    string
+	Value  string
+	Source string
+}
+
+// Manager layers the runtime settings: defaults from the environment, then
+// the RUNTIME_CONFIG_FILE, then overrides patched through the admin API.
+// Subscribers are told whenever the effective settings change, whether by a
+// patch or by a reload picking up an edited file or another replica's patch.
+type Manager struct {
+	defaults    map[string]string
+	path        string
+	store       Store
+	subscribers []Subscriber
+
+	// mu serializes reloads and patches; current is read without it.
+	mu        sync.Mutex
+	file      map[string]string
+	overrides map[string]string
+	values    map[string]string
+	current   atomic.Pointer[Settings]
+}
+
+// NewManager applies the defaults and the file, and notifies subscribers.
+// Stored overrides are applied when the app starts.
+func NewManager(cfg config.Config, store Store, subscribers []Subscriber) (*Manager, error) {
+	manager := &Manager{
+		defaults:    defaults(cfg),
+		path:        cfg.Runtime.File,
+		store:       store,
+		subscribers: subscribers,
+		mu:          sync.Mutex{},
+		file:        map[string]string{},
+		overrides:   map[string]string{},
+		values:      nil,
+		current:     atomic.Pointer[Settings]{},
+	}
+
+	file, err := manager.readFile()
+	if err != nil {
+		return nil, err
+	}
+
+	err = manager.apply(file, manager.overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
+
+// Current returns the effective settings.
+func (m *Manager) Current() Settings {
+	return *m.current.Load()
+}
+
+// Entries returns every setting with its effective value and source.
+func (m *Manager) Entries() []Entry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := make([]Entry, 0, len(fields))
+
+	for _, key := range Keys() {
+		source := SourceDefault
+		if _, ok := m.overrides[key]; ok {
+			source = SourceOverride
+		} else if _, ok := m.file[key]; ok {
+			source = SourceFile
+		}
+
+		entries = append(entries, Entry{Key: key, Value: m.values[key], Source: source})
+	}
+
+	return entries
+}
+
+// Patch sets and removes overrides. The result is validated before it is
+// stored, so an invalid patch changes nothing.
+func (m *Manager) Patch(ctx context.Context, set map[string]string, unset []string) error {
+	err := checkKeys(set)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	overrides := maps.Clone(m.overrides)
+	maps.Copy(overrides, set)
+
+	for _, key := range unset {
+		delete(overrides, key)
+	}
+
+	_, err = parse(merge(m.defaults, m.file, overrides))
+	if err != nil {
+		return err
+	}
+
+	err = m.store.Save(ctx, overrides)
+	if err != nil {
+		return err
+	}
+
+	return m.apply(m.file, overrides)
+}
+
+// Reload reads the file and the stored overrides again.
+func (m *Manager) Reload(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, err := m.readFile()
+	if err != nil {
+		return err
+	}
+
+	overrides, err := m.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	return m.apply(file, overrides)
+}
+
+// apply validates the layers and makes them current, notifying subscribers
+// if any effective value changed.
+func (m *Manager) apply(file map[string]string, overrides map[string]string) error {
+	values := merge(m.defaults, file, overrides)
+
+	settings, err := parse(values)
+	if err != nil {
+		return err
+	}
+
+	m.file = file
+	m.overrides = overrides
+
+	if maps.Equal(values, m.values) {
+		return nil
+	}
+
+	m.values = values
+	m.current.Store(&settings)
+
+	for _, subscriber := range m.subscribers {
+		subscriber(settings)
+	}
+
+	return nil
+}
+
+// readFile reads the RUNTIME_CONFIG_FILE, a JSON object of setting keys to
+// strings, numbers or booleans.
+func (m *Manager) readFile() (map[string]string, error) {
+	if m.path == "" {
+		return map[string]string{}, nil
+	}
+
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		return nil, fmt.Errorf("read runtime config file: %w", err)
+	}
+
+	var raw map[string]json.RawMessage
+
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("decode runtime config file %s: %w", m.path, err)
+	}
+
+	file := make(map[string]string, len(raw))
+
+	for key, value := range raw {
+		var text string
+
+		err = json.Unmarshal(value, &text)
+		if err != nil {
+			text = string(bytes.TrimSpace(value))
+		}
+
+		file[key] = text
+	}
+
+	err = checkKeys(file)
+	if err != nil {
+		return nil, fmt.Errorf("runtime config file %s: %w", m.path, err)
+	}
+
+	return file, nil
+}
+
+func merge(layers ...map[string]string) map[string]string {
+	values := map[string]string{}
+	for _, layer := range layers {
+		maps.Copy(values, layer)
+	}
+
+	return values
+}
+
+// RegisterLifecycle applies the stored overrides on start, then reloads every
+// 30 seconds. A failed reload keeps the current settings.
+func RegisterLifecycle(lc fx.Lifecycle, manager *Manager) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(startCtx context.Context) error {
+			err := manager.Reload(startCtx)
+			if err != nil {
+				return err
+			}
+
+			slog.Info("runtime config loaded")
+
+			go func() {
+				defer close(done)
+
+				manager.Run(ctx)
+			}()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+				return fmt.Errorf("stop runtime config reload: %w", stopCtx.Err())
+			}
+
+			return nil
+		},
+	})
+}
+
+// Run reloads the settings periodically until ctx is cancelled.
+func (m *Manager) Run(ctx context.Context) {
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := m.Reload(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "reload runtime config failed", "error", err)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kizuna-org/akari/internal/config"
+)
+
+// Keys of the settings that can change at runtime.
+const (
+	KeyRateLimitUserPerMinute    = "rate_limit.user.per_minute"
+	KeyRateLimitUserBurst        = "rate_limit.user.burst"
+	KeyRateLimitChannelPerMinute = "rate_limit.channel.per_minute"
+	KeyRateLimitChannelBurst     = "rate_limit.channel.burst"
+	KeyRateLimitGlobalPerMinute  = "rate_limit.global.per_minute"
+	KeyRateLimitGlobalBurst      = "rate_limit.global.burst"
+	KeyAllowedChannels           = "discord.allowed_channels"
+	KeyLogLevel                  = "log.level"
+	KeyLLMTemperature            = "llm.temperature"
+
+	maxTemperature = 2
+)
+
+var (
+	ErrUnknownKey   = errors.New("unknown runtime setting")
+	ErrInvalidValue = errors.New("invalid runtime setting")
+)
+
+// Settings are the values subsystems read at runtime. They are rebuilt from
+// the layered configuration on every change.
+type Settings struct {
+	RateLimit config.RateLimit
+	// AllowedChannels limits replies in guilds to these channels. Empty
+	// allows every channel a character listens to.
+	AllowedChannels []string
+	LogLevel        slog.Level
+	// Temperature is the LLM sampling temperature, or nil for the model
+	// default.
+	Temperature *float32
+}
+
+// AllowsChannel reports whether replies may be sent in a guild channel.
+func (s Settings) AllowsChannel(channelID string) bool {
+	return len(s.AllowedChannels) == 0 || slices.Contains(s.AllowedChannels, channelID)
+}
+
+// Subscriber is called with the effective settings at startup and after
+// every change.
+type Subscriber func(Settings)
+
+type field struct {
+	key   string
+	value func(cfg config.Config) string
+	parse func(value string, settings *Settings) error
+}
+
+// fields lists every key in the order entries are reported. The default of
+// each comes from the environment.
+var fields = []field{
+	bucketField(KeyRateLimitUserPerMinute, func(r *config.RateLimit) *int { return &r.User.PerMinute }),
+	bucketField(KeyRateLimitUserBurst, func(r *config.RateLimit) *int { return &r.User.Burst }),
+	bucketField(KeyRateLimitChannelPerMinute, func(r *config.RateLimit) *int { return &r.Channel.PerMinute }),
+	bucketField(KeyRateLimitChannelBurst, func(r *config.RateLimit) *int { return &r.Channel.Burst }),
+	bucketField(KeyRateLimitGlobalPerMinute, func(r *config.RateLimit) *int { return &r.Global.PerMinute }),
+	bucketField(KeyRateLimitGlobalBurst, func(r *config.RateLimit) *int { return &r.Global.Burst }),
+	{
+		key:   KeyAllowedChannels,
+		value: func(config.Config) string { return "" },
+		parse: func(value string, settings *Settings) error {
+			for channelID := range strings.SplitSeq(value, ",") {
+				if channelID = strings.TrimSpace(channelID); channelID != "" {
+					settings.AllowedChannels = append(settings.AllowedChannels, channelID)
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		key:   KeyLogLevel,
+		value: func(cfg config.Config) string { return cfg.Log.Level },
+		parse: func(value string, settings *Settings) error {
+			if value == "" {
+				return nil
+			}
+
+			return settings.LogLevel.UnmarshalText([]byte(value))
+		},
+	},
+	{
+		key:   KeyLLMTemperature,
+		value: func(config.Config) string { return "" },
+		parse: func(value string, settings *Settings) error {
+			if value == "" {
+				return nil
+			}
+
+			temperature, err := strconv.ParseFloat(value, 32)
+			if err != nil {
+				return err
+			}
+
+			if temperature < 0 || temperature > maxTemperature {
+				return fmt.Errorf("%v is not between 0 and %d", temperature, maxTemperature)
+			}
+
+			sampling := float32(temperature)
+			settings.Temperature = &sampling
+
+			return nil
+		},
+	},
+}
+
+func bucketField(key string, target func(*config.RateLimit) *int) field {
+	return field{
+		key: key,
+		value: func(cfg config.Config) string {
+			return strconv.Itoa(*target(&cfg.RateLimit))
+		},
+		parse: func(value string, settings *Settings) error {
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return err
+			}
+
+			if number < 0 {
+				return fmt.Errorf("%d is negative", number)
+			}
+
+			*target(&settings.RateLimit) = number
+
+			return nil
+		},
+	}
+}
+
+// Keys returns every runtime setting key.
+func Keys() []string {
+	keys := make([]string, 0, len(fields))
+	for _, field := range fields {
+		keys = append(keys, field.key)
+	}
+
+	return keys
+}
+
+func defaults(cfg config.Config) map[string]string {
+	values := make(map[string]string, len(fields))
+	for _, field := range fields {
+		values[field.key] = field.value(cfg)
+	}
+
+	return values
+}
+
+// checkKeys rejects keys that are not runtime settings.
+func checkKeys(values map[string]string) error {
+	var errs []error
+
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if !slices.Contains(Keys(), key) {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrUnknownKey, key))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func parse(values map[string]string) (Settings, error) {
+	var (
+		settings Settings
+		errs     []error
+	)
+
+	for _, field := range fields {
+		err := field.parse(values[field.key], &settings)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w %s: %w", ErrInvalidValue, field.key, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return Settings{}, errors.Join(errs...)
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,171 @@
+package settings
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kizuna-org/akari/internal/config"
+)
+
+func testConfig(file string) config.Config {
+	var cfg config.Config
+	cfg.Log.Level = "info"
+	cfg.RateLimit.User = config.Bucket{PerMinute: 6, Burst: 3}
+	cfg.Runtime.File = file
+
+	return cfg
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestManagerLayers(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "runtime.json")
+	writeFile(t, path, `{"rate_limit.user.burst": 5, "log.level": "debug", "discord.allowed_channels": "a, b"}`)
+
+	var notified []Settings
+
+	manager, err := NewManager(testConfig(path), NewMemoryStore(), []Subscriber{
+		func(current Settings) { notified = append(notified, current) },
+	})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	err = manager.Patch(t.Context(), map[string]string{KeyLogLevel: "warn", KeyLLMTemperature: "0.5"}, nil)
+	if err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+
+	current := manager.Current()
+	if current.RateLimit.User != (config.Bucket{PerMinute: 6, Burst: 5}) {
+		t.Errorf("RateLimit.User = %+v, want the file burst over the default rate", current.RateLimit.User)
+	}
+
+	if current.LogLevel != slog.LevelWarn || current.Temperature == nil || *current.Temperature != 0.5 {
+		t.Errorf("LogLevel, Temperature = %v, %v, want the overrides", current.LogLevel, current.Temperature)
+	}
+
+	if !current.AllowsChannel("b") || current.AllowsChannel("c") {
+		t.Errorf("AllowedChannels = %v, want [a b]", current.AllowedChannels)
+	}
+
+	sources := map[string]string{}
+	for _, entry := range manager.Entries() {
+		sources[entry.Key] = entry.Source
+	}
+
+	for key, want := range map[string]string{
+		KeyRateLimitUserPerMinute: SourceDefault,
+		KeyRateLimitUserBurst:     SourceFile,
+		KeyLogLevel:               SourceOverride,
+	} {
+		if sources[key] != want {
+			t.Errorf("source of %s = %q, want %q", key, sources[key], want)
+		}
+	}
+
+	err = manager.Patch(t.Context(), nil, []string{KeyLogLevel})
+	if err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+
+	if got := manager.Current().LogLevel; got != slog.LevelDebug {
+		t.Errorf("LogLevel after unset = %v, want the file value", got)
+	}
+
+	if len(notified) != 3 {
+		t.Errorf("subscribers notified %d times, want 3", len(notified))
+	}
+}
+
+func TestManagerPatchErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		set     map[string]string
+		wantErr error
+	}{
+		{name: "unknown key", set: map[string]string{"discord.token": "secret"}, wantErr: ErrUnknownKey},
+		{name: "negative limit", set: map[string]string{KeyRateLimitGlobalBurst: "-1"}, wantErr: ErrInvalidValue},
+		{name: "unknown level", set: map[string]string{KeyLogLevel: "loud"}, wantErr: ErrInvalidValue},
+		{name: "hot model", set: map[string]string{KeyLLMTemperature: "3"}, wantErr: ErrInvalidValue},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := NewMemoryStore()
+
+			manager, err := NewManager(testConfig(""), store, nil)
+			if err != nil {
+				t.Fatalf("NewManager() error = %v", err)
+			}
+
+			err = manager.Patch(t.Context(), testCase.set, nil)
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("Patch() error = %v, want %v", err, testCase.wantErr)
+			}
+
+			stored, _ := store.Load(t.Context())
+			if len(stored) != 0 {
+				t.Fatalf("rejected patch stored %v", stored)
+			}
+		})
+	}
+}
+
+func TestManagerReload(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "runtime.json")
+	writeFile(t, path, `{}`)
+
+	store := NewMemoryStore()
+
+	manager, err := NewManager(testConfig(path), store, nil)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	// Another replica patched the overrides and the file was edited.
+	err = store.Save(t.Context(), map[string]string{KeyRateLimitUserPerMinute: "2"})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	writeFile(t, path, `{"rate_limit.user.burst": "1"}`)
+
+	err = manager.Reload(t.Context())
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	if got := manager.Current().RateLimit.User; got != (config.Bucket{PerMinute: 2, Burst: 1}) {
+		t.Fatalf("RateLimit.User = %+v, want {2 1}", got)
+	}
+
+	writeFile(t, path, `{"rate_limit.user.burst": "many"}`)
+
+	err = manager.Reload(t.Context())
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Fatalf("Reload() error = %v, want %v", err, ErrInvalidValue)
+	}
+
+	if got := manager.Current().RateLimit.User; got != (config.Bucket{PerMinute: 2, Burst: 1}) {
+		t.Fatalf("RateLimit.User after a failed reload = %+v, want {2 1}", got)
+	}
+}
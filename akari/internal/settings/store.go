@@ -0,0 +1,91 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/kizuna-org/akari/internal/appstate"
+)
+
+// overridesKey is the app state entry holding the overrides as a JSON object.
+const overridesKey = "runtime_config.overrides"
+
+// Store persists the overrides patched through the admin API, so they are
+// shared by every replica and survive restarts.
+type Store interface {
+	Load(ctx context.Context) (map[string]string, error)
+	Save(ctx context.Context, overrides map[string]string) error
+}
+
+func NewStore(states *appstate.Store) Store {
+	return &StateStore{states: states}
+}
+
+// StateStore keeps the overrides in the app_states table.
+type StateStore struct {
+	states *appstate.Store
+}
+
+func (s *StateStore) Load(ctx context.Context) (map[string]string, error) {
+	value, err := s.states.Get(ctx, overridesKey)
+	if errors.Is(err, appstate.ErrNotFound) {
+		return map[string]string{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("load runtime config overrides: %w", err)
+	}
+
+	overrides := map[string]string{}
+
+	err = json.Unmarshal([]byte(value), &overrides)
+	if err != nil {
+		return nil, fmt.Errorf("decode runtime config overrides: %w", err)
+	}
+
+	return overrides, nil
+}
+
+func (s *StateStore) Save(ctx context.Context, overrides map[string]string) error {
+	value, err := json.Marshal(overrides)
+	if err != nil {
+		return fmt.Errorf("encode runtime config overrides: %w", err)
+	}
+
+	err = s.states.Set(ctx, overridesKey, string(value))
+	if err != nil {
+		return fmt.Errorf("save runtime config overrides: %w", err)
+	}
+
+	return nil
+}
+
+// MemoryStore keeps overrides in memory, for tests and local runs.
+type MemoryStore struct {
+	mu        sync.Mutex
+	overrides map[string]string
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{mu: sync.Mutex{}, overrides: map[string]string{}}
+}
+
+func (s *MemoryStore) Load(context.Context) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return maps.Clone(s.overrides), nil
+}
+
+func (s *MemoryStore) Save(_ context.Context, overrides map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.overrides = maps.Clone(overrides)
+
+	return nil
+}
@@ -0,0 +1,334 @@
+package sleep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/appstate"
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/discord"
+	"github.com/kizuna-org/akari/internal/kiseki"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/fx"
+)
+
+const (
+	lastRunKeyPrefix = "sleep.last_run."
+	pollInterval     = 10 * time.Second
+	pollTimeout      = time.Hour
+)
+
+var ErrSleepFailed = errors.New("kiseki sleep failed")
+
+type Kiseki interface {
+	Sleep(ctx context.Context, characterID string) (string, error)
+	SleepStatus(ctx context.Context, pollingURL string) (kiseki.SleepStatus, error)
+}
+
+type StateStore interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value string) error
+}
+
+// Presence reflects a character's sleep on its chat surface.
+type Presence interface {
+	Sleep() error
+	Wake() error
+}
+
+// Scheduler puts a character to sleep at its bedtime so that kiseki can
+// consolidate the day's memories.
+type Scheduler struct {
+	characterID  string
+	schedule     cron.Schedule
+	kiseki       Kiseki
+	states       StateStore
+	presence     Presence
+	pollInterval time.Duration
+	now          func() time.Time
+}
+
+// Schedulers are the sleep schedulers of the hosted characters that have a
+// sleep schedule.
+type Schedulers struct {
+	hosted     map[string]bool
+	schedulers map[string]*Scheduler
+}
+
+// NewSchedulers creates a scheduler for the primary character and every
+// listed character with a sleep schedule. There are none while kiseki is
+// disabled.
+func NewSchedulers(
+	cfg config.Config,
+	client *kiseki.Client,
+	states *appstate.Store,
+	bots *discord.Bots,
+) (*Schedulers, error) {
+	schedulers := &Schedulers{hosted: map[string]bool{}, schedulers: map[string]*Scheduler{}}
+
+	for _, hosted := range append([]config.Character{cfg.Character}, cfg.Characters...) {
+		if hosted.ID == "" {
+			continue
+		}
+
+		schedulers.hosted[hosted.ID] = true
+
+		if hosted.SleepSchedule == "" || !client.Enabled() {
+			continue
+		}
+
+		schedule, err := ParseSchedule(hosted.SleepSchedule, hosted.Timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		var presence Presence = noPresence{}
+		if bot, ok := bots.Of(hosted.ID); ok {
+			presence = bot
+		}
+
+		schedulers.schedulers[hosted.ID] = newScheduler(hosted.ID, schedule, client, states, presence)
+	}
+
+	return schedulers, nil
+}
+
+// Get returns the scheduler of a character, if it sleeps.
+func (s *Schedulers) Get(characterID string) (*Scheduler, bool) {
+	scheduler, ok := s.schedulers[characterID]
+
+	return scheduler, ok
+}
+
+// Hosts reports whether the character is hosted, whether or not it sleeps.
+func (s *Schedulers) Hosts(characterID string) bool {
+	return s.hosted[characterID]
+}
+
+// noPresence is the presence of a character sharing a bot it does not
+// present, which stays as it is while the character sleeps.
+type noPresence struct{}
+
+func (noPresence) Sleep() error { return nil }
+
+func (noPresence) Wake() error { return nil }
+
+func newScheduler(
+	characterID string,
+	schedule cron.Schedule,
+	kisekiClient Kiseki,
+	states StateStore,
+	presence Presence,
+) *Scheduler {
+	return &Scheduler{
+		characterID:  characterID,
+		schedule:     schedule,
+		kiseki:       kisekiClient,
+		states:       states,
+		presence:     presence,
+		pollInterval: pollInterval,
+		now:          time.Now,
+	}
+}
+
+// ParseSchedule parses a standard five-field cron expression evaluated in
+// the given IANA time zone.
+func ParseSchedule(spec string, timezone string) (cron.Schedule, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("load time zone %q: %w", timezone, err)
+	}
+
+	schedule, err := cron.ParseStandard("CRON_TZ=" + location.String() + " " + spec)
+	if err != nil {
+		return nil, fmt.Errorf("parse sleep schedule %q: %w", spec, err)
+	}
+
+	return schedule, nil
+}
+
+func RegisterLifecycle(lc fx.Lifecycle, schedulers *Schedulers) {
+	if len(schedulers.schedulers) == 0 {
+		slog.Info("sleep schedule is not configured, sleep scheduler disabled")
+
+		return
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(schedulers.schedulers)) {
+		register(lc, schedulers.schedulers[id])
+	}
+}
+
+func register(lc fx.Lifecycle, scheduler *Scheduler) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+
+				scheduler.Run(ctx)
+			}()
+
+			slog.Info("sleep scheduler started", "character_id", scheduler.characterID)
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+				return fmt.Errorf("stop sleep scheduler: %w", stopCtx.Err())
+			}
+
+			slog.Info("sleep scheduler stopped", "character_id", scheduler.characterID)
+
+			return nil
+		},
+	})
+}
+
+// Run sleeps at every scheduled bedtime until ctx is cancelled. A bedtime
+// missed while the process was down is caught up immediately. The last run
+// is kept in memory as well as persisted, so a store that keeps failing
+// cannot make Run catch up the same bedtime over and over.
+func (s *Scheduler) Run(ctx context.Context) {
+	lastRun := s.lastRun(ctx)
+
+	for {
+		bedtime := nextRun(s.schedule, lastRun, s.now())
+
+		timer := time.NewTimer(bedtime.Sub(s.now()))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+
+		err := s.Sleep(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "sleep failed", "character_id", s.characterID, "error", err)
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		lastRun = bedtime
+
+		err = s.states.Set(ctx, s.lastRunKey(), bedtime.UTC().Format(time.RFC3339))
+		if err != nil {
+			slog.ErrorContext(ctx, "persist sleep last run failed", "character_id", s.characterID, "error", err)
+		}
+	}
+}
+
+// Sleep starts consolidation and waits for kiseki to finish it.
+func (s *Scheduler) Sleep(ctx context.Context) error {
+	err := s.presence.Sleep()
+	if err != nil {
+		slog.WarnContext(ctx, "set sleeping presence failed", "error", err)
+	}
+
+	defer func() {
+		err := s.presence.Wake()
+		if err != nil {
+			slog.WarnContext(ctx, "set awake presence failed", "error", err)
+		}
+	}()
+
+	slog.InfoContext(ctx, "going to sleep", "character_id", s.characterID)
+
+	pollingURL, err := s.kiseki.Sleep(ctx, s.characterID)
+	if err != nil {
+		return fmt.Errorf("start sleep: %w", err)
+	}
+
+	err = s.poll(ctx, pollingURL)
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "woke up", "character_id", s.characterID)
+
+	return nil
+}
+
+func (s *Scheduler) poll(ctx context.Context, pollingURL string) error {
+	ctx, cancel := context.WithTimeout(ctx, pollTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := s.kiseki.SleepStatus(ctx, pollingURL)
+		if err != nil {
+			slog.WarnContext(ctx, "poll sleep status failed", "error", err)
+		}
+
+		switch status.Status {
+		case kiseki.SleepCompleted:
+			return nil
+		case kiseki.SleepFailed:
+			return fmt.Errorf("%w: %s", ErrSleepFailed, status.Message)
+		case kiseki.SleepPending, kiseki.SleepRunning:
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("poll sleep status: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) lastRun(ctx context.Context) time.Time {
+	value, err := s.states.Get(ctx, s.lastRunKey())
+	if errors.Is(err, appstate.ErrNotFound) {
+		return time.Time{}
+	}
+
+	if err != nil {
+		slog.WarnContext(ctx, "load sleep last run failed", "error", err)
+
+		return time.Time{}
+	}
+
+	lastRun, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		slog.WarnContext(ctx, "parse sleep last run failed", "value", value, "error", err)
+
+		return time.Time{}
+	}
+
+	return lastRun
+}
+
+func (s *Scheduler) lastRunKey() string {
+	return lastRunKeyPrefix + s.characterID
+}
+
+func nextRun(schedule cron.Schedule, lastRun time.Time, now time.Time) time.Time {
+	if lastRun.IsZero() {
+		return schedule.Next(now)
+	}
+
+	next := schedule.Next(lastRun)
+	if next.Before(now) {
+		return now
+	}
+
+	return next
+}
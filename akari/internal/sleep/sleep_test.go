@@ -0,0 +1,310 @@
+package sleep
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/appstate"
+	"github.com/kizuna-org/akari/internal/kiseki"
+)
+
+const (
+	testCharacterID = "0193b1c6-6f5e-7a51-9a3c-3f0d1c2b4e5f"
+	testPollingURL  = "/characters/" + testCharacterID + "/sleep/1"
+	testSchedule    = "0 3 * * *"
+	testTimezone    = "Asia/Tokyo"
+)
+
+var errDatabaseDown = errors.New("database is down")
+
+type fakeKiseki struct {
+	mu       sync.Mutex
+	statuses []kiseki.SleepState
+	sleeps   int
+}
+
+func (k *fakeKiseki) Sleep(context.Context, string) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.sleeps++
+
+	return testPollingURL, nil
+}
+
+func (k *fakeKiseki) SleepStatus(context.Context, string) (kiseki.SleepStatus, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	status := k.statuses[0]
+	if len(k.statuses) > 1 {
+		k.statuses = k.statuses[1:]
+	}
+
+	return kiseki.SleepStatus{Status: status, Message: "", UpdatedAt: time.Time{}}, nil
+}
+
+type fakeStates struct {
+	mu     sync.Mutex
+	values map[string]string
+	set    chan string
+	err    error
+}
+
+func (s *fakeStates) Get(_ context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.values[key]
+	if !ok {
+		return "", appstate.ErrNotFound
+	}
+
+	return value, nil
+}
+
+func (s *fakeStates) Set(_ context.Context, key string, value string) error {
+	s.mu.Lock()
+	if s.err == nil {
+		s.values[key] = value
+	}
+	s.mu.Unlock()
+
+	s.set <- value
+
+	return s.err
+}
+
+type fakePresence struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (p *fakePresence) Sleep() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, "sleep")
+
+	return nil
+}
+
+func (p *fakePresence) Wake() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, "wake")
+
+	return nil
+}
+
+func TestNextRun(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := ParseSchedule(testSchedule, testTimezone)
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+
+	tokyo, err := time.LoadLocation(testTimezone)
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, tokyo)
+
+	tests := []struct {
+		name    string
+		lastRun time.Time
+		want    time.Time
+	}{
+		{
+			name:    "first run waits for bedtime",
+			lastRun: time.Time{},
+			want:    time.Date(2026, 10, 20, 3, 0, 0, 0, tokyo),
+		},
+		{
+			name:    "ran last night",
+			lastRun: time.Date(2026, 10, 19, 3, 0, 0, 0, tokyo),
+			want:    time.Date(2026, 10, 20, 3, 0, 0, 0, tokyo),
+		},
+		{
+			name:    "catches up a missed bedtime",
+			lastRun: time.Date(2026, 10, 17, 3, 0, 0, 0, tokyo),
+			want:    now,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := nextRun(schedule, testCase.lastRun, now); !got.Equal(testCase.want) {
+				t.Fatalf("nextRun() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		spec     string
+		timezone string
+		wantErr  bool
+	}{
+		{name: "valid", spec: testSchedule, timezone: testTimezone, wantErr: false},
+		{name: "invalid time zone", spec: testSchedule, timezone: "Mars/Olympus", wantErr: true},
+		{name: "invalid expression", spec: "at night", timezone: testTimezone, wantErr: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseSchedule(testCase.spec, testCase.timezone)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("ParseSchedule() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchedulerSleep(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		statuses []kiseki.SleepState
+		wantErr  error
+	}{
+		{
+			name:     "polls to completion",
+			statuses: []kiseki.SleepState{kiseki.SleepPending, kiseki.SleepRunning, kiseki.SleepCompleted},
+			wantErr:  nil,
+		},
+		{
+			name:     "reports failure",
+			statuses: []kiseki.SleepState{kiseki.SleepRunning, kiseki.SleepFailed},
+			wantErr:  ErrSleepFailed,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			presence := &fakePresence{mu: sync.Mutex{}, events: nil}
+			scheduler := newScheduler(
+				testCharacterID,
+				nil,
+				&fakeKiseki{mu: sync.Mutex{}, statuses: testCase.statuses, sleeps: 0},
+				nil,
+				presence,
+			)
+			scheduler.pollInterval = time.Millisecond
+
+			err := scheduler.Sleep(t.Context())
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("Sleep() error = %v, want %v", err, testCase.wantErr)
+			}
+
+			if len(presence.events) != 2 || presence.events[0] != "sleep" || presence.events[1] != "wake" {
+				t.Fatalf("presence events = %q, want sleep then wake", presence.events)
+			}
+		})
+	}
+}
+
+func TestSchedulerRunCatchesUp(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := ParseSchedule(testSchedule, testTimezone)
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+
+	states := &fakeStates{
+		mu:     sync.Mutex{},
+		values: map[string]string{lastRunKeyPrefix + testCharacterID: "2026-01-01T00:00:00Z"},
+		set:    make(chan string, 1),
+		err:    nil,
+	}
+	client := &fakeKiseki{mu: sync.Mutex{}, statuses: []kiseki.SleepState{kiseki.SleepCompleted}, sleeps: 0}
+	scheduler := newScheduler(testCharacterID, schedule, client, states, &fakePresence{mu: sync.Mutex{}, events: nil})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		scheduler.Run(ctx)
+	}()
+
+	select {
+	case <-states.set:
+	case <-time.After(time.Second):
+		t.Fatal("Run() did not persist last run")
+	}
+
+	cancel()
+	<-done
+
+	if client.sleeps != 1 {
+		t.Fatalf("kiseki sleeps = %d, want 1", client.sleeps)
+	}
+}
+
+func TestSchedulerRunPersistFails(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := ParseSchedule(testSchedule, testTimezone)
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+
+	states := &fakeStates{
+		mu:     sync.Mutex{},
+		values: map[string]string{lastRunKeyPrefix + testCharacterID: "2026-01-01T00:00:00Z"},
+		set:    make(chan string, 1),
+		err:    errDatabaseDown,
+	}
+	client := &fakeKiseki{mu: sync.Mutex{}, statuses: []kiseki.SleepState{kiseki.SleepCompleted}, sleeps: 0}
+	scheduler := newScheduler(testCharacterID, schedule, client, states, &fakePresence{mu: sync.Mutex{}, events: nil})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		scheduler.Run(ctx)
+	}()
+
+	select {
+	case <-states.set:
+	case <-time.After(time.Second):
+		t.Fatal("Run() did not try to persist last run")
+	}
+
+	// The missed bedtime was caught up, so the next one is tomorrow even
+	// though it could not be persisted.
+	select {
+	case <-states.set:
+		t.Fatal("Run() slept again right after persisting failed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cancel()
+	<-done
+
+	if client.sleeps != 1 {
+		t.Fatalf("kiseki sleeps = %d, want 1", client.sleeps)
+	}
+}
@@ -0,0 +1,185 @@
+package tool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/memory"
+)
+
+// CurrentTime is what the current_time tool returns.
+type CurrentTime struct {
+	Time     string `json:"time"`
+	Weekday  string `json:"weekday"`
+	Timezone string `json:"timezone"`
+}
+
+// NewCurrentTime tells the time in a timezone, by default the character's
+// own.
+func NewCurrentTime(cfg config.Config) Tool {
+	timezones := map[string]string{cfg.Character.ID: cfg.Character.Timezone}
+	for _, hosted := range cfg.Characters {
+		timezones[hosted.ID] = hosted.Timezone
+	}
+
+	return Tool{
+		Name:        "current_time",
+		Description: "Get the current date and time in a timezone.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"timezone": map[string]any{
+					"type":        "string",
+					"description": "IANA timezone name such as Asia/Tokyo. Defaults to your own timezone.",
+				},
+			},
+		},
+		Timeout: 0,
+		Handler: func(_ context.Context, req Request) (any, error) {
+			var args struct {
+				Timezone string `json:"timezone"`
+			}
+
+			err := req.Decode(&args)
+			if err != nil {
+				return nil, err
+			}
+
+			if args.Timezone == "" {
+				args.Timezone = timezones[req.CharacterID]
+			}
+
+			location, err := time.LoadLocation(args.Timezone)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidArguments, err)
+			}
+
+			now := time.Now().In(location)
+
+			return CurrentTime{
+				Time:     now.Format(time.RFC3339),
+				Weekday:  now.Weekday().String(),
+				Timezone: location.String(),
+			}, nil
+		},
+	}
+}
+
+// RecalledMemories is what the recall_memory tool returns.
+type RecalledMemories struct {
+	Memories []string `json:"memories"`
+}
+
+// NewRecallMemory searches the character's long-term memory, for when the
+// memories recalled for the message are not enough.
+func NewRecallMemory(memories *memory.Service) Tool {
+	return Tool{
+		Name:        "recall_memory",
+		Description: "Search your long-term memory for things you were told before.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{
+					"type":        "string",
+					"description": "What to remember, in a few words.",
+				},
+			},
+			"required": []string{"query"},
+		},
+		Timeout: 0,
+		Handler: func(ctx context.Context, req Request) (any, error) {
+			var args struct {
+				Query string `json:"query"`
+			}
+
+			err := req.Decode(&args)
+			if err != nil {
+				return nil, err
+			}
+
+			if strings.TrimSpace(args.Query) == "" {
+				return nil, fmt.Errorf("%w: query is required", ErrInvalidArguments)
+			}
+
+			recalled := RecalledMemories{Memories: []string{}}
+			for _, fragment := range memories.Recall(ctx, req.CharacterID, args.Query) {
+				recalled.Memories = append(recalled.Memories, fragment.Text())
+			}
+
+			return recalled, nil
+		},
+	}
+}
+
+// KnownAbout is what the recall_person tool returns.
+type KnownAbout struct {
+	Name  string      `json:"name"`
+	Known []KnownFact `json:"known"`
+}
+
+// KnownFact is something known about a person, and how sure of it the
+// character is, from 0 to 1.
+type KnownFact struct {
+	Statement  string  `json:"statement"`
+	Confidence float64 `json:"confidence"`
+}
+
+// NewRecallPerson looks up what the character knows about a person, by
+// default the user it is talking with, in the knowledge graph of its
+// memories.
+func NewRecallPerson(memories *memory.Service) Tool {
+	return Tool{
+		Name:        "recall_person",
+		Description: "Look up what you know about a person, place or topic, by default the user you are talking with.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name": map[string]any{
+					"type":        "string",
+					"description": "Who or what to look up. Defaults to the user you are talking with.",
+				},
+			},
+		},
+		Timeout: 0,
+		Handler: func(ctx context.Context, req Request) (any, error) {
+			var args struct {
+				Name string `json:"name"`
+			}
+
+			err := req.Decode(&args)
+			if err != nil {
+				return nil, err
+			}
+
+			name := strings.TrimSpace(args.Name)
+
+			var known []memory.Known
+
+			switch {
+			case name != "":
+				known, err = memories.About(ctx, req.CharacterID, name)
+			case req.UserID != "":
+				// The user is looked up by ID: their name may be shared or
+				// changed.
+				name = req.UserName
+				known, err = memories.AboutUser(ctx, req.CharacterID, req.UserID)
+			default:
+				return nil, fmt.Errorf("%w: name is required", ErrInvalidArguments)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			about := KnownAbout{Name: name, Known: []KnownFact{}}
+			for _, fact := range known {
+				about.Known = append(about.Known, KnownFact{Statement: fact.Statement, Confidence: fact.Confidence})
+			}
+
+			return about, nil
+		},
+	}
+}
@@ -0,0 +1,286 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/character"
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/llm"
+	"github.com/kizuna-org/akari/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// defaultTimeout bounds a call of a tool without a timeout of its own.
+	defaultTimeout = 10 * time.Second
+	// maxRounds is how many times the model may call tools for one reply.
+	// It must answer without tools after that.
+	maxRounds = 4
+
+	tracerName = "github.com/kizuna-org/akari/internal/tool"
+)
+
+var (
+	ErrUnknownTool      = errors.New("unknown tool")
+	ErrDuplicateTool    = errors.New("tool declared twice")
+	ErrNotAllowed       = errors.New("tool not allowed for this character")
+	ErrInvalidArguments = errors.New("invalid tool arguments")
+)
+
+// Tool is a tool declared in Go that characters' models may call.
+// Parameters is the JSON schema of the arguments object, and Timeout bounds
+// each call, 10 seconds when zero.
+type Tool struct {
+	Name        string
+	Description string
+	Parameters  map[string]any
+	Timeout     time.Duration
+	Handler     Handler
+}
+
+// Handler runs a call and returns its output, which is encoded as JSON for
+// the model.
+type Handler func(ctx context.Context, req Request) (any, error)
+
+// Caller is who tools are called for: a character replying to a user in a
+// channel. UserName is the name the user goes by.
+type Caller struct {
+	CharacterID string
+	ChannelID   string
+	UserID      string
+	UserName    string
+}
+
+// Request is a call of a tool with the JSON object of its arguments.
+type Request struct {
+	Caller
+
+	Arguments json.RawMessage
+}
+
+// Decode decodes the arguments into target.
+func (r Request) Decode(target any) error {
+	err := json.Unmarshal(r.Arguments, target)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidArguments, err)
+	}
+
+	return nil
+}
+
+// Generate produces a model response, such as llm.Model.Generate.
+type Generate func(ctx context.Context, req llm.Request) (llm.Response, error)
+
+// Runner offers each character the tools its current definition in the
+// registry allows and runs the calls its model makes.
+type Runner struct {
+	tools      map[string]Tool
+	characters *character.Registry
+	calls      *prometheus.CounterVec
+	tracer     trace.Tracer
+}
+
+// NewRunner checks that the tools have distinct names and that every tool a
+// configured character is allowed is declared.
+func NewRunner(
+	cfg config.Config,
+	registry *prometheus.Registry,
+	characters *character.Registry,
+	tools []Tool,
+) (*Runner, error) {
+	calls, err := metrics.Register(registry, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "tool",
+		Name:      "calls_total",
+		Help:      "Tool calls made by the model, by tool and outcome.",
+	}, []string{"tool", "outcome"}))
+	if err != nil {
+		return nil, err
+	}
+
+	runner := &Runner{
+		tools:      make(map[string]Tool, len(tools)),
+		characters: characters,
+		calls:      calls,
+		tracer:     otel.Tracer(tracerName),
+	}
+
+	for _, declared := range tools {
+		if _, ok := runner.tools[declared.Name]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateTool, declared.Name)
+		}
+
+		runner.tools[declared.Name] = declared
+	}
+
+	for _, hosted := range append([]config.Character{cfg.Character}, cfg.Characters...) {
+		err := runner.Validate(hosted.Tools)
+		if err != nil {
+			return nil, fmt.Errorf("character %q: %w", hosted.ID, err)
+		}
+	}
+
+	return runner, nil
+}
+
+// Validate checks that every named tool is declared.
+func (r *Runner) Validate(names []string) error {
+	for _, name := range names {
+		if _, ok := r.tools[name]; !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownTool, name)
+		}
+	}
+
+	return nil
+}
+
+// Declarations returns the tools a character's model may call. Tools
+// allowed for the character but no longer declared are left out.
+func (r *Runner) Declarations(characterID string) []llm.Tool {
+	names := r.allowed(characterID)
+	if len(names) == 0 {
+		return nil
+	}
+
+	declarations := make([]llm.Tool, 0, len(names))
+	for _, name := range names {
+		declared, ok := r.tools[name]
+		if !ok {
+			continue
+		}
+
+		declarations = append(declarations, llm.Tool{
+			Name:        declared.Name,
+			Description: declared.Description,
+			Parameters:  declared.Parameters,
+		})
+	}
+
+	return declarations
+}
+
+// Generate has the model answer req with the caller's tools, running the
+// calls it makes and generating again with their results until it answers.
+// After maxRounds of calls the tools are withdrawn so that it must answer.
+func (r *Runner) Generate(
+	ctx context.Context,
+	caller Caller,
+	req llm.Request,
+	generate Generate,
+) (llm.Response, error) {
+	req.Tools = r.Declarations(caller.CharacterID)
+
+	for round := 0; ; round++ {
+		if round == maxRounds {
+			req.Tools = nil
+		}
+
+		resp, err := generate(ctx, req)
+		if err != nil || len(resp.Calls) == 0 || len(req.Tools) == 0 {
+			return resp, err
+		}
+
+		results := make([]llm.Result, 0, len(resp.Calls))
+		for _, call := range resp.Calls {
+			results = append(results, r.call(ctx, caller, call))
+		}
+
+		req.Messages = append(slices.Clip(req.Messages),
+			llm.Message{Role: llm.RoleModel, Text: resp.Text, Attachments: nil, Calls: resp.Calls, Results: nil},
+			llm.Message{Role: llm.RoleUser, Text: "", Attachments: nil, Calls: nil, Results: results},
+		)
+	}
+}
+
+// call runs one call. Every failure is reported to the model as the result,
+// so it can answer anyway.
+func (r *Runner) call(ctx context.Context, caller Caller, call llm.Call) llm.Result {
+	ctx, span := r.tracer.Start(ctx, "tool.Call", trace.WithAttributes(
+		attribute.String("tool.name", call.Name),
+		attribute.String("akari.character_id", caller.CharacterID),
+	))
+	defer span.End()
+
+	result := llm.Result{ID: call.ID, Name: call.Name, Output: nil, Error: ""}
+
+	output, outcome, err := r.run(ctx, caller, call)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		slog.WarnContext(ctx, "tool call failed", "tool", call.Name, "character_id", caller.CharacterID, "error", err)
+
+		result.Error = err.Error()
+	}
+
+	// Models sometimes invent tools, which should not grow the metric's
+	// cardinality.
+	label := call.Name
+	if _, ok := r.tools[call.Name]; !ok {
+		label = "unknown"
+	}
+
+	r.calls.WithLabelValues(label, outcome).Inc()
+	result.Output = output
+
+	return result
+}
+
+func (r *Runner) run(ctx context.Context, caller Caller, call llm.Call) (any, string, error) {
+	declared, ok := r.tools[call.Name]
+	if !ok || !slices.Contains(r.allowed(caller.CharacterID), call.Name) {
+		return nil, "denied", fmt.Errorf("%w: %s", ErrNotAllowed, call.Name)
+	}
+
+	timeout := declared.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		output any
+		err    error
+	}
+
+	done := make(chan outcome, 1)
+
+	go func() {
+		output, err := declared.Handler(ctx, Request{Caller: caller, Arguments: call.Arguments})
+		done <- outcome{output: output, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, "timeout", fmt.Errorf("%s: %w", call.Name, ctx.Err())
+	case finished := <-done:
+		if finished.err != nil {
+			return nil, "error", finished.err
+		}
+
+		return finished.output, "success", nil
+	}
+}
+
+// allowed returns the tools the character's registry entry allows, read
+// per call so that edits made through the admin API apply to the next
+// reply.
+func (r *Runner) allowed(characterID string) []string {
+	hosted, ok := r.characters.Get(characterID)
+	if !ok {
+		return nil
+	}
+
+	return hosted.Tools
+}
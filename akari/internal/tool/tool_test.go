@@ -0,0 +1,254 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kizuna-org/akari/internal/character"
+	"github.com/kizuna-org/akari/internal/config"
+	"github.com/kizuna-org/akari/internal/llm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const testCharacterID = "akari"
+
+func testTools() []Tool {
+	return []Tool{
+		{
+			Name:        "echo",
+			Description: "Echo the arguments.",
+			Parameters:  map[string]any{"type": "object"},
+			Timeout:     0,
+			Handler: func(_ context.Context, req Request) (any, error) {
+				return req.Arguments, nil
+			},
+		},
+		{
+			Name:        "stall",
+			Description: "Never finish.",
+			Parameters:  map[string]any{"type": "object"},
+			Timeout:     time.Millisecond,
+			Handler: func(ctx context.Context, _ Request) (any, error) {
+				<-ctx.Done()
+
+				return nil, ctx.Err()
+			},
+		},
+	}
+}
+
+func testRunner(t *testing.T, allowed ...string) *Runner {
+	t.Helper()
+
+	var cfg config.Config
+	cfg.Character = config.Character{ID: testCharacterID, Name: "Akari", Tools: allowed}
+
+	characters := character.NewRegistry(cfg, character.NewMemoryStore())
+
+	runner, err := NewRunner(cfg, prometheus.NewRegistry(), characters, testTools())
+	if err != nil {
+		t.Fatalf("NewRunner() error = %v", err)
+	}
+
+	return runner
+}
+
+func TestNewRunner(t *testing.T) {
+	t.Parallel()
+
+	var cfg config.Config
+	cfg.Character = config.Character{ID: testCharacterID, Name: "Akari", Tools: []string{"missing"}}
+
+	_, err := NewRunner(cfg, prometheus.NewRegistry(), nil, testTools())
+	if !errors.Is(err, ErrUnknownTool) {
+		t.Fatalf("NewRunner() with an unknown allowed tool error = %v, want %v", err, ErrUnknownTool)
+	}
+
+	cfg.Character.Tools = nil
+
+	_, err = NewRunner(cfg, prometheus.NewRegistry(), nil, append(testTools(), testTools()[0]))
+	if !errors.Is(err, ErrDuplicateTool) {
+		t.Fatalf("NewRunner() with a duplicate tool error = %v, want %v", err, ErrDuplicateTool)
+	}
+}
+
+func TestRunnerGenerate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		allowed []string
+		message string
+		want    string
+	}{
+		{name: "call", allowed: []string{"echo"}, message: `use echo {"a":1}`, want: `echo returned {"a":1}`},
+		{name: "no tools", allowed: nil, message: `use echo {"a":1}`, want: `You said: use echo {"a":1}`},
+		{
+			name:    "timeout",
+			allowed: []string{"stall"},
+			message: "use stall",
+			want:    "stall failed: stall: context deadline exceeded",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := testRunner(t, testCase.allowed...)
+			caller := Caller{CharacterID: testCharacterID, ChannelID: "channel", UserID: "user", UserName: "Alice"}
+			message := llm.Message{Role: llm.RoleUser, Text: testCase.message, Attachments: nil, Calls: nil, Results: nil}
+			req := llm.Request{System: "", Messages: []llm.Message{message}, Temperature: nil, Tools: nil}
+
+			resp, err := runner.Generate(t.Context(), caller, req, llm.NewFake().Generate)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			if resp.Text != testCase.want {
+				t.Fatalf("Generate() = %q, want %q", resp.Text, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRunnerGenerateRounds(t *testing.T) {
+	t.Parallel()
+
+	runner := testRunner(t, "echo")
+	caller := Caller{CharacterID: testCharacterID, ChannelID: "channel", UserID: "user", UserName: "Alice"}
+
+	// A model that keeps calling tools, and even a tool it was not offered.
+	rounds := 0
+	generate := func(_ context.Context, req llm.Request) (llm.Response, error) {
+		rounds++
+
+		if len(req.Tools) == 0 {
+			return llm.Response{Text: "done", Usage: llm.Usage{}, Calls: nil}, nil
+		}
+
+		return llm.Response{Text: "", Usage: llm.Usage{}, Calls: []llm.Call{
+			{ID: "1", Name: "echo", Arguments: json.RawMessage(`{}`)},
+			{ID: "2", Name: "stall", Arguments: json.RawMessage(`{}`)},
+		}}, nil
+	}
+
+	resp, err := runner.Generate(t.Context(), caller, llm.Request{}, generate)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if resp.Text != "done" || rounds != maxRounds+1 {
+		t.Fatalf("Generate() = %q after %d rounds, want done after %d", resp.Text, rounds, maxRounds+1)
+	}
+
+	denied := runner.call(t.Context(), caller, llm.Call{ID: "2", Name: "stall", Arguments: json.RawMessage(`{}`)})
+	if !strings.Contains(denied.Error, ErrNotAllowed.Error()) {
+		t.Fatalf("call() of a tool not allowed = %+v, want %v", denied, ErrNotAllowed)
+	}
+}
+
+func TestRunnerAllowedAtCallTime(t *testing.T) {
+	t.Parallel()
+
+	var cfg config.Config
+	cfg.Character = config.Character{ID: testCharacterID, Name: "Akari", Tools: []string{"echo"}}
+
+	characters := character.NewRegistry(cfg, character.NewMemoryStore())
+
+	err := characters.Load(t.Context())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	runner, err := NewRunner(cfg, prometheus.NewRegistry(), characters, testTools())
+	if err != nil {
+		t.Fatalf("NewRunner() error = %v", err)
+	}
+
+	_, err = characters.Create(t.Context(), character.Character{
+		ID:             "hikari",
+		Name:           "Hikari",
+		Persona:        "",
+		PromptTemplate: "",
+		ChannelIDs:     nil,
+		Tools:          []string{"stall"},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	_, err = characters.Update(t.Context(), testCharacterID, func(edited *character.Character) {
+		edited.Tools = nil
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	tests := []struct {
+		characterID string
+		want        []string
+	}{
+		{characterID: testCharacterID, want: nil},
+		{characterID: "hikari", want: []string{"stall"}},
+		{characterID: "missing", want: nil},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.characterID, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, declared := range runner.Declarations(testCase.characterID) {
+				got = append(got, declared.Name)
+			}
+
+			if !slices.Equal(got, testCase.want) {
+				t.Fatalf("Declarations() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestCurrentTime(t *testing.T) {
+	t.Parallel()
+
+	var cfg config.Config
+	cfg.Character = config.Character{ID: testCharacterID, Name: "Akari", Timezone: "Asia/Tokyo"}
+
+	handler := NewCurrentTime(cfg).Handler
+
+	tests := []struct {
+		name      string
+		arguments string
+		want      string
+		wantErr   error
+	}{
+		{name: "own timezone", arguments: `{}`, want: "Asia/Tokyo", wantErr: nil},
+		{name: "timezone", arguments: `{"timezone":"Europe/Paris"}`, want: "Europe/Paris", wantErr: nil},
+		{name: "unknown timezone", arguments: `{"timezone":"Moon/Base"}`, want: "", wantErr: ErrInvalidArguments},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := handler(t.Context(), Request{
+				Caller:    Caller{CharacterID: testCharacterID, ChannelID: "", UserID: "", UserName: ""},
+				Arguments: json.RawMessage(testCase.arguments),
+			})
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("current_time error = %v, want %v", err, testCase.wantErr)
+			}
+
+			if got, _ := output.(CurrentTime); got.Timezone != testCase.want {
+				t.Fatalf("current_time = %+v, want timezone %q", output, testCase.want)
+			}
+		})
+	}
+}
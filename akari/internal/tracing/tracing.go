@@ -0,0 +1,163 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"connectrpc.com/otelconnect"
+	"github.com/kizuna-org/akari/internal/config"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/fx"
+)
+
+const (
+	ExporterNone    = "none"
+	ExporterOTLP    = "otlp"
+	ExporterConsole = "console"
+
+	filePerm = 0o600
+)
+
+var ErrUnknownExporter = errors.New("unknown trace exporter")
+
+// Provider is the process tracer provider. Instrumentation reaches it through
+// the otel globals, which RegisterLifecycle installs.
+type Provider struct {
+	*sdktrace.TracerProvider
+
+	// output is the file the console exporter writes to, if any.
+	output io.Closer
+}
+
+// NewProvider builds the tracer provider configured by the OTEL_* variables.
+// With the "none" exporter spans are still created, so trace IDs show up in
+// logs and propagate to kiseki, but nothing is exported.
+func NewProvider(cfg config.Config) (*Provider, error) {
+	exporter, output, err := newExporter(context.Background(), cfg.Tracing)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", cfg.Tracing.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.Tracing.SampleRatio))),
+	}
+	if exporter != nil {
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	return &Provider{TracerProvider: sdktrace.NewTracerProvider(options...), output: output}, nil
+}
+
+func newExporter(ctx context.Context, cfg config.Tracing) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.Exporter {
+	case ExporterNone:
+		return nil, nil, nil
+	case ExporterOTLP:
+		var options []otlptracehttp.Option
+		if cfg.Endpoint != "" {
+			options = append(options, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		}
+
+		exporter, err := otlptracehttp.New(ctx, options...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create otlp trace exporter: %w", err)
+		}
+
+		return exporter, nil, nil
+	case ExporterConsole:
+		return newConsoleExporter(cfg.File)
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnknownExporter, cfg.Exporter)
+	}
+}
+
+func newConsoleExporter(path string) (sdktrace.SpanExporter, io.Closer, error) {
+	if path == "" {
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, nil, fmt.Errorf("create console trace exporter: %w", err)
+		}
+
+		return exporter, nil, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, filePerm)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open trace file: %w", err)
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+	if err != nil {
+		_ = file.Close()
+
+		return nil, nil, fmt.Errorf("create console trace exporter: %w", err)
+	}
+
+	return exporter, file, nil
+}
+
+// Shutdown flushes pending spans and closes the trace file.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	err := p.TracerProvider.Shutdown(ctx)
+	if err != nil {
+		return fmt.Errorf("shutdown tracer provider: %w", err)
+	}
+
+	if p.output != nil {
+		err = p.output.Close()
+		if err != nil {
+			return fmt.Errorf("close trace file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RegisterLifecycle installs provider and the W3C trace context propagator as
+// the otel globals, and flushes spans on stop.
+func RegisterLifecycle(lc fx.Lifecycle, provider *Provider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	lc.Append(fx.Hook{
+		OnStart: nil,
+		OnStop:  provider.Shutdown,
+	})
+}
+
+// NewRPCInterceptor traces Connect calls, continuing traces started by the
+// caller.
+func NewRPCInterceptor() (*otelconnect.Interceptor, error) {
+	interceptor, err := otelconnect.NewInterceptor(
+		otelconnect.WithTrustRemote(),
+		otelconnect.WithoutServerPeerAttributes(),
+		// RPC metrics are exported to Prometheus instead.
+		otelconnect.WithoutMetrics(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create otelconnect interceptor: %w", err)
+	}
+
+	return interceptor, nil
+}
+
+// NewTransport traces outbound requests made through base and propagates the
+// trace context to the server.
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base)
+}
@@ -0,0 +1,120 @@
+package tracing
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kizuna-org/akari/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/fx/fxtest"
+)
+
+func testConfig(exporter string, file string) config.Config {
+	var cfg config.Config
+	cfg.Tracing = config.Tracing{
+		Exporter:    exporter,
+		Endpoint:    "",
+		File:        file,
+		SampleRatio: 1,
+		ServiceName: "akari-test",
+	}
+
+	return cfg
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		exporter string
+		wantErr  error
+	}{
+		{name: "none", exporter: ExporterNone, wantErr: nil},
+		{name: "otlp", exporter: ExporterOTLP, wantErr: nil},
+		{name: "console", exporter: ExporterConsole, wantErr: nil},
+		{name: "unknown", exporter: "zipkin", wantErr: ErrUnknownExporter},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider, err := NewProvider(testConfig(testCase.exporter, filepath.Join(t.TempDir(), "traces.json")))
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("NewProvider() error = %v, want %v", err, testCase.wantErr)
+			}
+
+			if err == nil {
+				_ = provider.Shutdown(t.Context())
+			}
+		})
+	}
+}
+
+// TestTransport checks that an outbound request joins the caller's trace, as
+// requests to kiseki must. It installs the otel globals, so it is not
+// parallel.
+func TestTransport(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "traces.json")
+
+	provider, err := NewProvider(testConfig(ExporterConsole, file))
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	lifecycle := fxtest.NewLifecycle(t)
+	RegisterLifecycle(lifecycle, provider)
+	lifecycle.RequireStart()
+
+	var traceparent string
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		traceparent = req.Header.Get("Traceparent")
+	}))
+	t.Cleanup(upstream.Close)
+
+	ctx, span := otel.Tracer("test").Start(t.Context(), "reply")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, upstream.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	resp, err := (&http.Client{Transport: NewTransport(http.DefaultTransport)}).Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	_ = resp.Body.Close()
+
+	span.End()
+
+	if !strings.Contains(traceparent, span.SpanContext().TraceID().String()) {
+		t.Fatalf("traceparent = %q, want trace %s", traceparent, span.SpanContext().TraceID())
+	}
+
+	lifecycle.RequireStop()
+
+	exported, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	for _, name := range []string{`"Name":"reply"`, `"Name":"HTTP GET"`} {
+		if !strings.Contains(string(exported), name) {
+			t.Fatalf("exported spans do not include %s:\n%s", name, exported)
+		}
+	}
+}
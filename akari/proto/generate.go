@@ -0,0 +1,3 @@
+package proto
+
+//go:generate go tool buf generate
@@ -23,7 +23,20 @@ import (
 
 // Defines values for DType.
 const (
-	Text DType = "text"
+	DTypeEvent      DType = "event"
+	DTypeFact       DType = "fact"
+	DTypeImage      DType = "image"
+	DTypeImageRef   DType = "image_ref"
+	DTypePreference DType = "preference"
+	DTypeText       DType = "text"
+)
+
+// Defines values for EntityKind.
+const (
+	Other  EntityKind = "other"
+	Person EntityKind = "person"
+	Place  EntityKind = "place"
+	Topic  EntityKind = "topic"
 )
 
 // Defines values for HealthResponseStatus.
@@ -33,19 +46,38 @@ const (
 	Unhealthy HealthResponseStatus = "unhealthy"
 )
 
+// Defines values for MemorySleepStatusResponseStatus.
+const (
+	Completed MemorySleepStatusResponseStatus = "completed"
+	Failed    MemorySleepStatusResponseStatus = "failed"
+	Pending   MemorySleepStatusResponseStatus = "pending"
+	Running   MemorySleepStatusResponseStatus = "running"
+)
+
+// Defines values for PreferenceSentiment.
+const (
+	Dislikes PreferenceSentiment = "dislikes"
+	Likes    PreferenceSentiment = "likes"
+)
+
 // BaseData Base data structure with dType and data
 type BaseData struct {
-	// DType Data type identifier
+	// DType Data type identifier, which selects the schema of the data: `text`
+	// is a string, `fact` a Fact, `event` an Event, `preference` a
+	// Preference and `image_ref` an ImageRef. `image` is a deprecated alias
+	// of `image_ref` whose data is a string describing the image, as
+	// fragments stored before `image_ref` existed have; write `image_ref`
+	// instead.
 	DType DType `json:"dType"`
 
-	// Data Typed data value
+	// Data Typed data value, of the schema dType selects
 	Data BaseData_Data `json:"data"`
 }
 
 // BaseDataData0 defines model for .
 type BaseDataData0 = string
 
-// BaseData_Data Typed data value
+// BaseData_Data Typed data value, of the schema dType selects
 type BaseData_Data struct {
 	union json.RawMessage
 }
@@ -77,26 +109,64 @@ type CreateCharacterRequest struct {
 	Name string `json:"name"`
 }
 
-// DType Data type identifier
+// DType Data type identifier, which selects the schema of the data: `text`
+// is a string, `fact` a Fact, `event` an Event, `preference` a
+// Preference and `image_ref` an ImageRef. `image` is a deprecated alias
+// of `image_ref` whose data is a string describing the image, as
+// fragments stored before `image_ref` existed have; write `image_ref`
+// instead.
 type DType string
 
 // DataFragment defines model for DataFragment.
 type DataFragment struct {
-	// DType Data type identifier
+	// DType Data type identifier, which selects the schema of the data: `text`
+	// is a string, `fact` a Fact, `event` an Event, `preference` a
+	// Preference and `image_ref` an ImageRef. `image` is a deprecated alias
+	// of `image_ref` whose data is a string describing the image, as
+	// fragments stored before `image_ref` existed have; write `image_ref`
+	// instead.
 	DType DType `json:"dType"`
 
-	// Data Typed data value
+	// Data Typed data value, of the schema dType selects
 	Data DataFragment_Data `json:"data"`
 }
 
 // DataFragmentData0 defines model for .
 type DataFragmentData0 = string
 
-// DataFragment_Data Typed data value
+// DataFragment_Data Typed data value, of the schema dType selects
 type DataFragment_Data struct {
 	union json.RawMessage
 }
 
+// Entity A node of the knowledge graph
+type Entity struct {
+	// Aliases Other names the entity is known by
+	Aliases []string `json:"aliases"`
+
+	// ExternalIds IDs the entity is known by outside kiseki, such as the Discord
+	// user ID of a person, taken from the subjectId of its fragments
+	ExternalIds *[]string `json:"externalIds,omitempty"`
+
+	// Id Entity unique identifier
+	Id openapi_types.UUID `json:"id"`
+
+	// Kind What an entity is
+	Kind EntityKind `json:"kind"`
+
+	// Name Canonical name of the entity
+	Name string `json:"name"`
+}
+
+// EntityKind What an entity is
+type EntityKind string
+
+// EntityListResponse defines model for EntityListResponse.
+type EntityListResponse struct {
+	// Items List of entities
+	Items []Entity `json:"items"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Code    string                  `json:"code"`
@@ -104,12 +174,39 @@ type Error struct {
 	Message string                  `json:"message"`
 }
 
+// Event Something that happened, or will happen, at a time
+type Event struct {
+	// Participants Names of the people taking part
+	Participants []string `json:"participants"`
+	Summary      string   `json:"summary"`
+
+	// Time When the event happens
+	Time time.Time `json:"time"`
+}
+
+// Fact A subject-predicate-object statement, such as "Alice" "lives in" "Osaka"
+type Fact struct {
+	Object    string `json:"object"`
+	Predicate string `json:"predicate"`
+	Subject   string `json:"subject"`
+
+	// SubjectId External ID of the subject, such as the Discord user ID of a user.
+	// The subject's person entity is keyed on it, so a user who changes
+	// their name stays one entity.
+	SubjectId *string `json:"subjectId,omitempty"`
+}
+
 // Fragment defines model for Fragment.
 type Fragment struct {
-	// DType Data type identifier
+	// DType Data type identifier, which selects the schema of the data: `text`
+	// is a string, `fact` a Fact, `event` an Event, `preference` a
+	// Preference and `image_ref` an ImageRef. `image` is a deprecated alias
+	// of `image_ref` whose data is a string describing the image, as
+	// fragments stored before `image_ref` existed have; write `image_ref`
+	// instead.
 	DType DType `json:"dType"`
 
-	// Data Typed data value
+	// Data Typed data value, of the schema dType selects
 	Data Fragment_Data `json:"data"`
 
 	// Meta Metadata object with timestamps
@@ -119,11 +216,23 @@ type Fragment struct {
 // FragmentData0 defines model for .
 type FragmentData0 = string
 
-// Fragment_Data Typed data value
+// Fragment_Data Typed data value, of the schema dType selects
 type Fragment_Data struct {
 	union json.RawMessage
 }
 
+// GraphResponse The neighbourhood of an entity
+type GraphResponse struct {
+	// Edges The relations between the entity and its neighbours, the most confident first
+	Edges []Relation `json:"edges"`
+
+	// Entity A node of the knowledge graph
+	Entity Entity `json:"entity"`
+
+	// Nodes The related entities, without the entity itself
+	Nodes []Entity `json:"nodes"`
+}
+
 // HealthResponse defines model for HealthResponse.
 type HealthResponse struct {
 	Status    HealthResponseStatus `json:"status"`
@@ -134,19 +243,32 @@ type HealthResponse struct {
 // HealthResponseStatus defines model for HealthResponse.Status.
 type HealthResponseStatus string
 
+// ImageRef An image someone shared, described in text. The image itself is not stored.
+type ImageRef struct {
+	Description string  `json:"description"`
+	Filename    *string `json:"filename,omitempty"`
+	MimeType    string  `json:"mimeType"`
+	Url         string  `json:"url"`
+}
+
 // MemoryIORequest defines model for MemoryIORequest.
 type MemoryIORequest struct {
-	// DType Data type identifier
+	// DType Data type identifier, which selects the schema of the data: `text`
+	// is a string, `fact` a Fact, `event` an Event, `preference` a
+	// Preference and `image_ref` an ImageRef. `image` is a deprecated alias
+	// of `image_ref` whose data is a string describing the image, as
+	// fragments stored before `image_ref` existed have; write `image_ref`
+	// instead.
 	DType DType `json:"dType"`
 
-	// Data Typed data value
+	// Data Typed data value, of the schema dType selects
 	Data MemoryIORequest_Data `json:"data"`
 }
 
 // MemoryIORequestData0 defines model for .
 type MemoryIORequestData0 = string
 
-// MemoryIORequest_Data Typed data value
+// MemoryIORequest_Data Typed data value, of the schema dType selects
 type MemoryIORequest_Data struct {
 	union json.RawMessage
 }
@@ -171,10 +293,25 @@ type MemoryPollingResponse struct {
 
 // MemorySleepResponse defines model for MemorySleepResponse.
 type MemorySleepResponse struct {
-	// PollingUrl Polling URL for memory operations
+	// PollingUrl Polling URL for memory operations, served by getMemorySleepStatus
 	PollingUrl string `json:"pollingUrl"`
 }
 
+// MemorySleepStatusResponse defines model for MemorySleepStatusResponse.
+type MemorySleepStatusResponse struct {
+	// Message Human readable detail, set when the sleep failed
+	Message *string `json:"message,omitempty"`
+
+	// Status Progress of the sleep
+	Status MemorySleepStatusResponseStatus `json:"status"`
+
+	// UpdatedAt Timestamp when the status last changed
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// MemorySleepStatusResponseStatus Progress of the sleep
+type MemorySleepStatusResponseStatus string
+
 // Meta Metadata object with timestamps
 type Meta struct {
 	// CreatedAt Timestamp when the data was created
@@ -190,10 +327,15 @@ type Meta struct {
 
 // PollingRequestItem defines model for PollingRequestItem.
 type PollingRequestItem struct {
-	// DType Data type identifier
+	// DType Data type identifier, which selects the schema of the data: `text`
+	// is a string, `fact` a Fact, `event` an Event, `preference` a
+	// Preference and `image_ref` an ImageRef. `image` is a deprecated alias
+	// of `image_ref` whose data is a string describing the image, as
+	// fragments stored before `image_ref` existed have; write `image_ref`
+	// instead.
 	DType DType `json:"dType"`
 
-	// Data Typed data value
+	// Data Typed data value, of the schema dType selects
 	Data PollingRequestItem_Data `json:"data"`
 
 	// TaskId Identifier of the completed task
@@ -203,7 +345,7 @@ type PollingRequestItem struct {
 // PollingRequestItemData0 defines model for .
 type PollingRequestItemData0 = string
 
-// PollingRequestItem_Data Typed data value
+// PollingRequestItem_Data Typed data value, of the schema dType selects
 type PollingRequestItem_Data struct {
 	union json.RawMessage
 }
@@ -219,10 +361,15 @@ type PollingResponseGroup struct {
 
 // PollingResponseItem defines model for PollingResponseItem.
 type PollingResponseItem struct {
-	// DType Data type identifier
+	// DType Data type identifier, which selects the schema of the data: `text`
+	// is a string, `fact` a Fact, `event` an Event, `preference` a
+	// Preference and `image_ref` an ImageRef. `image` is a deprecated alias
+	// of `image_ref` whose data is a string describing the image, as
+	// fragments stored before `image_ref` existed have; write `image_ref`
+	// instead.
 	DType DType `json:"dType"`
 
-	// Data Typed data value
+	// Data Typed data value, of the schema dType selects
 	Data PollingResponseItem_Data `json:"data"`
 
 	// Meta Metadata object with timestamps
@@ -235,11 +382,54 @@ type PollingResponseItem struct {
 // PollingResponseItemData0 defines model for .
 type PollingResponseItemData0 = string
 
-// PollingResponseItem_Data Typed data value
+// PollingResponseItem_Data Typed data value, of the schema dType selects
 type PollingResponseItem_Data struct {
 	union json.RawMessage
 }
 
+// Preference What someone likes or dislikes
+type Preference struct {
+	// Object What the preference is about
+	Object    string              `json:"object"`
+	Sentiment PreferenceSentiment `json:"sentiment"`
+
+	// Subject Who holds the preference
+	Subject string `json:"subject"`
+
+	// SubjectId External ID of the subject, as for a Fact
+	SubjectId *string `json:"subjectId,omitempty"`
+}
+
+// PreferenceSentiment defines model for Preference.Sentiment.
+type PreferenceSentiment string
+
+// Provenance A memory a relation was extracted from
+type Provenance struct {
+	// ExtractedAt Timestamp when the relation was extracted from the fragment
+	ExtractedAt time.Time `json:"extractedAt"`
+	Fragment    Fragment  `json:"fragment"`
+}
+
+// Relation An edge of the knowledge graph, reading "source predicate target",
+// such as Alice lives in Osaka
+type Relation struct {
+	// Confidence How sure the character is of the relation, growing with its sources
+	Confidence float32 `json:"confidence"`
+
+	// Id Relation unique identifier
+	Id        openapi_types.UUID `json:"id"`
+	Predicate string             `json:"predicate"`
+
+	// Provenance The memories the relation was extracted from
+	Provenance []Provenance `json:"provenance"`
+
+	// Source ID of the entity the relation starts from
+	Source openapi_types.UUID `json:"source"`
+
+	// Target ID of the entity the relation points to
+	Target openapi_types.UUID `json:"target"`
+}
+
 // UpdateCharacterRequest defines model for UpdateCharacterRequest.
 type UpdateCharacterRequest struct {
 	// Name Character name
@@ -249,6 +439,12 @@ type UpdateCharacterRequest struct {
 // CharacterIdPath defines model for CharacterIdPath.
 type CharacterIdPath = openapi_types.UUID
 
+// EntityIdPath defines model for EntityIdPath.
+type EntityIdPath = openapi_types.UUID
+
+// SleepIdPath defines model for SleepIdPath.
+type SleepIdPath = openapi_types.UUID
+
 // BadRequest defines model for BadRequest.
 type BadRequest = Error
 
@@ -258,13 +454,64 @@ type InternalServerError = Error
 // NotFound defines model for NotFound.
 type NotFound = Error
 
+// ListGraphEntitiesParams defines parameters for ListGraphEntities.
+type ListGraphEntitiesParams struct {
+	// Name Name or alias of the entity, matched case-insensitively
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+
+	// ExternalId External ID of the entity, such as a Discord user ID, matched exactly
+	ExternalId *string `form:"externalId,omitempty" json:"externalId,omitempty"`
+
+	// Kind Kind of the entity
+	Kind *EntityKind `form:"kind,omitempty" json:"kind,omitempty"`
+}
+
+// GetGraphNeighborsParams defines parameters for GetGraphNeighbors.
+type GetGraphNeighborsParams struct {
+	// Depth How many relations away to look
+	Depth *int `form:"depth,omitempty" json:"depth,omitempty"`
+
+	// MinConfidence Lowest confidence of the relations to follow
+	MinConfidence *float32 `form:"minConfidence,omitempty" json:"minConfidence,omitempty"`
+
+	// Limit Most relations to return, the most confident first
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// DeleteMemoryIOParams defines parameters for DeleteMemoryIO.
+type DeleteMemoryIOParams struct {
+	// DType Data type identifier
+	DType DType `form:"dType" json:"dType"`
+
+	// Data Data value to forget, JSON encoded for structured types
+	Data string `form:"data" json:"data"`
+}
+
 // GetMemoryIOParams defines parameters for GetMemoryIO.
 type GetMemoryIOParams struct {
 	// DType Data type identifier
 	DType DType `form:"dType" json:"dType"`
 
 	// Data Data identifier or value
-	Data string `form:"data" json:"data"`
+	Data *string `form:"data,omitempty" json:"data,omitempty"`
+
+	// Subject Subject of a fact or preference
+	Subject *string `form:"subject,omitempty" json:"subject,omitempty"`
+
+	// Predicate Predicate of a fact
+	Predicate *string `form:"predicate,omitempty" json:"predicate,omitempty"`
+
+	// Object Object of a fact or preference
+	Object *string `form:"object,omitempty" json:"object,omitempty"`
+
+	// Participant A participant of an event
+	Participant *string `form:"participant,omitempty" json:"participant,omitempty"`
+
+	// Since Earliest time of an event
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Latest time of an event
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
 }
 
 // CreateCharacterJSONRequestBody defines body for CreateCharacter for application/json ContentType.
@@ -397,6 +644,110 @@ func (t *BaseData_Data) MergeBaseDataData0(v BaseDataData0) error {
 	return err
 }
 
+// AsFact returns the union data inside the BaseData_Data as a Fact
+func (t BaseData_Data) AsFact() (Fact, error) {
+	var body Fact
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromFact overwrites any union data inside the BaseData_Data as the provided Fact
+func (t *BaseData_Data) FromFact(v Fact) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeFact performs a merge with any union data inside the BaseData_Data, using the provided Fact
+func (t *BaseData_Data) MergeFact(v Fact) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsEvent returns the union data inside the BaseData_Data as a Event
+func (t BaseData_Data) AsEvent() (Event, error) {
+	var body Event
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromEvent overwrites any union data inside the BaseData_Data as the provided Event
+func (t *BaseData_Data) FromEvent(v Event) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeEvent performs a merge with any union data inside the BaseData_Data, using the provided Event
+func (t *BaseData_Data) MergeEvent(v Event) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsPreference returns the union data inside the BaseData_Data as a Preference
+func (t BaseData_Data) AsPreference() (Preference, error) {
+	var body Preference
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromPreference overwrites any union data inside the BaseData_Data as the provided Preference
+func (t *BaseData_Data) FromPreference(v Preference) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergePreference performs a merge with any union data inside the BaseData_Data, using the provided Preference
+func (t *BaseData_Data) MergePreference(v Preference) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsImageRef returns the union data inside the BaseData_Data as a ImageRef
+func (t BaseData_Data) AsImageRef() (ImageRef, error) {
+	var body ImageRef
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromImageRef overwrites any union data inside the BaseData_Data as the provided ImageRef
+func (t *BaseData_Data) FromImageRef(v ImageRef) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeImageRef performs a merge with any union data inside the BaseData_Data, using the provided ImageRef
+func (t *BaseData_Data) MergeImageRef(v ImageRef) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t BaseData_Data) MarshalJSON() ([]byte, error) {
 	b, err := t.union.MarshalJSON()
 	return b, err
@@ -433,32 +784,48 @@ func (t *DataFragment_Data) MergeDataFragmentData0(v DataFragmentData0) error {
 	return err
 }
 
-func (t DataFragment_Data) MarshalJSON() ([]byte, error) {
-	b, err := t.union.MarshalJSON()
-	return b, err
+// AsFact returns the union data inside the DataFragment_Data as a Fact
+func (t DataFragment_Data) AsFact() (Fact, error) {
+	var body Fact
+	err := json.Unmarshal(t.union, &body)
+	return body, err
 }
 
-func (t *DataFragment_Data) UnmarshalJSON(b []byte) error {
-	err := t.union.UnmarshalJSON(b)
+// FromFact overwrites any union data inside the DataFragment_Data as the provided Fact
+func (t *DataFragment_Data) FromFact(v Fact) error {
+	b, err := json.Marshal(v)
+	t.union = b
 	return err
 }
 
-// AsFragmentData0 returns the union data inside the Fragment_Data as a FragmentData0
-func (t Fragment_Data) AsFragmentData0() (FragmentData0, error) {
-	var body FragmentData0
+// MergeFact performs a merge with any union data inside the DataFragment_Data, using the provided Fact
+func (t *DataFragment_Data) MergeFact(v Fact) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsEvent returns the union data inside the DataFragment_Data as a Event
+func (t DataFragment_Data) AsEvent() (Event, error) {
+	var body Event
 	err := json.Unmarshal(t.union, &body)
 	return body, err
 }
 
-// FromFragmentData0 overwrites any union data inside the Fragment_Data as the provided FragmentData0
-func (t *Fragment_Data) FromFragmentData0(v FragmentData0) error {
+// FromEvent overwrites any union data inside the DataFragment_Data as the provided Event
+func (t *DataFragment_Data) FromEvent(v Event) error {
 	b, err := json.Marshal(v)
 	t.union = b
 	return err
 }
 
-// MergeFragmentData0 performs a merge with any union data inside the Fragment_Data, using the provided FragmentData0
-func (t *Fragment_Data) MergeFragmentData0(v FragmentData0) error {
+// MergeEvent performs a merge with any union data inside the DataFragment_Data, using the provided Event
+func (t *DataFragment_Data) MergeEvent(v Event) error {
 	b, err := json.Marshal(v)
 	if err != nil {
 		return err
@@ -469,32 +836,48 @@ func (t *Fragment_Data) MergeFragmentData0(v FragmentData0) error {
 	return err
 }
 
-func (t Fragment_Data) MarshalJSON() ([]byte, error) {
-	b, err := t.union.MarshalJSON()
-	return b, err
+// AsPreference returns the union data inside the DataFragment_Data as a Preference
+func (t DataFragment_Data) AsPreference() (Preference, error) {
+	var body Preference
+	err := json.Unmarshal(t.union, &body)
+	return body, err
 }
 
-func (t *Fragment_Data) UnmarshalJSON(b []byte) error {
-	err := t.union.UnmarshalJSON(b)
+// FromPreference overwrites any union data inside the DataFragment_Data as the provided Preference
+func (t *DataFragment_Data) FromPreference(v Preference) error {
+	b, err := json.Marshal(v)
+	t.union = b
 	return err
 }
 
-// AsMemoryIORequestData0 returns the union data inside the MemoryIORequest_Data as a MemoryIORequestData0
-func (t MemoryIORequest_Data) AsMemoryIORequestData0() (MemoryIORequestData0, error) {
-	var body MemoryIORequestData0
+// MergePreference performs a merge with any union data inside the DataFragment_Data, using the provided Preference
+func (t *DataFragment_Data) MergePreference(v Preference) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsImageRef returns the union data inside the DataFragment_Data as a ImageRef
+func (t DataFragment_Data) AsImageRef() (ImageRef, error) {
+	var body ImageRef
 	err := json.Unmarshal(t.union, &body)
 	return body, err
 }
 
-// FromMemoryIORequestData0 overwrites any union data inside the MemoryIORequest_Data as the provided MemoryIORequestData0
-func (t *MemoryIORequest_Data) FromMemoryIORequestData0(v MemoryIORequestData0) error {
+// FromImageRef overwrites any union data inside the DataFragment_Data as the provided ImageRef
+func (t *DataFragment_Data) FromImageRef(v ImageRef) error {
 	b, err := json.Marshal(v)
 	t.union = b
 	return err
 }
 
-// MergeMemoryIORequestData0 performs a merge with any union data inside the MemoryIORequest_Data, using the provided MemoryIORequestData0
-func (t *MemoryIORequest_Data) MergeMemoryIORequestData0(v MemoryIORequestData0) error {
+// MergeImageRef performs a merge with any union data inside the DataFragment_Data, using the provided ImageRef
+func (t *DataFragment_Data) MergeImageRef(v ImageRef) error {
 	b, err := json.Marshal(v)
 	if err != nil {
 		return err
@@ -505,32 +888,32 @@ func (t *MemoryIORequest_Data) MergeMemoryIORequestData0(v MemoryIORequestData0)
 	return err
 }
 
-func (t MemoryIORequest_Data) MarshalJSON() ([]byte, error) {
+func (t DataFragment_Data) MarshalJSON() ([]byte, error) {
 	b, err := t.union.MarshalJSON()
 	return b, err
 }
 
-func (t *MemoryIORequest_Data) UnmarshalJSON(b []byte) error {
+func (t *DataFragment_Data) UnmarshalJSON(b []byte) error {
 	err := t.union.UnmarshalJSON(b)
 	return err
 }
 
-// AsPollingRequestItemData0 returns the union data inside the PollingRequestItem_Data as a PollingRequestItemData0
-func (t PollingRequestItem_Data) AsPollingRequestItemData0() (PollingRequestItemData0, error) {
-	var body PollingRequestItemData0
+// AsFragmentData0 returns the union data inside the Fragment_Data as a FragmentData0
+func (t Fragment_Data) AsFragmentData0() (FragmentData0, error) {
+	var body FragmentData0
 	err := json.Unmarshal(t.union, &body)
 	return body, err
 }
 
-// FromPollingRequestItemData0 overwrites any union data inside the PollingRequestItem_Data as the provided PollingRequestItemData0
-func (t *PollingRequestItem_Data) FromPollingRequestItemData0(v PollingRequestItemData0) error {
+// FromFragmentData0 overwrites any union data inside the Fragment_Data as the provided FragmentData0
+func (t *Fragment_Data) FromFragmentData0(v FragmentData0) error {
 	b, err := json.Marshal(v)
 	t.union = b
 	return err
 }
 
-// MergePollingRequestItemData0 performs a merge with any union data inside the PollingRequestItem_Data, using the provided PollingRequestItemData0
-func (t *PollingRequestItem_Data) MergePollingRequestItemData0(v PollingRequestItemData0) error {
+// MergeFragmentData0 performs a merge with any union data inside the Fragment_Data, using the provided FragmentData0
+func (t *Fragment_Data) MergeFragmentData0(v FragmentData0) error {
 	b, err := json.Marshal(v)
 	if err != nil {
 		return err
@@ -541,32 +924,48 @@ func (t *PollingRequestItem_Data) MergePollingRequestItemData0(v PollingRequestI
 	return err
 }
 
-func (t PollingRequestItem_Data) MarshalJSON() ([]byte, error) {
-	b, err := t.union.MarshalJSON()
-	return b, err
+// AsFact returns the union data inside the Fragment_Data as a Fact
+func (t Fragment_Data) AsFact() (Fact, error) {
+	var body Fact
+	err := json.Unmarshal(t.union, &body)
+	return body, err
 }
 
-func (t *PollingRequestItem_Data) UnmarshalJSON(b []byte) error {
-	err := t.union.UnmarshalJSON(b)
+// FromFact overwrites any union data inside the Fragment_Data as the provided Fact
+func (t *Fragment_Data) FromFact(v Fact) error {
+	b, err := json.Marshal(v)
+	t.union = b
 	return err
 }
 
-// AsPollingResponseItemData0 returns the union data inside the PollingResponseItem_Data as a PollingResponseItemData0
-func (t PollingResponseItem_Data) AsPollingResponseItemData0() (PollingResponseItemData0, error) {
-	var body PollingResponseItemData0
+// MergeFact performs a merge with any union data inside the Fragment_Data, using the provided Fact
+func (t *Fragment_Data) MergeFact(v Fact) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsEvent returns the union data inside the Fragment_Data as a Event
+func (t Fragment_Data) AsEvent() (Event, error) {
+	var body Event
 	err := json.Unmarshal(t.union, &body)
 	return body, err
 }
 
-// FromPollingResponseItemData0 overwrites any union data inside the PollingResponseItem_Data as the provided PollingResponseItemData0
-func (t *PollingResponseItem_Data) FromPollingResponseItemData0(v PollingResponseItemData0) error {
+// FromEvent overwrites any union data inside the Fragment_Data as the provided Event
+func (t *Fragment_Data) FromEvent(v Event) error {
 	b, err := json.Marshal(v)
 	t.union = b
 	return err
 }
 
-// MergePollingResponseItemData0 performs a merge with any union data inside the PollingResponseItem_Data, using the provided PollingResponseItemData0
-func (t *PollingResponseItem_Data) MergePollingResponseItemData0(v PollingResponseItemData0) error {
+// MergeEvent performs a merge with any union data inside the Fragment_Data, using the provided Event
+func (t *Fragment_Data) MergeEvent(v Event) error {
 	b, err := json.Marshal(v)
 	if err != nil {
 		return err
@@ -577,9 +976,481 @@ func (t *PollingResponseItem_Data) MergePollingResponseItemData0(v PollingRespon
 	return err
 }
 
-func (t PollingResponseItem_Data) MarshalJSON() ([]byte, error) {
-	b, err := t.union.MarshalJSON()
-	return b, err
+// AsPreference returns the union data inside the Fragment_Data as a Preference
+func (t Fragment_Data) AsPreference() (Preference, error) {
+	var body Preference
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromPreference overwrites any union data inside the Fragment_Data as the provided Preference
+func (t *Fragment_Data) FromPreference(v Preference) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergePreference performs a merge with any union data inside the Fragment_Data, using the provided Preference
+func (t *Fragment_Data) MergePreference(v Preference) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsImageRef returns the union data inside the Fragment_Data as a ImageRef
+func (t Fragment_Data) AsImageRef() (ImageRef, error) {
+	var body ImageRef
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromImageRef overwrites any union data inside the Fragment_Data as the provided ImageRef
+func (t *Fragment_Data) FromImageRef(v ImageRef) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeImageRef performs a merge with any union data inside the Fragment_Data, using the provided ImageRef
+func (t *Fragment_Data) MergeImageRef(v ImageRef) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t Fragment_Data) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *Fragment_Data) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// AsMemoryIORequestData0 returns the union data inside the MemoryIORequest_Data as a MemoryIORequestData0
+func (t MemoryIORequest_Data) AsMemoryIORequestData0() (MemoryIORequestData0, error) {
+	var body MemoryIORequestData0
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromMemoryIORequestData0 overwrites any union data inside the MemoryIORequest_Data as the provided MemoryIORequestData0
+func (t *MemoryIORequest_Data) FromMemoryIORequestData0(v MemoryIORequestData0) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeMemoryIORequestData0 performs a merge with any union data inside the MemoryIORequest_Data, using the provided MemoryIORequestData0
+func (t *MemoryIORequest_Data) MergeMemoryIORequestData0(v MemoryIORequestData0) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsFact returns the union data inside the MemoryIORequest_Data as a Fact
+func (t MemoryIORequest_Data) AsFact() (Fact, error) {
+	var body Fact
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromFact overwrites any union data inside the MemoryIORequest_Data as the provided Fact
+func (t *MemoryIORequest_Data) FromFact(v Fact) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeFact performs a merge with any union data inside the MemoryIORequest_Data, using the provided Fact
+func (t *MemoryIORequest_Data) MergeFact(v Fact) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsEvent returns the union data inside the MemoryIORequest_Data as a Event
+func (t MemoryIORequest_Data) AsEvent() (Event, error) {
+	var body Event
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromEvent overwrites any union data inside the MemoryIORequest_Data as the provided Event
+func (t *MemoryIORequest_Data) FromEvent(v Event) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeEvent performs a merge with any union data inside the MemoryIORequest_Data, using the provided Event
+func (t *MemoryIORequest_Data) MergeEvent(v Event) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsPreference returns the union data inside the MemoryIORequest_Data as a Preference
+func (t MemoryIORequest_Data) AsPreference() (Preference, error) {
+	var body Preference
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromPreference overwrites any union data inside the MemoryIORequest_Data as the provided Preference
+func (t *MemoryIORequest_Data) FromPreference(v Preference) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergePreference performs a merge with any union data inside the MemoryIORequest_Data, using the provided Preference
+func (t *MemoryIORequest_Data) MergePreference(v Preference) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsImageRef returns the union data inside the MemoryIORequest_Data as a ImageRef
+func (t MemoryIORequest_Data) AsImageRef() (ImageRef, error) {
+	var body ImageRef
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromImageRef overwrites any union data inside the MemoryIORequest_Data as the provided ImageRef
+func (t *MemoryIORequest_Data) FromImageRef(v ImageRef) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeImageRef performs a merge with any union data inside the MemoryIORequest_Data, using the provided ImageRef
+func (t *MemoryIORequest_Data) MergeImageRef(v ImageRef) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t MemoryIORequest_Data) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *MemoryIORequest_Data) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// AsPollingRequestItemData0 returns the union data inside the PollingRequestItem_Data as a PollingRequestItemData0
+func (t PollingRequestItem_Data) AsPollingRequestItemData0() (PollingRequestItemData0, error) {
+	var body PollingRequestItemData0
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromPollingRequestItemData0 overwrites any union data inside the PollingRequestItem_Data as the provided PollingRequestItemData0
+func (t *PollingRequestItem_Data) FromPollingRequestItemData0(v PollingRequestItemData0) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergePollingRequestItemData0 performs a merge with any union data inside the PollingRequestItem_Data, using the provided PollingRequestItemData0
+func (t *PollingRequestItem_Data) MergePollingRequestItemData0(v PollingRequestItemData0) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsFact returns the union data inside the PollingRequestItem_Data as a Fact
+func (t PollingRequestItem_Data) AsFact() (Fact, error) {
+	var body Fact
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromFact overwrites any union data inside the PollingRequestItem_Data as the provided Fact
+func (t *PollingRequestItem_Data) FromFact(v Fact) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeFact performs a merge with any union data inside the PollingRequestItem_Data, using the provided Fact
+func (t *PollingRequestItem_Data) MergeFact(v Fact) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsEvent returns the union data inside the PollingRequestItem_Data as a Event
+func (t PollingRequestItem_Data) AsEvent() (Event, error) {
+	var body Event
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromEvent overwrites any union data inside the PollingRequestItem_Data as the provided Event
+func (t *PollingRequestItem_Data) FromEvent(v Event) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeEvent performs a merge with any union data inside the PollingRequestItem_Data, using the provided Event
+func (t *PollingRequestItem_Data) MergeEvent(v Event) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsPreference returns the union data inside the PollingRequestItem_Data as a Preference
+func (t PollingRequestItem_Data) AsPreference() (Preference, error) {
+	var body Preference
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromPreference overwrites any union data inside the PollingRequestItem_Data as the provided Preference
+func (t *PollingRequestItem_Data) FromPreference(v Preference) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergePreference performs a merge with any union data inside the PollingRequestItem_Data, using the provided Preference
+func (t *PollingRequestItem_Data) MergePreference(v Preference) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsImageRef returns the union data inside the PollingRequestItem_Data as a ImageRef
+func (t PollingRequestItem_Data) AsImageRef() (ImageRef, error) {
+	var body ImageRef
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromImageRef overwrites any union data inside the PollingRequestItem_Data as the provided ImageRef
+func (t *PollingRequestItem_Data) FromImageRef(v ImageRef) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeImageRef performs a merge with any union data inside the PollingRequestItem_Data, using the provided ImageRef
+func (t *PollingRequestItem_Data) MergeImageRef(v ImageRef) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t PollingRequestItem_Data) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *PollingRequestItem_Data) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// AsPollingResponseItemData0 returns the union data inside the PollingResponseItem_Data as a PollingResponseItemData0
+func (t PollingResponseItem_Data) AsPollingResponseItemData0() (PollingResponseItemData0, error) {
+	var body PollingResponseItemData0
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromPollingResponseItemData0 overwrites any union data inside the PollingResponseItem_Data as the provided PollingResponseItemData0
+func (t *PollingResponseItem_Data) FromPollingResponseItemData0(v PollingResponseItemData0) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergePollingResponseItemData0 performs a merge with any union data inside the PollingResponseItem_Data, using the provided PollingResponseItemData0
+func (t *PollingResponseItem_Data) MergePollingResponseItemData0(v PollingResponseItemData0) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsFact returns the union data inside the PollingResponseItem_Data as a Fact
+func (t PollingResponseItem_Data) AsFact() (Fact, error) {
+	var body Fact
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromFact overwrites any union data inside the PollingResponseItem_Data as the provided Fact
+func (t *PollingResponseItem_Data) FromFact(v Fact) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeFact performs a merge with any union data inside the PollingResponseItem_Data, using the provided Fact
+func (t *PollingResponseItem_Data) MergeFact(v Fact) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsEvent returns the union data inside the PollingResponseItem_Data as a Event
+func (t PollingResponseItem_Data) AsEvent() (Event, error) {
+	var body Event
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromEvent overwrites any union data inside the PollingResponseItem_Data as the provided Event
+func (t *PollingResponseItem_Data) FromEvent(v Event) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeEvent performs a merge with any union data inside the PollingResponseItem_Data, using the provided Event
+func (t *PollingResponseItem_Data) MergeEvent(v Event) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsPreference returns the union data inside the PollingResponseItem_Data as a Preference
+func (t PollingResponseItem_Data) AsPreference() (Preference, error) {
+	var body Preference
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromPreference overwrites any union data inside the PollingResponseItem_Data as the provided Preference
+func (t *PollingResponseItem_Data) FromPreference(v Preference) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergePreference performs a merge with any union data inside the PollingResponseItem_Data, using the provided Preference
+func (t *PollingResponseItem_Data) MergePreference(v Preference) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsImageRef returns the union data inside the PollingResponseItem_Data as a ImageRef
+func (t PollingResponseItem_Data) AsImageRef() (ImageRef, error) {
+	var body ImageRef
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromImageRef overwrites any union data inside the PollingResponseItem_Data as the provided ImageRef
+func (t *PollingResponseItem_Data) FromImageRef(v ImageRef) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeImageRef performs a merge with any union data inside the PollingResponseItem_Data, using the provided ImageRef
+func (t *PollingResponseItem_Data) MergeImageRef(v ImageRef) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t PollingResponseItem_Data) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
 }
 
 func (t *PollingResponseItem_Data) UnmarshalJSON(b []byte) error {
@@ -604,6 +1475,15 @@ type ServerInterface interface {
 	// Update a character
 	// (PUT /characters/{characterId})
 	UpdateCharacter(ctx echo.Context, characterId CharacterIdPath) error
+	// Find entities
+	// (GET /characters/{characterId}/graph/entities)
+	ListGraphEntities(ctx echo.Context, characterId CharacterIdPath, params ListGraphEntitiesParams) error
+	// Get the neighbours of an entity
+	// (GET /characters/{characterId}/graph/entities/{entityId}/neighbors)
+	GetGraphNeighbors(ctx echo.Context, characterId CharacterIdPath, entityId EntityIdPath, params GetGraphNeighborsParams) error
+	// Forget memory data
+	// (DELETE /characters/{characterId}/memory)
+	DeleteMemoryIO(ctx echo.Context, characterId CharacterIdPath, params DeleteMemoryIOParams) error
 	// Retrieve memory data
 	// (GET /characters/{characterId}/memory)
 	GetMemoryIO(ctx echo.Context, characterId CharacterIdPath, params GetMemoryIOParams) error
@@ -613,6 +1493,9 @@ type ServerInterface interface {
 	// Sleep memory service
 	// (POST /characters/{characterId}/sleep)
 	PostMemorySleep(ctx echo.Context, characterId CharacterIdPath) error
+	// Get sleep status
+	// (GET /characters/{characterId}/sleep/{sleepId})
+	GetMemorySleepStatus(ctx echo.Context, characterId CharacterIdPath, sleepId SleepIdPath) error
 	// Task processing endpoint
 	// (POST /characters/{characterId}/task)
 	PostMemoryPolling(ctx echo.Context, characterId CharacterIdPath) error
@@ -692,6 +1575,124 @@ func (w *ServerInterfaceWrapper) UpdateCharacter(ctx echo.Context) error {
 	return err
 }
 
+// ListGraphEntities converts echo context to params.
+func (w *ServerInterfaceWrapper) ListGraphEntities(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "characterId" -------------
+	var characterId CharacterIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "characterId", ctx.Param("characterId"), &characterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter characterId: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListGraphEntitiesParams
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", ctx.QueryParams(), &params.Name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// ------------- Optional query parameter "externalId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "externalId", ctx.QueryParams(), &params.ExternalId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter externalId: %s", err))
+	}
+
+	// ------------- Optional query parameter "kind" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "kind", ctx.QueryParams(), &params.Kind)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter kind: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListGraphEntities(ctx, characterId, params)
+	return err
+}
+
+// GetGraphNeighbors converts echo context to params.
+func (w *ServerInterfaceWrapper) GetGraphNeighbors(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "characterId" -------------
+	var characterId CharacterIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "characterId", ctx.Param("characterId"), &characterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter characterId: %s", err))
+	}
+
+	// ------------- Path parameter "entityId" -------------
+	var entityId EntityIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "entityId", ctx.Param("entityId"), &entityId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter entityId: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetGraphNeighborsParams
+	// ------------- Optional query parameter "depth" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "depth", ctx.QueryParams(), &params.Depth)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter depth: %s", err))
+	}
+
+	// ------------- Optional query parameter "minConfidence" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minConfidence", ctx.QueryParams(), &params.MinConfidence)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter minConfidence: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetGraphNeighbors(ctx, characterId, entityId, params)
+	return err
+}
+
+// DeleteMemoryIO converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteMemoryIO(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "characterId" -------------
+	var characterId CharacterIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "characterId", ctx.Param("characterId"), &characterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter characterId: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteMemoryIOParams
+	// ------------- Required query parameter "dType" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "dType", ctx.QueryParams(), &params.DType)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter dType: %s", err))
+	}
+
+	// ------------- Required query parameter "data" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "data", ctx.QueryParams(), &params.Data)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter data: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteMemoryIO(ctx, characterId, params)
+	return err
+}
+
 // GetMemoryIO converts echo context to params.
 func (w *ServerInterfaceWrapper) GetMemoryIO(ctx echo.Context) error {
 	var err error
@@ -712,13 +1713,55 @@ func (w *ServerInterfaceWrapper) GetMemoryIO(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter dType: %s", err))
 	}
 
-	// ------------- Required query parameter "data" -------------
+	// ------------- Optional query parameter "data" -------------
 
-	err = runtime.BindQueryParameter("form", true, true, "data", ctx.QueryParams(), &params.Data)
+	err = runtime.BindQueryParameter("form", true, false, "data", ctx.QueryParams(), &params.Data)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter data: %s", err))
 	}
 
+	// ------------- Optional query parameter "subject" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "subject", ctx.QueryParams(), &params.Subject)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subject: %s", err))
+	}
+
+	// ------------- Optional query parameter "predicate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "predicate", ctx.QueryParams(), &params.Predicate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter predicate: %s", err))
+	}
+
+	// ------------- Optional query parameter "object" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "object", ctx.QueryParams(), &params.Object)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter object: %s", err))
+	}
+
+	// ------------- Optional query parameter "participant" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "participant", ctx.QueryParams(), &params.Participant)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter participant: %s", err))
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", ctx.QueryParams(), &params.Since)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter since: %s", err))
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", ctx.QueryParams(), &params.Until)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter until: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.GetMemoryIO(ctx, characterId, params)
 	return err
@@ -756,6 +1799,30 @@ func (w *ServerInterfaceWrapper) PostMemorySleep(ctx echo.Context) error {
 	return err
 }
 
+// GetMemorySleepStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) GetMemorySleepStatus(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "characterId" -------------
+	var characterId CharacterIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "characterId", ctx.Param("characterId"), &characterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter characterId: %s", err))
+	}
+
+	// ------------- Path parameter "sleepId" -------------
+	var sleepId SleepIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sleepId", ctx.Param("sleepId"), &sleepId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sleepId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetMemorySleepStatus(ctx, characterId, sleepId)
+	return err
+}
+
 // PostMemoryPolling converts echo context to params.
 func (w *ServerInterfaceWrapper) PostMemoryPolling(ctx echo.Context) error {
 	var err error
@@ -814,9 +1881,13 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.DELETE(baseURL+"/characters/:characterId", wrapper.DeleteCharacter)
 	router.GET(baseURL+"/characters/:characterId", wrapper.GetCharacter)
 	router.PUT(baseURL+"/characters/:characterId", wrapper.UpdateCharacter)
+	router.GET(baseURL+"/characters/:characterId/graph/entities", wrapper.ListGraphEntities)
+	router.GET(baseURL+"/characters/:characterId/graph/entities/:entityId/neighbors", wrapper.GetGraphNeighbors)
+	router.DELETE(baseURL+"/characters/:characterId/memory", wrapper.DeleteMemoryIO)
 	router.GET(baseURL+"/characters/:characterId/memory", wrapper.GetMemoryIO)
 	router.PUT(baseURL+"/characters/:characterId/memory", wrapper.PutMemoryIO)
 	router.POST(baseURL+"/characters/:characterId/sleep", wrapper.PostMemorySleep)
+	router.GET(baseURL+"/characters/:characterId/sleep/:sleepId", wrapper.GetMemorySleepStatus)
 	router.POST(baseURL+"/characters/:characterId/task", wrapper.PostMemoryPolling)
 	router.GET(baseURL+"/health", wrapper.GetMemoryHealth)
 
@@ -825,41 +1896,77 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8xa3W/bOBL/VwjeAXcLaGP3rgcs/JY2adfYNMk5yb0sgoKRxja3EqnyI60b+H8/8EMS",
-	"JVG2Eztp3hKRnO/5cTjjB5zyouQMmJJ48oBLIkgBCoT97/2SCJIqENPskqil+ZSBTAUtFeUMT5oNaHqC",
-	"E0zNt9LsTDAjBeAJThsSOMECvmoqIMMTJTQkWKZLKIihO+eiIApPsNbU7FSr0hyXSlC2wOv12hyWJWcS",
-	"rGjvSDaDrxqkMv+lnClg9k9SljlNiRFw9Jc0Uj5g+E6KMge3MzN0p+f/Oz6bnnyenf735vTqGie4ACnJ",
-	"wq6xe5LTDAlHHwU2WYci/13AHE/w30aNCUduVY5OheDCid222DtSEzbUpkyBYCS/AnEPwp16mj7Xp7Pz",
-	"47PPp7PZxaylzjFD1HNB0rJBYPggnqZaGGfsr9U0xsDQPefqA9cse5JS5xfXnz9c3JyftPSZgeRapIAY",
-	"V2huie+vQIxoTdUHnIQTokg/CcwKyogiSCqhU6UFoG9ULVF2vSoBEZbZVZzgUvAShKIuhO3yNolP7CYj",
-	"b5S3WXXk0T3JNeAEcwYXczz586GbQ7cuiaoM/NML4Enf1jnH7/6C1EZnnd4WGlrCpwKIguxYRWSiBUhF",
-	"ihJ9WwJDagmoRgH0jUjkz+KkyfqMKPhV0QL6qZ9gmm1CHs3oVw2IZsAUnVMQId04mlToNEzUrie4oOwM",
-	"2MJA35sIFV1mTzZCTqRCnsCOlui4z2rmBW3cEUq10adnVKqZh9S+f6mCQvbVMocQnze6SAP71d5NgdzE",
-	"0rqWighBVn29LLmo7FbLmlJwAbSFP4R3O0LZIzGZTqosbjMzUIHM5nZgAtOFIafge+ieJqTMuQ+CLIoK",
-	"KfPcZ/Mm49bgtE6igsw9RYtLXCtUgCIWNf6pJWSIsupSkr/gnpK36wTXV1MHBixSP/T1yEARmjtv6Dwn",
-	"dwbZ3aXfM2GN7Q9bnGC5Nftj7nhm20UN58uSX3oIbzZvy4tPZk9XUXvwNuqI34HkajmcuFIRpe1fVawt",
-	"7YmVAXpYCJJZvNGs+hyLQlWhV6s02wjS9yAkdTf5Zid6AWPO+wQFF6vpRZDYu/mwlTV9P3p6SHEkFReA",
-	"CsuoupYjVm4keSRAHhtIMwgZsKhDSLZjaFfoDDR7KnI6fS55nlO2GMTNbToZ2XJQkCFF5BcT+TpXO98A",
-	"be5TBcXhFHqqlxh8q3Rx8IcWgmtTU92t3IJyNdLjNHTifDSk9tbxKgcohzUsHc8bkffV9PKgm9kZmnNR",
-	"xaQ5bmtv2SqVBN1acgTM4gI7vCNZRg19kl8Gsjr0b0v4qYJTR8TlR40+soeovs75THYruSzpp5Sc1lL0",
-	"xxM41Sd35uXrtUdz2r+CbGmZhMZtSRVzdSSZD4XW72Mo04sEszaNPAymdbll8tvW3S16W43iCcdv32iG",
-	"92Swn+Pw0oGVfdHKZLVaUrk3VsXhOMEqXt5eV+waCSxwbrfudSjjxsgKBNs5tDaE1XnHeKYauANUCp6C",
-	"lDaFHh1hTnmoHbNfcN3YlHux501HAvOJsjmvWjUkVQ0z/Af9oRlBF2JBGP1hbw8DEubWwUulSjkZjRZU",
-	"LfXdUcqL0Re7/Vcu/GOgFc6XUyRLSOnct4CsGf+gEr5Q20CiqYUuqnLH2S4cX05xUGXi8dGbo7Ehzktg",
-	"pKR4gv99ND4aGzcStbSGGgXP1MkDXkAEYWegBIV7QATl/nFL8rz9wK0vTBMO9g38PlxuNSb/NR7v0Oza",
-	"rV0Vf6tH2lf9ZzkSXq8MSZ2aCJ/rPLcp/dZJGGNcazIK+qvrBP9nlyOxXqZtoumiIGJVidmzriILaXKj",
-	"6RCYZCi5jHjLdQAQsRkXNFRs2bCEKq4gq5Kg7bpOA8F3pEGqdzxbHc5t8TbFug0Gphxa94LnzeGDJxYw",
-	"DVj4S/8VRUncxwOBsk7CLB89BPOGtQsfc/VH+jP2OyJBEN2t3BSjHTJuYxgy4Yxk4Dpqtoy6M5T1bc/n",
-	"bzeBuVMg5p+3241dt98P552+4QZTeDPe+g6RBdz6PmhRbTviI6hn9cL4pTNvE0L/HN9+BBXNiAGE1hH3",
-	"uhoGEYbgO5XKvD5rev+QyBQY5pniyoe2gzvlz4F8fHh4HyjTdoL3Fw8y/4w7BLz/rKisQmqvu2Dkeh+D",
-	"ZaAJ/bBlZ95p7qXSQ6GqN7h/gCY7Tg3sXP2rBrFqBuvV8G54pL7DWHFAAhq8oUU9WIxK4dqYw0J0nx3P",
-	"ibu9pm0kMz4FPn6NJXJ9PXZa1D7cnfjD8Htl29tWO8oqGrY6birjaFxf6gPG9TMBb3c8sBPiRoqrMAbs",
-	"POA1BcBVbEDR9f5GpJM5QOlaw7En1KVW9qnkWfgHN6JMcWSPosKN2ToBwqUKGtKvuwKLdc6HwaAygdXe",
-	"N2UP5U1n0BafRzvUdpYG/Xml7wqqWhMZ+5sTASnQe+g3DTe41vfeXjkCdMZYEcdeh8aYC14ETeDW9OPl",
-	"6rX4wCoi+3mvydttVP5UfLKm9bKY6h5YVnLK1FBUuzHzpvabFkxaRHJbkRsQV937Xu4M1GNuLv6c3bjO",
-	"5D3iuqsKSyWqpuuHM3wHrLyx0iWkXwLbezPcWuncr/FcBnfbH/eQ89L+rsHtanVzJ6NRzlOSL7lUk9/G",
-	"v41tRnseXVq/B5KE4eBLRC9RZM5TP1IKwsgCrDDVedkQCH85NHCVbybg43F9u/5/AAAA///FjaIz7yoA",
-	"AA==",
+	"H4sIAAAAAAAC/9w8a2/cuLV/hdC9QLuA1uO0W6BwP3k3j/XdxM61ndsPHSOmpaMZ1hKpJSk7s8b89wse",
+	"khIlUfPwIwn6yeORyPPkeXMekkxUteDAtUqOHpKaSlqBBon//bKkkmYa5En+keql+SoHlUlWayZ4ctS9",
+	"QE5eJ2nCzHe1eTNNOK0gOUqyboskTST83jAJeXKkZQNporIlVNTsWwhZUZ0cJU3DzJt6VZvlSkvGF8l6",
+	"nSZvuGZ6NYWJfTqJBrjFT8ThogSop1DAh5MYKLv0SQiszWJVC64A5fMzzc/h9waUNv9lgmvg+JHWdcky",
+	"ahCb/VsZ7B4S+EKrugT7Zm72PTn9v+P3J68/n7/5309vLi6TNKlAKbrAZ/yOliwn0u5PAsVYhyj/t4Qi",
+	"OUr+a9bp0cw+VbM3Ugpp0e5z6mfabmx2O+EaJKflBcg7kHbV4+i5fHN+evz+85vz87PzHjnHnDAHhSgE",
+	"Q8DAISLLGmmE8XSqTmIAzL6nQr8VDc8fRdTp2eXnt2efTl/36DkHJRqZAeFCkwI3fzoBsU3bXZ3CKXhN",
+	"NR0rv3lCcqopUVo2mW4kkHumlyS/XNVAKM/xaZImtRQ1SM2sCuPjbRi/xpcMvlHY5qndntzRsoGUiILo",
+	"JRC73qGgoIRMqyRNBIezIjn618PwgKWb0XhLM731pTd3wLe/9VFCARJ4BltfPanoAs6hSNZX63VoPf7l",
+	"mOfYctXaC3Hzb0BMO/uMtr3H+EwC1ZAf6wg/WQVK06om90vgyMnWjJN7qohbm6Sdxcqphh81q2BsttKE",
+	"5ZtcR8PZ7w0QlhsjXTCQSbrNEnqrOr0pPk+TivH3wBfGXL+K7NLU+aOZUFKlidtgR04MxIeUOUQ7cYRY",
+	"bZTpe6b0uXMHY/kyDZUak2UWmePR0qKStHt3kx62cJN1ixWVkq7GdOF2UdyRynanwHn1kX8O6Q6QwiUx",
+	"nF57C9QHZswcMS8HipmS+yXLlt6UhDbGWRxzEo/ItYYv+nrOmSJoDxlfpOS6oJm+JpQYM5KSazCG4ppQ",
+	"TtBkpOS6bo3CNaFz3tkINJ/XzBiCzxIKXOTNwoF7cE0QWg61hMyoD6Elo2rORdFber8U3lIH6BFL/I35",
+	"aOjABSkx6wtJF5XRBKK0kJCTGyiEhN6m8IUpA3JJ7+Af5F4y3Xs+54wrDTQ/mPMkTYA3lRGJ4ZI5OTQz",
+	"f5Af6B882UYz/R7+cyDC7hgbWb11aKJnLUtn4DcpdOvMjAWOCN8Tjn5MNJpUoCky7s+Ngpww7oMY9UMy",
+	"UqyrNmQd69Yx4SIHrzO3XNyXkC+ALCStlyMXiXKEyFk+00t3Fqwq2iDXiNVsycnNKjzbI671z3CawBcb",
+	"v5zkEVgnr6dgENFoxXIgt0zBLUuJarIlofb110xlQuZz3ihMEgzNlNQgleAp0fQWOCmkqPBl1SDvTnLz",
+	"FtOqlYCa870oifkblyA8ytncMhu8bfT7uP9vzEZiEwaMcsFZRksUmpe/Zeme5iz0HYhe2upJzMgF2I2w",
+	"+ueSamNRWtEGR9RKyqhkSfFAalGzLEkTYXQvehgtqCc5J8TEvLqja7Ign+CX2pxjECNhCB5RuBw0ZSW+",
+	"w5uypDcl+GxutHcbtD9sESlC696P4nnnLVyeM8M0Wn4MMC5oqWAY1V+ICvTSGnaqyZLWNXDIUyIkuWdl",
+	"6b5JidEC4gKXPh9qKjXLWE1dlaAP4BQNkFPmGkRdgjnZBqJZGApxSzg2PMeqqSoqV7usZLHz9k8fuaFv",
+	"cYSqxwVrnjEhKzoMY8LCdGE/WR17G/hjLSE3ySH8aDckSlMNFQYK3sDOk+OSZTBPyDwp2R0owjj+c6bo",
+	"LZ0nIzk63Lazs4W+w7sO493fPIlZZ+d7nIsIvEHUn5CeOzH/HMz5ZbfoT8r5mNBfwQpyIjhhZk/h1pl4",
+	"yATDfAFqzvUSmPWoht8rRQT39tmGLvuYaM+YkJ2pl0FUYV42hInGL66a9MNIV8zL2+zuB/POkGxceBWN",
+	"h96Z+Cb0CoOUawmEA1ssb0Qjl0JgHNA6phGGJmRS8V0klFhXUeQG9D14K2B1wQTTJrpoQakUH1dCaZIJ",
+	"XmBwQAomld7VA507gNG4qo0Cd/NhJjbcRBfkrYNM29g0jM20grJ4Zt/ZysBilzrux9T4V6ClXk47f6Wp",
+	"bvCTjzKWuMLsnsNC0hwT6ob7r68mDD6m57266cYqxB1IxWyZbcvBtQjGaGuLMXvadW4zKqJEBcamqCWV",
+	"xge7tMueRpMPHZBLn345QRrbZQpyNvs6GFfQQkhbbXDBSvDB6ehhxSrw2fCWfRpZ9ivWkm11oWZNAKTP",
+	"pBi3P0Al5OrkLKgT7GYXewnh2Da6/YgWlqukQkC+QhmxXB0me4a0x+Y0GTsWgOjymr5d3vXIBpQ9NuC1",
+	"9HwUZcn4YrIMs40mg1sJxiBpqm6JBNWUeueovQ/9REP1fAQ9Vkoc7j0tiJUiCykaU16+WdkH2qrufhRa",
+	"dN6ZrZ5MI7aZpimsLcxPshyT6fAhn87fk0JIr5NmuXWXqe1dILUL0AHAC2sT0/1OfIDMFoLs/tNkBVlU",
+	"n6Zfm4pyIoHmJgsjNjMzdOiucIu9N1JQVkI0v+880oBfUiwkqDa5wX162THPzRZpIhvO7af2TBheWYhX",
+	"T648WwRtydkGq48sOW9wbR9cwBd3aza57SP6wceTdhNrzFrHrEaeytW4P9PdiMatH9NuQLVmfzwCUrty",
+	"Z1hOjntDenr3oEdlGjK3h1VM1BHL+1yu9ZeYSxhpgnkWSwNP2rKcP3J9F7M9S7cbx9OPqDke4YBfx33B",
+	"wAc81bUYE6yXTD3ZscR9Z5roeGvj0oPrMEAvt527lyGOGzUrQGxn1dqgVqcD5pnQ7QZILUUGSkE+ksYO",
+	"GmaJh1YwT1Surm2xX26A5VefF5TsFpSpzOVM4ecNNZzINoacrn+CDZ4b0ejtjVBluOJrD969eQRaXK42",
+	"l3+GCAmyFGWuBljtgMzj6kTUnijbYHt0rUb4Dx1LooouxR1w6sQ9rOK5wIq2hQi0+fBFY/8yx5bHSLLt",
+	"4x2Dgg1743OfZezsy4qg/rTbOR1wMYAYEhPjX1swGXOPE2yGxbtjKYZ6JoidJ25apa2uEU3lAvQ8Sefc",
+	"Fw2xQkp8dZTYyigfhyau7BMT56/inqhGwmAGgLVhoRdEaszovcENIyGmFbEo9uLmohTooyv6hVXmoL1C",
+	"VbWfD1tW8aa6ATnVzfLse1w/a5/ybr1B0U2pwoYhoHqciKv7bq6tgxfrCCA/Y+3Jfjetj43SVGrl0djK",
+	"HqtG+wKpBTMZvRbbQcR6eY6yFnq/ahzoZ08ksaP1CYO/rzZkMYBvvmK8EO5UadcEscCS39gfDafkTC4o",
+	"Z39YC+CKSclS61odzWYLppfNzUEmqtktvv6jkK7r1rMTH0+IqiFjhRuiQ+v/GzahMY1llp1MlxYyPjj+",
+	"eJIEpcDk8ODVwaHZXNTAac2So+SvB4cHh4bNVC+RUbNgWOboIYnqxjloyeAOCCWl62LSsuyP2bR59knu",
+	"mp2/hI97o51/OTzcYVxwt4G/+MRQZABwPBxEpKMrN22YDJQqmrLEo/jT4eEU4JaSWTChuk6Tv+2yJDYN",
+	"ug6bgRbNEXc1XShzmlpqExOW1UJFpGXnkAjF2C8Y68IEdgleryD3h6AvusEYk5vpBaV/Fvnq+cQWH5Za",
+	"982Hlg2sR8rz6vmVJ6Yw7UOfo39HWhKX8YSirNPwlM8egrH1tVWfEnRsSgy/JzRQohs3hd5XGftiqDLh",
+	"qP1EYtS9MhuO4q+vRjL/aZMxtwTE5PPTdma3A8zPJ50x4yaP8GZ760YxbCfY+4Pern1BvAP9olI4/Non",
+	"b5OF/jayfQc6eiImLHQTEa+NYbD5a0YLTUzd7vcnE8nbCMuGD30BD8KfZ5Lx85v3iTBtJ/P+1ZXMFRSf",
+	"w7x/K630KvUkXzDDJHTWDqhNhYMYorSZAgNnn0IdHiW2orYVo3JlTgynFaQEwnqHJG7SbxxI4mTFm25s",
+	"7okqn8ZmvAwCOGPYz4JSUlGdLSEnGVXwI+MKuGKa3UG58lehfm9Arrq7UPgnvK4yyip2qPt46D7Tp8Ph",
+	"oA4x+EIzPYlNN/faw2lL3jPE0MxWjiY6Y+CcDHe8qxNMlb6os4lMbUYMgtew7zIteGskEIyO+rONZ2O/",
+	"cz178BcG1zM3JrQh9zP+rnfW/ZiOFsFcrRk70rHZJBw7q1LS1GZBDrVeBi/Re7o6IOfBolLck4rxX9qS",
+	"AKES5ryEQptZbCyEUleu6swPL7FesSIlUIOZnWcbxUbIrNOW5OewJFuW9O51rtNY9a2ifDVgiWFVKcTt",
+	"xCFDLvZOWQ4FNa2oo1dB6e2vQemtO+SMa1igRxxh817cQzAllsGwBqgMYoUoS3E/gVpPdHEUDx9fLhyj",
+	"/EEo3UdPgm4k3zz0FkG8ZBXTcYT/dhgg+JfDwy1sfUlD1p8xjNiw03bs73mt2LeMtnU4OamGY5N7GULb",
+	"utgh5w0HmtDR+rtDC3YH3PYXjc3DK5kT+bAfq3qBiCV2f2vKWLi25vTF7B0up05ggNRbmyAXoFPyPxdn",
+	"pwR4JnJTGBeyuy+bI7pqCkk7IDaN4zBC2alA8CEQ4nSJ4Jt5dOTZcDrPabNFfbpC8K6/Epv3qxoO7H8F",
+	"43lwwYgoVrGSSnTZpJAAOI055yiFf7ghHCcoUrASi6Oo9qRgYLqcohiJcs5d5GkHzrXxvUrjULkoLB7Y",
+	"sLT7mY6SF+8BeWthmOCAapILnAE1ZhFdn8HHnjAJRIIpvUM+4dL/8w8ZC8ZGZGtwNpyiPTKPC9sZtvlb",
+	"Qc0n2e9mx+B0/eQ9QH1sG5ktsIntw9bQHgDOHkOKeAQlxyS4J+PdkbvfGaWne3vPvJDKkuE8CKtgB0CK",
+	"DYOu3SavRnEg1XtAbbhm5f5QXzJMGg00RyKl0Dl8jwlfWwPe6iCiNcYLHP1G6hj3e2ALqGv/uKGsvk39",
+	"2DyjTX2h6uJwdH6nsuKWAMHd//5+FOAiNrw/lP7GaNdO9B49TPQJP7qbNQ6E6yoTxrVwQ8WVyCMKIlQ4",
+	"O/19txliU+XTxsCzAKl38xLPJU3L0B6cxwl09uB+bWk9WbMxg4puMK2b8aZOqDgvYqfg65EoJ+Kr/pT8",
+	"i1dNwl+i+lrqMZjRjyjJhWefbtR32phSAYp7axeOiU5ai4vmpmK6dxcGM2AJGbA7GE8AbzAcbpD2O/cv",
+	"gwtEEY24DJmBo4ndRHdLu0q+ZssrflUoWikayGs0dfxNvR+y1uFiai/Ac5xBm9Jqe51y0wRTI7md47Ov",
+	"+oPsSpwjyzxhCe39z5ccaBrcMI0ZIu+pFfG3SJ+P8QNX6JiVLSG7DXjv2HCF2CnczJ7gYTXtDkpR4y1t",
+	"+1ZvIO5oNitFRsulUPro74d/P8QT7WCMauUBJqE6uDTEPo5d2mj7vBXldAGIjF+vug3Cn4CaCBQ3b2Bf",
+	"iqz+rd8MHXdL/ZjpAWl7UH+2vzWREvxtEpXOOf44ifphutVivq2wZjKYl8ZsmPJ8zoPx+a46ZH99YIUr",
+	"XRRsYNwvWTkcC0b/og7m/Di4fY+/udT9vA1TeD/Nj+fjDv3fK3CTaFSbElLb+jS/P0U13LnhTMI0yWhZ",
+	"KkvWjfuNAsdrW+1dX63/fwCVHhKV5FMAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file